@@ -0,0 +1,53 @@
+package ai
+
+import (
+	"fatbot/db"
+	"time"
+
+	"github.com/spf13/viper"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// TokenUsage counts the tokens a group's AI replies used in a month. Chat 0
+// is for what isn't tied to a group, like PSAs.
+type TokenUsage struct {
+	ID          uint   `gorm:"primarykey"`
+	GroupChatID int64  `gorm:"uniqueIndex:idx_token_usage_month"`
+	Month       string `gorm:"uniqueIndex:idx_token_usage_month"` // 2006-01
+	Tokens      int
+	UpdatedAt   time.Time
+}
+
+func usageMonth(now time.Time) string {
+	return now.UTC().Format("2006-01")
+}
+
+// overBudget reports whether the group used up ai.monthly_group_tokens this
+// month. Without a limit, for chat 0 or when the usage can't be read, it
+// never is.
+func overBudget(groupChatId int64) bool {
+	limit := viper.GetInt("ai.monthly_group_tokens")
+	if limit <= 0 || groupChatId == 0 || db.DBCon == nil {
+		return false
+	}
+	var usage TokenUsage
+	err := db.DBCon.Where("group_chat_id = ? AND month = ?", groupChatId, usageMonth(time.Now())).
+		Limit(1).Find(&usage).Error
+	return err == nil && usage.Tokens >= limit
+}
+
+// recordUsage adds the tokens to the group's usage this month.
+func recordUsage(groupChatId int64, tokens int) error {
+	if tokens <= 0 || db.DBCon == nil {
+		return nil
+	}
+	usage := TokenUsage{GroupChatID: groupChatId, Month: usageMonth(time.Now()), Tokens: tokens}
+	return db.DBCon.Clauses(clause.OnConflict{
+		Columns: []clause.Column{{Name: "group_chat_id"}, {Name: "month"}},
+		DoUpdates: clause.Assignments(map[string]any{
+			"tokens":     gorm.Expr("token_usages.tokens + ?", tokens),
+			"updated_at": time.Now(),
+		}),
+	}).Create(&usage).Error
+}
@@ -0,0 +1,62 @@
+package ai
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"sync"
+	"time"
+)
+
+// The completion cache lives in memory rather than the state store: state
+// imports spotlight, which imports ai, so it can't be used from here.
+var (
+	cacheMu sync.Mutex
+	cache   = map[string]cachedCompletion{}
+)
+
+type cachedCompletion struct {
+	content   string
+	expiresAt time.Time
+}
+
+// cacheKey keys a completion by its prompt and everything sent for it, so a
+// reply is only reused for the exact same request.
+func cacheKey(name string, request Request) string {
+	body, _ := json.Marshal(request)
+	sum := sha256.Sum256(body)
+	return name + ":" + hex.EncodeToString(sum[:])
+}
+
+func getCached(key string) (string, bool) {
+	cacheMu.Lock()
+	defer cacheMu.Unlock()
+	entry, ok := cache[key]
+	if !ok {
+		return "", false
+	}
+	if !time.Now().Before(entry.expiresAt) {
+		delete(cache, key)
+		return "", false
+	}
+	return entry.content, true
+}
+
+func setCached(key string, content string, ttl time.Duration) {
+	cacheMu.Lock()
+	defer cacheMu.Unlock()
+	now := time.Now()
+	for k, entry := range cache {
+		if !now.Before(entry.expiresAt) {
+			delete(cache, k)
+		}
+	}
+	cache[key] = cachedCompletion{content: content, expiresAt: now.Add(ttl)}
+}
+
+// ClearCache drops every cached completion, e.g. between tests.
+func ClearCache() {
+	cacheMu.Lock()
+	defer cacheMu.Unlock()
+	cache = map[string]cachedCompletion{}
+}
@@ -0,0 +1,156 @@
+package ai
+
+import (
+	"context"
+	"errors"
+	"fatbot/metrics"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/charmbracelet/log"
+	"github.com/getsentry/sentry-go"
+	"github.com/spf13/viper"
+)
+
+// prompt is a prompt from ai.prompts.<name> in config.yaml. The user and
+// system messages have {placeholders} that complete fills in. Model only
+// applies to ai.provider, fallbacks use their own. Replies are reused for
+// CacheMinutes when it's set.
+type prompt struct {
+	Model        string
+	Temperature  float32
+	System       string
+	User         string
+	CacheMinutes int
+}
+
+func getPrompt(name string) (p prompt, ok bool) {
+	key := "ai.prompts." + name
+	p = prompt{
+		Model:       viper.GetString(key + ".model"),
+		Temperature: float32(viper.GetFloat64(key + ".temperature")),
+		System:      viper.GetString(key + ".system"),
+		User:        viper.GetString(key + ".user"),
+
+		CacheMinutes: viper.GetInt(key + ".cache_minutes"),
+	}
+	return p, p.User != ""
+}
+
+var (
+	providersMu sync.Mutex
+	providers   []Provider
+)
+
+// SetProviders replaces the configured providers, tried in order, e.g. with
+// fakes in tests.
+func SetProviders(newProviders ...Provider) {
+	providersMu.Lock()
+	defer providersMu.Unlock()
+	providers = newProviders
+}
+
+// getProviders sets up ai.provider and ai.fallback from config.yaml the first
+// time they're needed.
+func getProviders() []Provider {
+	providersMu.Lock()
+	defer providersMu.Unlock()
+	if providers != nil {
+		return providers
+	}
+	providers = []Provider{}
+	for _, name := range []string{viper.GetString("ai.provider"), viper.GetString("ai.fallback")} {
+		if name == "" {
+			continue
+		}
+		provider, err := NewProvider(name)
+		if err != nil {
+			log.Error("Can't set up ai provider", "err", err)
+			continue
+		}
+		providers = append(providers, provider)
+	}
+	return providers
+}
+
+// complete runs the named prompt for a group and returns the reply, or
+// fallback when every provider failed, the prompt isn't configured or the
+// group went over its monthly token budget. Each provider gets
+// ai.timeout_seconds per attempt and ai.retries more attempts. A cached reply
+// costs no tokens.
+func complete(name string, groupChatId int64, fallback string, args ...string) string {
+	p, ok := getPrompt(name)
+	if !ok {
+		log.Warn("AI prompt is not configured", "prompt", name)
+		return fallback
+	}
+	if overBudget(groupChatId) {
+		log.Info("Group is over its AI budget, using a canned reply", "group", groupChatId, "prompt", name)
+		return fallback
+	}
+	replacer := strings.NewReplacer(args...)
+	var messages []Message
+	if p.System != "" {
+		messages = append(messages, Message{Role: "system", Content: replacer.Replace(p.System)})
+	}
+	messages = append(messages, Message{Role: "user", Content: replacer.Replace(p.User)})
+
+	for i, provider := range getProviders() {
+		request := Request{Model: provider.Model(), Temperature: p.Temperature, Messages: messages}
+		// The prompt's model is one of the primary provider's, a fallback
+		// wouldn't know it
+		if i == 0 && p.Model != "" {
+			request.Model = p.Model
+		}
+		key := cacheKey(name, request)
+		if p.CacheMinutes > 0 {
+			if content, ok := getCached(key); ok {
+				return content
+			}
+		}
+		resp, err := completeWithRetries(provider, name, request)
+		if err != nil {
+			log.Errorf("%s completion of %s failed: %v", provider.Name(), name, err)
+			sentry.CaptureException(err)
+			continue
+		}
+		if err := recordUsage(groupChatId, resp.Tokens); err != nil {
+			log.Warn("Failed to record AI token usage", "group", groupChatId, "err", err)
+		}
+		if p.CacheMinutes > 0 {
+			setCached(key, resp.Content, time.Duration(p.CacheMinutes)*time.Minute)
+		}
+		return resp.Content
+	}
+	return fallback
+}
+
+func completeWithRetries(provider Provider, name string, request Request) (resp Response, err error) {
+	timeout := time.Duration(viper.GetInt("ai.timeout_seconds")) * time.Second
+	if timeout <= 0 {
+		timeout = 15 * time.Second
+	}
+	attempts := 1 + max(viper.GetInt("ai.retries"), 0)
+	for attempt := 1; attempt <= attempts; attempt++ {
+		if attempt > 1 {
+			time.Sleep(time.Duration(attempt-1) * retryBackoff)
+		}
+		ctx, cancel := context.WithTimeout(context.Background(), timeout)
+		start := time.Now()
+		resp, err = provider.Complete(ctx, request)
+		cancel()
+		if err == nil && strings.TrimSpace(resp.Content) == "" {
+			err = errors.New("empty completion")
+		}
+		metrics.ObserveAI(provider.Name(), name, start, err)
+		if err == nil {
+			return resp, nil
+		}
+	}
+	return resp, err
+}
+
+// retryBackoff is the wait before the second attempt, and grows by as much
+// for each one after.
+var retryBackoff = 500 * time.Millisecond
@@ -0,0 +1,141 @@
+package ai
+
+import (
+	"context"
+	"errors"
+	"fatbot/db"
+	"testing"
+
+	"github.com/spf13/viper"
+	"gorm.io/driver/sqlite"
+	"gorm.io/gorm"
+	"gorm.io/gorm/logger"
+)
+
+type fakeProvider struct {
+	name    string
+	replies []string // An empty reply fails
+	calls   int
+	request Request
+}
+
+func (fake *fakeProvider) Name() string  { return fake.name }
+func (fake *fakeProvider) Model() string { return fake.name + "-model" }
+
+func (fake *fakeProvider) Complete(ctx context.Context, request Request) (Response, error) {
+	fake.request = request
+	reply := fake.replies[min(fake.calls, len(fake.replies)-1)]
+	fake.calls++
+	if reply == "" {
+		return Response{}, errors.New("provider is down")
+	}
+	return Response{Content: reply, Tokens: 60}, nil
+}
+
+func setupCompleteTest(t *testing.T) {
+	database, err := gorm.Open(sqlite.Open("file:"+t.Name()+"?mode=memory&cache=shared"),
+		&gorm.Config{Logger: logger.Default.LogMode(logger.Silent)})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := database.AutoMigrate(&TokenUsage{}); err != nil {
+		t.Fatal(err)
+	}
+	db.DBCon = database
+	retryBackoff = 0
+	viper.Set("ai.retries", 1)
+	viper.Set("ai.monthly_group_tokens", 100)
+	viper.Set("ai.prompts.greet.user", "Greet {name}")
+	t.Cleanup(func() {
+		viper.Set("ai.retries", nil)
+		viper.Set("ai.monthly_group_tokens", nil)
+		viper.Set("ai.prompts.greet.user", nil)
+		SetProviders()
+		ClearCache()
+	})
+}
+
+func TestCompleteRetriesAndFallsBack(t *testing.T) {
+	setupCompleteTest(t)
+	primary := &fakeProvider{name: "primary", replies: []string{""}}
+	secondary := &fakeProvider{name: "secondary", replies: []string{"", "Hi Dana"}}
+	SetProviders(primary, secondary)
+
+	if got := complete("greet", -100, "canned", "{name}", "Dana"); got != "Hi Dana" {
+		t.Errorf("got %q, want the second provider's reply on its retry", got)
+	}
+	if primary.calls != 2 || secondary.calls != 2 {
+		t.Errorf("got %d and %d calls, want two attempts each", primary.calls, secondary.calls)
+	}
+	if content := secondary.request.Messages[0].Content; content != "Greet Dana" {
+		t.Errorf("sent %q, want the prompt filled in", content)
+	}
+	if secondary.request.Model != "secondary-model" {
+		t.Errorf("sent model %q, want the provider's", secondary.request.Model)
+	}
+
+	viper.Set("ai.prompts.greet.model", "gpt-4o")
+	defer viper.Set("ai.prompts.greet.model", nil)
+	primary.calls, secondary.calls = 0, 0
+	complete("greet", -100, "canned", "{name}", "Dana")
+	if primary.request.Model != "gpt-4o" || secondary.request.Model != "secondary-model" {
+		t.Errorf("sent models %q and %q, want the prompt's to the primary provider only",
+			primary.request.Model, secondary.request.Model)
+	}
+
+	down := &fakeProvider{name: "down", replies: []string{""}}
+	SetProviders(down)
+	if got := complete("greet", -100, "canned", "{name}", "Dana"); got != "canned" {
+		t.Errorf("got %q, want the canned reply when every provider fails", got)
+	}
+	if got := complete("missing", -100, "canned"); got != "canned" {
+		t.Errorf("got %q, want the canned reply for a prompt that isn't configured", got)
+	}
+}
+
+func TestCompleteCache(t *testing.T) {
+	setupCompleteTest(t)
+	provider := &fakeProvider{name: "primary", replies: []string{"Hi Dana", "Hello Dana", "Hey Dana", "Hi Sam"}}
+	SetProviders(provider)
+	viper.Set("ai.monthly_group_tokens", 0)
+
+	complete("greet", -100, "canned", "{name}", "Dana")
+	if got := complete("greet", -100, "canned", "{name}", "Dana"); got != "Hello Dana" {
+		t.Errorf("got %q, want a new reply without cache_minutes", got)
+	}
+
+	viper.Set("ai.prompts.greet.cache_minutes", 10)
+	defer viper.Set("ai.prompts.greet.cache_minutes", nil)
+	complete("greet", -100, "canned", "{name}", "Dana")
+	if got := complete("greet", -100, "canned", "{name}", "Dana"); got != "Hey Dana" {
+		t.Errorf("got %q, want the cached reply", got)
+	}
+	if got := complete("greet", -100, "canned", "{name}", "Sam"); got != "Hi Sam" {
+		t.Errorf("got %q, want a reply for the new message", got)
+	}
+	if provider.calls != 4 {
+		t.Errorf("got %d calls, want the cached reply to skip the provider", provider.calls)
+	}
+}
+
+func TestCompleteMonthlyBudget(t *testing.T) {
+	setupCompleteTest(t)
+	provider := &fakeProvider{name: "openai", replies: []string{"Hi"}}
+	SetProviders(provider)
+
+	// 60 tokens each, the limit is 100
+	complete("greet", -100, "canned")
+	complete("greet", -100, "canned")
+	if got := complete("greet", -100, "canned"); got != "canned" {
+		t.Errorf("got %q, want the canned reply over budget", got)
+	}
+	if provider.calls != 2 {
+		t.Errorf("got %d calls, want none over budget", provider.calls)
+	}
+	if got := complete("greet", -200, "canned"); got != "Hi" {
+		t.Errorf("got %q, other groups have their own budget", got)
+	}
+	if got := complete("greet", 0, "canned"); got != "Hi" {
+		t.Errorf("got %q, chat 0 has no budget", got)
+	}
+}
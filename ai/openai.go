@@ -1,144 +1,60 @@
 package ai
 
 import (
-	"context"
+	"fatbot/i18n"
 	"fmt"
-	"os"
+	"math/rand"
 	"strings"
-
-	"github.com/charmbracelet/log"
-	"github.com/getsentry/sentry-go"
-	openai "github.com/sashabaranov/go-openai"
 )
 
-func getOpenAIToken() string {
-	token := os.Getenv("OPENAI_APITOKEN")
-	if token == "" {
-		token = os.Getenv("OPENAI_API_KEY")
+// respondIn asks for a reply in the group's language. English prompts get the
+// best results, so only the reply language changes.
+func respondIn(lang i18n.Lang) string {
+	if lang == i18n.English {
+		return ""
 	}
-	return token
+	return fmt.Sprintf(" Respond in %s.", lang.EnglishName())
 }
 
-func GetAiResponse(labels []string) string {
-	client := openai.NewClient(getOpenAIToken())
-	resp, err := client.CreateChatCompletion(
-		context.Background(),
-		openai.ChatCompletionRequest{
-			Temperature: 1.2,
-			Model:       openai.GPT3Dot5Turbo,
-			Messages: []openai.ChatCompletionMessage{
-				{
-					Role:    openai.ChatMessageRoleUser,
-					Content: fmt.Sprintf("You are funny David Goggins. Write a response to a user after their workout, congratulating them for their effort and enoucraging them to continue working out, address this list of words in your response: %s. Keep it under 100 characters. End the message with emojis matching the words from the list.", labels),
-				},
-			},
-		},
-	)
-
-	if err != nil {
-		log.Errorf("ChatCompletion error: %v\n", err)
-		sentry.CaptureException(err)
+// cannedReply is one of the key.1, key.2... templates, for when there's no AI
+// reply.
+func cannedReply(lang i18n.Lang, key string) string {
+	variants := i18n.Variants(lang, key)
+	if len(variants) == 0 {
 		return ""
 	}
-	return resp.Choices[0].Message.Content
+	return variants[rand.Intn(len(variants))]
 }
 
-func GetAiWhoopResponse(sport string, strain float64, calories float64, hr int, duration float64) string {
-	client := openai.NewClient(getOpenAIToken())
-	resp, err := client.CreateChatCompletion(
-		context.Background(),
-		openai.ChatCompletionRequest{
-			Temperature: 1.2,
-			Model:       openai.GPT3Dot5Turbo,
-			Messages: []openai.ChatCompletionMessage{
-				{
-					Role:    openai.ChatMessageRoleUser,
-					Content: fmt.Sprintf("You are funny David Goggins. Write a response to a user after their %s workout. Metrics: Strain %.1f, Calories %.0f, Avg HR %d, Duration %.0f mins. Congratulate them on the effort using the metrics. Keep it under 100 characters. End with emojis.", sport, strain, calories, hr, duration),
-				},
-			},
-		},
+func GetAiResponse(lang i18n.Lang, groupChatId int64, labels []string) string {
+	return complete("workout_response", groupChatId, cannedReply(lang, "ai.workout"),
+		"{labels}", strings.Join(labels, ", "),
+		"{respond_in}", respondIn(lang),
 	)
-
-	if err != nil {
-		log.Errorf("ChatCompletion error: %v\n", err)
-		sentry.CaptureException(err)
-		return ""
-	}
-	return resp.Choices[0].Message.Content
 }
 
-func GetAiWelcomeResponse() string {
-	client := openai.NewClient(getOpenAIToken())
-	resp, err := client.CreateChatCompletion(
-		context.Background(),
-		openai.ChatCompletionRequest{
-			Temperature: 1.2,
-			Model:       openai.GPT3Dot5Turbo,
-			Messages: []openai.ChatCompletionMessage{
-				{
-					Role:    openai.ChatMessageRoleUser,
-					Content: "You are funny David Goggins. Write a response to a user after their workout, welcoming them back. Keep it under 100 characters.",
-				},
-			},
-		},
+func GetAiWhoopResponse(lang i18n.Lang, groupChatId int64, sport string, strain float64, calories float64, hr int, duration float64) string {
+	return complete("whoop_response", groupChatId, cannedReply(lang, "ai.workout"),
+		"{sport}", sport,
+		"{strain}", fmt.Sprintf("%.1f", strain),
+		"{calories}", fmt.Sprintf("%.0f", calories),
+		"{heart_rate}", fmt.Sprint(hr),
+		"{duration}", fmt.Sprintf("%.0f", duration),
+		"{respond_in}", respondIn(lang),
 	)
-
-	if err != nil {
-		log.Errorf("ChatCompletion error: %v\n", err)
-		sentry.CaptureException(err)
-		return ""
-	}
-	return resp.Choices[0].Message.Content
 }
 
-func GetAiMotivationalTitle() string {
-	client := openai.NewClient(getOpenAIToken())
-	resp, err := client.CreateChatCompletion(
-		context.Background(),
-		openai.ChatCompletionRequest{
-			Temperature: 1.2,
-			Model:       openai.GPT3Dot5Turbo,
-			Messages: []openai.ChatCompletionMessage{
-				{
-					Role:    openai.ChatMessageRoleUser,
-					Content: "Write a single, extremely impactful, high-energy motivational word or short phrase (max 2 words) for a fitness hero. Examples: UNSTOPPABLE, BEAST MODE, RELENTLESS. Capitalized.",
-				},
-			},
-		},
+func GetAiWelcomeResponse(lang i18n.Lang, groupChatId int64) string {
+	return complete("welcome", groupChatId, cannedReply(lang, "ai.welcome"),
+		"{respond_in}", respondIn(lang),
 	)
+}
 
-	if err != nil {
-		log.Errorf("ChatCompletion error: %v\n", err)
-		sentry.CaptureException(err)
-		return "UNSTOPPABLE"
-	}
-	return strings.ToUpper(strings.Trim(resp.Choices[0].Message.Content, `".`))
+func GetAiMotivationalTitle(groupChatId int64) string {
+	title := complete("motivational_title", groupChatId, "UNSTOPPABLE")
+	return strings.ToUpper(strings.Trim(title, `".`))
 }
 
 func StylizePSA(message string) string {
-	client := openai.NewClient(getOpenAIToken())
-	resp, err := client.CreateChatCompletion(
-		context.Background(),
-		openai.ChatCompletionRequest{
-			Temperature: 0.7,
-			Model:       openai.GPT3Dot5Turbo,
-			Messages: []openai.ChatCompletionMessage{
-				{
-					Role:    openai.ChatMessageRoleSystem,
-					Content: "You are a professional assistant for FatBot, a Telegram bot for workout tracking. Your task is to stylize, organize, and format PSA messages to make them easy to read and easy on the eye. Use clear language and proper Telegram Markdown formatting (bolding with *, italics with _). Do NOT use MarkdownV2 specific characters or escaping. The message should be professional yet motivating and ready for a group announcement.",
-				},
-				{
-					Role:    openai.ChatMessageRoleUser,
-					Content: fmt.Sprintf("Please stylize this PSA message:\n\n%s", message),
-				},
-			},
-		},
-	)
-
-	if err != nil {
-		log.Errorf("ChatCompletion error: %v\n", err)
-		sentry.CaptureException(err)
-		return message
-	}
-	return resp.Choices[0].Message.Content
+	return complete("stylize_psa", 0, message, "{message}", message)
 }
@@ -0,0 +1,115 @@
+package ai
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"strings"
+
+	openai "github.com/sashabaranov/go-openai"
+	"github.com/spf13/viper"
+)
+
+// Message is a chat message to a language model, with the role "system" or
+// "user".
+type Message struct {
+	Role    string
+	Content string
+}
+
+type Request struct {
+	Model       string
+	Temperature float32
+	Messages    []Message
+}
+
+type Response struct {
+	Content string
+	// Tokens is how many tokens the request and the response used together.
+	Tokens int
+}
+
+// Provider is a language model backend.
+type Provider interface {
+	Name() string
+	// Model is the model requests use unless they name one.
+	Model() string
+	Complete(ctx context.Context, request Request) (Response, error)
+}
+
+// OpenAIToken is the OpenAI API key from the environment.
+func OpenAIToken() string {
+	token := os.Getenv("OPENAI_APITOKEN")
+	if token == "" {
+		token = os.Getenv("OPENAI_API_KEY")
+	}
+	return token
+}
+
+// OpenAICompatible talks to OpenAI or to any server with the same chat
+// completions API, like Ollama or vLLM.
+type OpenAICompatible struct {
+	name   string
+	model  string
+	client *openai.Client
+}
+
+// NewProvider sets up the provider configured under ai.<name> in
+// config.yaml: openai, or local for an OpenAI-compatible server at
+// ai.local.base_url.
+func NewProvider(name string) (Provider, error) {
+	switch name {
+	case "openai":
+		config := openai.DefaultConfig(OpenAIToken())
+		if baseURL := viper.GetString("ai.openai.base_url"); baseURL != "" {
+			config.BaseURL = baseURL
+		}
+		return newOpenAICompatible(name, config), nil
+	case "local":
+		baseURL := viper.GetString("ai.local.base_url")
+		if baseURL == "" {
+			return nil, errors.New("ai.local.base_url is not set")
+		}
+		// Local servers mostly ignore the key, but the client always sends one
+		config := openai.DefaultConfig(os.Getenv("AI_LOCAL_APITOKEN"))
+		config.BaseURL = strings.TrimSuffix(baseURL, "/")
+		return newOpenAICompatible(name, config), nil
+	}
+	return nil, fmt.Errorf("unknown ai provider %s, expected openai or local", name)
+}
+
+func newOpenAICompatible(name string, config openai.ClientConfig) *OpenAICompatible {
+	return &OpenAICompatible{
+		name:   name,
+		model:  viper.GetString("ai." + name + ".model"),
+		client: openai.NewClientWithConfig(config),
+	}
+}
+
+func (provider *OpenAICompatible) Name() string {
+	return provider.name
+}
+
+func (provider *OpenAICompatible) Model() string {
+	return provider.model
+}
+
+func (provider *OpenAICompatible) Complete(ctx context.Context, request Request) (Response, error) {
+	messages := make([]openai.ChatCompletionMessage, 0, len(request.Messages))
+	for _, message := range request.Messages {
+		messages = append(messages, openai.ChatCompletionMessage{Role: message.Role, Content: message.Content})
+	}
+	resp, err := provider.client.CreateChatCompletion(ctx, openai.ChatCompletionRequest{
+		Model:       request.Model,
+		Temperature: request.Temperature,
+		Messages:    messages,
+	})
+	if err != nil {
+		return Response{}, err
+	}
+	if len(resp.Choices) == 0 {
+		return Response{}, errors.New("no choices in the completion")
+	}
+	return Response{Content: resp.Choices[0].Message.Content, Tokens: resp.Usage.TotalTokens}, nil
+}
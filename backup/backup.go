@@ -0,0 +1,183 @@
+package backup
+
+import (
+	"compress/gzip"
+	"context"
+	"fatbot/db"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/spf13/viper"
+	"gorm.io/driver/sqlite"
+	"gorm.io/gorm"
+	"gorm.io/gorm/logger"
+)
+
+const keyTimeFormat = "20060102T150405Z"
+
+// Object is a stored backup.
+type Object struct {
+	Key          string
+	Size         int64
+	LastModified time.Time
+}
+
+// Store is where backups are kept. S3Store works with AWS and any
+// S3-compatible service such as MinIO.
+type Store interface {
+	Put(key string, body io.ReadSeeker) error
+	List() ([]Object, error)
+	Get(key string) (io.ReadCloser, error)
+	Delete(key string) error
+}
+
+// Retention keeps every backup younger than KeepDays, and the newest MinKeep
+// whatever their age so a stopped job doesn't empty the bucket.
+type Retention struct {
+	KeepDays int
+	MinKeep  int
+}
+
+func RetentionFromConfig() Retention {
+	return Retention{
+		KeepDays: viper.GetInt("backup.retention.keep_days"),
+		MinKeep:  viper.GetInt("backup.retention.min_keep"),
+	}
+}
+
+// NewKey names a backup taken at the given time.
+func NewKey(at time.Time) string {
+	return fmt.Sprintf("%sfat-%s.db.gz", viper.GetString("backup.prefix"), at.UTC().Format(keyTimeFormat))
+}
+
+// Create takes a consistent snapshot of the SQLite database with VACUUM INTO,
+// gzips it and uploads it under key. Writers aren't blocked while it runs.
+func Create(ctx context.Context, store Store, key string) (Object, error) {
+	if db.Dialect() != db.SQLite {
+		return Object{}, fmt.Errorf("backups only support SQLite, %s has its own tooling", db.Dialect())
+	}
+	dir, err := os.MkdirTemp("", "fatbot-backup")
+	if err != nil {
+		return Object{}, err
+	}
+	defer os.RemoveAll(dir)
+
+	snapshot := filepath.Join(dir, "fat.db")
+	if err := db.DBCon.WithContext(ctx).Exec("VACUUM INTO ?", snapshot).Error; err != nil {
+		return Object{}, fmt.Errorf("snapshot: %w", err)
+	}
+	compressed, err := os.Create(snapshot + ".gz")
+	if err != nil {
+		return Object{}, err
+	}
+	defer compressed.Close()
+	if err := compress(snapshot, compressed); err != nil {
+		return Object{}, fmt.Errorf("compress: %w", err)
+	}
+	size, err := compressed.Seek(0, io.SeekCurrent)
+	if err != nil {
+		return Object{}, err
+	}
+	if _, err := compressed.Seek(0, io.SeekStart); err != nil {
+		return Object{}, err
+	}
+	if err := store.Put(key, compressed); err != nil {
+		return Object{}, fmt.Errorf("upload %s: %w", key, err)
+	}
+	return Object{Key: key, Size: size, LastModified: time.Now()}, nil
+}
+
+func compress(path string, w io.Writer) error {
+	f, err := os.Open(path)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+	writer := gzip.NewWriter(w)
+	if _, err := io.Copy(writer, f); err != nil {
+		return err
+	}
+	return writer.Close()
+}
+
+// Newest returns the backups sorted from newest to oldest.
+func Newest(store Store) ([]Object, error) {
+	objects, err := store.List()
+	if err != nil {
+		return nil, err
+	}
+	sort.Slice(objects, func(i, j int) bool { return objects[i].LastModified.After(objects[j].LastModified) })
+	return objects, nil
+}
+
+// Expired returns the backups the retention policy no longer keeps.
+// objects must be sorted newest first.
+func Expired(objects []Object, now time.Time, retention Retention) (expired []Object) {
+	if retention.KeepDays <= 0 {
+		return nil
+	}
+	cutoff := now.AddDate(0, 0, -retention.KeepDays)
+	for i, object := range objects {
+		if i >= retention.MinKeep && object.LastModified.Before(cutoff) {
+			expired = append(expired, object)
+		}
+	}
+	return expired
+}
+
+// Restore downloads the backup to path and checks it's a sound database.
+// It never overwrites, the bot has to be stopped and the file moved in place.
+func Restore(store Store, key string, path string) error {
+	if _, err := os.Stat(path); err == nil {
+		return fmt.Errorf("%s already exists", path)
+	}
+	body, err := store.Get(key)
+	if err != nil {
+		return fmt.Errorf("download %s: %w", key, err)
+	}
+	defer body.Close()
+	reader, err := gzip.NewReader(body)
+	if err != nil {
+		return err
+	}
+	partial := path + ".partial"
+	f, err := os.Create(partial)
+	if err != nil {
+		return err
+	}
+	defer os.Remove(partial)
+	if _, err := io.Copy(f, reader); err != nil {
+		f.Close()
+		return err
+	}
+	if err := f.Close(); err != nil {
+		return err
+	}
+	if err := checkIntegrity(partial); err != nil {
+		return fmt.Errorf("%s is not a sound database: %w", key, err)
+	}
+	return os.Rename(partial, path)
+}
+
+func checkIntegrity(path string) error {
+	database, err := gorm.Open(sqlite.Open(path), &gorm.Config{Logger: logger.Default.LogMode(logger.Silent)})
+	if err != nil {
+		return err
+	}
+	if sqlDB, err := database.DB(); err == nil {
+		defer sqlDB.Close()
+	}
+	var result string
+	if err := database.Raw("PRAGMA quick_check").Scan(&result).Error; err != nil {
+		return err
+	}
+	if !strings.EqualFold(result, "ok") {
+		return fmt.Errorf("%s", result)
+	}
+	return nil
+}
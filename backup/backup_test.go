@@ -0,0 +1,110 @@
+package backup
+
+import (
+	"bytes"
+	"context"
+	"fatbot/db"
+	"fmt"
+	"io"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"gorm.io/driver/sqlite"
+	"gorm.io/gorm"
+	"gorm.io/gorm/logger"
+)
+
+type memoryStore map[string][]byte
+
+func (store memoryStore) Put(key string, body io.ReadSeeker) error {
+	data, err := io.ReadAll(body)
+	store[key] = data
+	return err
+}
+
+func (store memoryStore) List() (objects []Object, err error) {
+	for key, data := range store {
+		objects = append(objects, Object{Key: key, Size: int64(len(data))})
+	}
+	return objects, nil
+}
+
+func (store memoryStore) Get(key string) (io.ReadCloser, error) {
+	data, ok := store[key]
+	if !ok {
+		return nil, fmt.Errorf("no such key %s", key)
+	}
+	return io.NopCloser(bytes.NewReader(data)), nil
+}
+
+func (store memoryStore) Delete(key string) error {
+	delete(store, key)
+	return nil
+}
+
+type note struct {
+	ID   uint
+	Text string
+}
+
+func TestCreateAndRestore(t *testing.T) {
+	dir := t.TempDir()
+	database, err := gorm.Open(sqlite.Open(filepath.Join(dir, "fat.db")), &gorm.Config{Logger: logger.Default.LogMode(logger.Silent)})
+	if err != nil {
+		t.Fatal(err)
+	}
+	database.AutoMigrate(&note{})
+	database.Create(&note{Text: "kept"})
+	db.DBCon = database
+
+	store := memoryStore{}
+	object, err := Create(context.Background(), store, "backups/fat.db.gz")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if object.Size == 0 || object.Size != int64(len(store[object.Key])) {
+		t.Errorf("got size %d, stored %d bytes", object.Size, len(store[object.Key]))
+	}
+
+	restored := filepath.Join(dir, "restored.db")
+	if err := Restore(store, object.Key, restored); err != nil {
+		t.Fatal(err)
+	}
+	if err := Restore(store, object.Key, restored); err == nil {
+		t.Error("expected restore not to overwrite an existing file")
+	}
+	check, err := gorm.Open(sqlite.Open(restored), &gorm.Config{Logger: logger.Default.LogMode(logger.Silent)})
+	if err != nil {
+		t.Fatal(err)
+	}
+	var notes []note
+	check.Find(&notes)
+	if len(notes) != 1 || notes[0].Text != "kept" {
+		t.Errorf("got %+v from the restored database", notes)
+	}
+}
+
+func TestExpired(t *testing.T) {
+	now := time.Date(2024, 5, 18, 12, 0, 0, 0, time.UTC)
+	var objects []Object
+	for days := 0; days < 40; days += 5 {
+		objects = append(objects, Object{Key: fmt.Sprint(days), LastModified: now.AddDate(0, 0, -days)})
+	}
+	tests := []struct {
+		name      string
+		retention Retention
+		want      int
+	}{
+		{"by age", Retention{KeepDays: 20}, 3},
+		{"min keep wins", Retention{KeepDays: 10, MinKeep: 7}, 1},
+		{"disabled", Retention{}, 0},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			if got := Expired(objects, now, test.retention); len(got) != test.want {
+				t.Errorf("got %d expired, want %d", len(got), test.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,100 @@
+package backup
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"strings"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/session"
+	"github.com/aws/aws-sdk-go/service/s3"
+	"github.com/spf13/viper"
+)
+
+// S3Store keeps backups in a bucket under backup.prefix. Setting
+// backup.endpoint (BACKUP_ENDPOINT) points it at an S3-compatible service.
+type S3Store struct {
+	client *s3.S3
+	bucket string
+	prefix string
+}
+
+// NewS3Store uses backup.bucket (BACKUP_BUCKET). There's no falling back to
+// the image bucket, which is public while the dumps hold access tokens.
+// Credentials come from the usual AWS environment.
+func NewS3Store() (*S3Store, error) {
+	bucket := viper.GetString("backup.bucket")
+	if bucket == "" {
+		return nil, fmt.Errorf("backup.bucket not configured")
+	}
+	region := viper.GetString("backup.region")
+	if region == "" {
+		region = os.Getenv("AWS_REGION")
+	}
+	if region == "" {
+		region = "us-east-1"
+	}
+	config := &aws.Config{Region: aws.String(region)}
+	if endpoint := viper.GetString("backup.endpoint"); endpoint != "" {
+		// MinIO and most other S3-compatible services need path-style URLs
+		config.Endpoint = aws.String(endpoint)
+		config.S3ForcePathStyle = aws.Bool(true)
+	}
+	sess, err := session.NewSession(config)
+	if err != nil {
+		return nil, err
+	}
+	return &S3Store{client: s3.New(sess), bucket: bucket, prefix: viper.GetString("backup.prefix")}, nil
+}
+
+func (store *S3Store) Put(key string, body io.ReadSeeker) error {
+	_, err := store.client.PutObject(&s3.PutObjectInput{
+		Bucket:      aws.String(store.bucket),
+		Key:         aws.String(key),
+		Body:        body,
+		ContentType: aws.String("application/gzip"),
+		ACL:         aws.String(s3.ObjectCannedACLPrivate),
+	})
+	return err
+}
+
+func (store *S3Store) List() (objects []Object, err error) {
+	err = store.client.ListObjectsV2Pages(&s3.ListObjectsV2Input{
+		Bucket: aws.String(store.bucket),
+		Prefix: aws.String(store.prefix),
+	}, func(page *s3.ListObjectsV2Output, lastPage bool) bool {
+		for _, object := range page.Contents {
+			key := aws.StringValue(object.Key)
+			if !strings.HasSuffix(key, ".db.gz") {
+				continue
+			}
+			objects = append(objects, Object{
+				Key:          key,
+				Size:         aws.Int64Value(object.Size),
+				LastModified: aws.TimeValue(object.LastModified),
+			})
+		}
+		return true
+	})
+	return objects, err
+}
+
+func (store *S3Store) Get(key string) (io.ReadCloser, error) {
+	output, err := store.client.GetObject(&s3.GetObjectInput{
+		Bucket: aws.String(store.bucket),
+		Key:    aws.String(key),
+	})
+	if err != nil {
+		return nil, err
+	}
+	return output.Body, nil
+}
+
+func (store *S3Store) Delete(key string) error {
+	_, err := store.client.DeleteObject(&s3.DeleteObjectInput{
+		Bucket: aws.String(store.bucket),
+		Key:    aws.String(key),
+	})
+	return err
+}
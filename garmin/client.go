@@ -4,6 +4,7 @@ import (
 	"crypto/sha256"
 	"encoding/base64"
 	"encoding/json"
+	"fatbot/metrics"
 	"fmt"
 	"io"
 	"math/rand"
@@ -18,13 +19,13 @@ import (
 
 // httpClient is shared across all Garmin API calls to avoid leaking idle
 // TCP connections that result from creating &http.Client{} per request.
-var httpClient = &http.Client{
+var httpClient = metrics.InstrumentClient("garmin", &http.Client{
 	Timeout: 30 * time.Second,
 	Transport: &http.Transport{
 		MaxIdleConns:    5,
 		IdleConnTimeout: 60 * time.Second,
 	},
-}
+})
 
 type TokenResponse struct {
 	AccessToken  string `json:"access_token"`
@@ -44,6 +45,7 @@ type ActivityData struct {
 	Calories           float64 `json:"calories"`
 	DistanceInMeters   float64 `json:"distanceInMeters"`
 	AverageHeartRate   int     `json:"averageHeartRateInBeatsPerMinute"`
+	MaxHeartRate       int     `json:"maxHeartRateInBeatsPerMinute"`
 }
 
 func NormalizeSummaryID(summaryID string) string {
@@ -122,7 +124,7 @@ func ExchangeToken(code, verifier string) (*TokenResponse, error) {
 	data.Set("redirect_uri", redirectURI)
 
 	tokenURL := getBaseTokenURL() + "/di-oauth2-service/oauth/token"
-	resp, err := http.PostForm(tokenURL, data)
+	resp, err := httpClient.PostForm(tokenURL, data)
 	if err != nil {
 		return nil, err
 	}
@@ -151,7 +153,7 @@ func RefreshToken(refreshToken string) (*TokenResponse, error) {
 	data.Set("client_secret", clientSecret)
 
 	tokenURL := getBaseTokenURL() + "/di-oauth2-service/oauth/token"
-	resp, err := http.PostForm(tokenURL, data)
+	resp, err := httpClient.PostForm(tokenURL, data)
 	if err != nil {
 		return nil, err
 	}
@@ -199,6 +201,32 @@ func FetchActivityByPullURI(pullURI, accessToken string) ([]ActivityData, error)
 	return activities, nil
 }
 
+// RequestBackfill asks Garmin to send the activities in the time range again.
+// They arrive through the activities webhook like new ones, the response has
+// no data.
+func RequestBackfill(accessToken string, start, end time.Time) error {
+	url := fmt.Sprintf("%s/wellness-api/rest/backfill/activities?summaryStartTimeInSeconds=%d&summaryEndTimeInSeconds=%d",
+		getBaseAPIURL(), start.Unix(), end.Unix())
+	req, err := http.NewRequest("GET", url, nil)
+	if err != nil {
+		return err
+	}
+	req.Header.Add("Authorization", "Bearer "+accessToken)
+
+	resp, err := httpClient.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	// 409 means the same range was already requested
+	if resp.StatusCode != http.StatusAccepted && resp.StatusCode != http.StatusConflict {
+		body, _ := io.ReadAll(resp.Body)
+		return fmt.Errorf("failed to request backfill: %s, body: %s", resp.Status, string(body))
+	}
+	return nil
+}
+
 func GetUserID(accessToken string) (string, error) {
 	// Identity endpoint to get the real permanent User ID used in webhooks
 	url := "https://healthapi.garmin.com/wellness-api/rest/user/id"
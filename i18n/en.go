@@ -0,0 +1,319 @@
+package i18n
+
+// english is the reference catalog, every key has to be here. Other catalogs
+// may leave keys out, those fall back to English.
+var english = map[string]string{
+	// Durations
+	"days.one":            "1 day",
+	"days.other":          "{count} days",
+	"hours.one":           "1 hour",
+	"hours.other":         "{count} hours",
+	"duration.days_hours": "{days}, {hours}",
+	"ago.hours.one":       "1 hour ago",
+	"ago.hours.other":     "{count} hours ago",
+	"ago.days_hours":      "{days} and {hours} ago",
+	"weekday.0":           "Sunday",
+	"weekday.1":           "Monday",
+	"weekday.2":           "Tuesday",
+	"weekday.3":           "Wednesday",
+	"weekday.4":           "Thursday",
+	"weekday.5":           "Friday",
+	"weekday.6":           "Saturday",
+
+	// Buttons
+	"common.yes":       "Yes",
+	"common.no":        "No",
+	"common.cancel":    "Cancel",
+	"common.cancelled": "Cancelled",
+
+	// Private commands
+	"start":                    "Welcome to FatBot! Use /join to join a group.",
+	"help":                     "Join a group: /join\nCreate your own group: /creategroup\nCheck your status: /status\nView stats: /stats\nLog a workout without a photo: /log\nSet a weekly goal: /goal\nCancel your last workout (within a few minutes): /cancel\nChange your language: /language\nDownload your data: /export\nDelete your account: /delete_me",
+	"command.unknown":          "Unknown command",
+	"private.try_help":         "Try /help",
+	"user.unregistered":        "You are not registered.",
+	"user.load_failed":         "Failed to load user.",
+	"user.blocked":             "You are blocked from using this bot.",
+	"rank.no_history":          "No workout history yet.",
+	"rank.highest":             "Current Rank: {rank} (highest rank!)",
+	"rank.next":                "Current Rank: {rank}\nDays until next rank ({next}): {days}",
+	"status.no_workout":        "I don't have your last workout yet.",
+	"status.overdue":           "{name}, your last workout was on {weekday}\nYou are overdue for your workout!",
+	"status.days_left.one":     "{name}, your last workout was on {weekday}\nYou have 1 day left to workout.",
+	"status.days_left.other":   "{name}, your last workout was on {weekday}\nYou have {count} days left to workout.",
+	"status.goal":              "Weekly goal: {done}/{goal}",
+	"join.welcome":             "Welcome!\nYou'll get a link to join the group soon.\nOnce you join, you have {grace} to post your first workout photo in the group chat.\nAfter that, post at least once every {window} to stay in!",
+	"join.already_active":      "You are already active",
+	"join.wait":                "{name}, it's only been {hours}, you have to wait {wait}",
+	"cancel.no_group":          "You are not in any group.",
+	"cancel.nothing":           "You don't have any workouts to cancel.",
+	"cancel.too_late":          "Your last workout was {ago} minutes ago — you can only cancel within {window} minutes.",
+	"cancel.group":             "{name} cancelled their last workout from {time}.",
+	"cancel.done":              "Cancelled your workout from {time}.",
+	"instagram.missing_handle": "Please provide your Instagram handle: `/instagram your_handle`",
+	"instagram.registered":     "Awesome! I've registered your Instagram handle @{handle} and enabled daily automated stories. 🔥",
+	"instagram.disabled":       "Daily automated Instagram stories disabled. 🫡",
+	"language.current":         "Your language is {language}. To change it, send /language followed by one of:\n{languages}",
+	"language.unsupported":     "I don't speak that one yet. Send /language followed by one of:\n{languages}",
+	"language.changed":         "Done, I'll talk to you in English from now on. Group messages follow the language the group admin picked.",
+	"admin.unauthorized":       "You are not authorized to use admin commands. Only group administrators can use this feature.",
+	"admin.unauthorized_again": "Warning: This is your {attempts} attempt to access admin commands. Continued unauthorized attempts may result in being blocked.",
+	"admin.unauthorized_final": "FINAL WARNING: Your repeated attempts to access admin features have been logged. Further attempts will result in being blocked from using this bot.",
+
+	// Account
+	"account.no_data":                        "I don't have any data about you",
+	"account.export":                         "Here's everything I have about you: your profile, groups, workouts, events and rank, as JSON and CSV.",
+	"account.delete.confirm":                 "This removes you from all your groups, disconnects Whoop, Garmin and Strava, stops Instagram stories and keeps your workouts in the group history without your name.\nIt can't be undone, you may want to /export your data first. Delete your account?",
+	"account.delete.confirm_delete_workouts": "This removes you from all your groups, disconnects Whoop, Garmin and Strava, stops Instagram stories and deletes your workouts.\nIt can't be undone, you may want to /export your data first. Delete your account?",
+	"account.delete.button":                  "Yes, delete my account",
+	"account.delete.failed":                  "Something went wrong deleting your account, please contact /support",
+	"account.delete.done":                    "Your account was deleted. Take care 👋",
+
+	// Support
+	"support.unavailable":     "Support is currently unavailable. Please try again later.",
+	"support.cooldown":        "Please wait before sending another support message.",
+	"support.prompt":          "Please type your support message. You can ask for help or suggest a feature:",
+	"support.text_only_retry": "Support messages currently only accept text. Please use /support and describe your issue in words.",
+	"support.text_only":       "Support messages currently only accept text.",
+	"support.failed":          "Failed to send your message. Please try again.",
+	"support.sent":            "Your message has been sent to our support team. You'll receive a reply here.\n\nTo continue the conversation, reply directly to the support message you receive.",
+	"support.reply":           "Support reply:\n\n{reply}",
+	"support.follow_up_sent":  "Your follow-up has been sent to support.",
+
+	// Group setup
+	"creategroup.disabled":   "Group creation is currently disabled.",
+	"creategroup.limit":      "You already have a group. Each user can create one group.",
+	"creategroup.steps":      "Let's create your group!\n\nFollow these steps:\n\n1. Open Telegram and create a new group\n   Give it a short name (e.g. \"Warriors\")\n\n2. Add @{bot} to the group\n\n3. Make @{bot} an admin:\n   Tap the group name > Edit > Administrators > Add @{bot}\n\n4. Tap @{bot} > turn ON \"Remain Anonymous\" > then turn it back OFF\n\nThat's it! I'll set everything up automatically and send you an invite link to share with friends.",
+	"setup.added_group":      "Thanks for adding me!\n\nI need to be an admin to work. Here's how:\n\n1. Tap the group name > Edit > Administrators\n2. Add @{bot} as admin\n3. Turn ON \"Remain Anonymous\" for @{bot}\n4. Turn it back OFF\n\nThis converts the group so I can manage it. I'll finish setup automatically!",
+	"setup.added_supergroup": "Thanks for adding me!\n\nOne step left - make me an admin:\nTap the group name > Edit > Administrators > Add @{bot}\n\nI'll finish setup automatically!",
+	"setup.convert":          "Almost there!\n\n1. Tap the group name > Edit > Administrators\n2. Tap @{bot} > turn ON \"Remain Anonymous\"\n3. Turn it back OFF\n\nThis converts the group so I can manage it. I'll finish setup automatically!",
+	"setup.activated":        "Group activated!\n\nHow it works:\n- Post a workout photo every {window}\n- Miss the deadline = banned (you can rejoin after {rejoin})\n- Everyone starts with a {grace} grace period\n\n{admin} is the group admin.\nIMPORTANT❗: Do not add other users yourself, share the link below with them to register them to the group.\n{link}",
+	"setup.creator":          "You're the admin of \"{title}\"!\n\nSend this link to friends you want to invite:\n{link}\n\nWhen they click it, you'll get a message to approve them.\n\nYour admin tools (type /admin in our private chat):\n- Group Link: get a fresh invite link anytime\n- Show Users: see all members\n- Ban User / Rejoin User: manage members\n- Push Workout: credit a workout for someone\n- Close Group: shut down the group\n\nNeed help? Type /admin anytime to see all options.",
+	"setup.removed":          "I was removed from \"{title}\". The group has been deactivated.\n\nYou can create a new group anytime with /creategroup.",
+	"group.not_activated":    "Group {title} not activated, send this to the admin: `{chat_id}`",
+
+	// Bans and invites
+	"ban.group": "{name} was not working out. 🦥⛔",
+	"ban.dm.one": `{name} you were banned from the group
+after not working out.
+You can rejoin after 1 hour:
+
+1. Tap this: /join
+2. Wait for approval
+3. Get a link to join the group
+
+*NOTICE!!* After joining the group, you
+will have 60 minutes to send a workout
+in the group chat.`,
+	"ban.dm.other": `{name} you were banned from the group
+after not working out.
+You can rejoin after {count} hours:
+
+1. Tap this: /join
+2. Wait for approval
+3. Get a link to join the group
+
+*NOTICE!!* After joining the group, you
+will have 60 minutes to send a workout
+in the group chat.`,
+	"ban.lifted": "Your ban was lifted by an admin, you can rejoin the group here: {link}",
+	"invite.new": "You're invited to join! You have {grace} to post your first workout photo in the group. After that, post at least once every {window} to stay in. Here's your link: {link}",
+
+	// Scheduled warnings
+	"strike.warning":         "{mention} you have {left} to workout",
+	"strike.end_of_day":      "until the end of the day",
+	"strike.days_left.one":   "one day left",
+	"strike.days_left.other": "{count} days left",
+	"strike.immunity":        "Saved because of immunity: {name}",
+	"nudge.comeback":         "Maybe it's time to comeback?\nTap: /join",
+
+	// Disputes
+	"dispute.question":     "Cancel workout by {name} from {time}?",
+	"dispute.explanation":  "Vote to decide if this workout should be cancelled",
+	"dispute.cancelled":    "The group has decided to cancel {name}'s workout from {time}.\nVotes: Yes: {yes}, No: {no} (Required: {required})",
+	"dispute.cancelled_dm": "Your workout from {time} was disputed and cancelled by group vote.",
+	"dispute.kept":         "The group has decided to keep {name}'s workout.\nVotes: Yes: {yes}, No: {no} (Required: {required})",
+
+	// Reports
+	"report.weekly.title":                   "Weekly summary:",
+	"report.weekly.leader.one":              "{name} is the ⭐ with 1 workout!",
+	"report.weekly.leader.other":            "{name} is the ⭐ with {count} workouts!",
+	"report.weekly.leaders.one":             "⭐ Leaders of the week with 1 workout:",
+	"report.weekly.leaders.other":           "⭐ Leaders of the week with {count} workouts:",
+	"report.monthly_standings":              "Monthly standings:",
+	"report.monthly_standings.first.one":    "🥇 {name} is leading with 1 workout",
+	"report.monthly_standings.first.other":  "🥇 {name} is leading with {count} workouts",
+	"report.monthly_standings.second.one":   "🥈 {name} is in second place with 1 workout",
+	"report.monthly_standings.second.other": "🥈 {name} is in second place with {count} workouts",
+	"report.group_rank":                     "Your group averaged {average} workouts per member this week. You're ranked {rank}/{groups} among active groups!",
+	"report.first_week":                     "This is your first recorded week! Your record has been set - try to break it next week!",
+	"report.best_week":                      "This is your best week in recorded history!",
+	"report.below_best":                     "You were {diff} points away from your best week ever ({best}).",
+	"report.goals":                          "🎯 Weekly goals reached: {hit}/{count}",
+	"report.leader_message":                 "🎤 {mention}, as this week's first leader, please share your weekly message as a reply to this message",
+	"report.leader_thanks":                  "Thanks for your weekly message, {name}! It has been pinned until next week's winner is announced.",
+	"report.chart.last_week":                "Last Week",
+	"report.chart.workouts":                 "Workouts",
+	"report.monthly.one":                    "Monthly summary:\n🥇 {name} has won this month with 1 workout!\nThey get immunity 🛡️",
+	"report.monthly.other":                  "Monthly summary:\n🥇 {name} has won this month with {count} workouts!\nThey get immunity 🛡️",
+	"rankings.title":                        "📊 Mid-Week Power Rankings 📊",
+	"rankings.empty":                        "No workout data available yet this week.",
+	"rankings.leaderboard":                  "🏆 Current Leaderboard:",
+	"rankings.entry.one":                    "{name}: 1 workout",
+	"rankings.entry.other":                  "{name}: {count} workouts",
+	"rankings.comeback":                     "🔥 Comeback Player: {name} (+{improvement} vs last week!)",
+	"rankings.close_race.one":               "⚡ CLOSE RACE! {players} players tied at 1 workout!",
+	"rankings.close_race.other":             "⚡ CLOSE RACE! {players} players tied at {count} workouts!",
+	"rankings.win_probability":              "💪 Win Probability:",
+	"rankings.contender.leading":            "{name}: Leading! One more workout seals it 🏆",
+	"rankings.contender.one_behind":         "{name}: 1 workout behind - still in the game! 🎯",
+	"rankings.contender.two_behind":         "{name}: 2 workouts needed - you've got time! ⏰",
+	"rankings.goals":                        "🎯 Weekly goals reached so far: {hit}/{count}",
+
+	// Workout replies
+	"workout.first":          "{name} nice work!\nThis is your first workout",
+	"workout.great_work":     "Great work!",
+	"workout.stats":          "{name} {cheer}\nYour rank: {rank}\nLast workout: {weekday} ({ago})\nThis week: {week}\n{streak}",
+	"workout.streak":         "{count} days in a row! {crowns} {cheer}",
+	"workout.streak_record":  "Longest streak: {longest} · 🧊 Freezes left: {freezes}",
+	"workout.photo_prompt":   "Great job on your {sport} workout!\n\nReply to this message with a photo to send it to all your groups.",
+	"duplicate.warning":      "⚠️ {name}, this photo looks like one already posted on {date}. Please post a fresh photo of each workout.",
+	"duplicate.refused":      "{name}, this photo looks like one already posted on {date}, so it doesn't count. Post a fresh photo of your workout.",
+	"review.pending":         "👀 This photo doesn't quite look like a workout, so an admin will take a look. It counts until then.",
+	"review.rejected":        "Your workout from {date} in {group} was rejected by the group admins, the photo didn't look like a workout. Use /support if you think it's a mistake.",
+	"photo.prompt":           "Nice photo! What would you like to do with it?",
+	"photo.prompt.now":       "Send to groups now",
+	"photo.prompt.save":      "Save for next workout",
+	"photo.prompt.nothing":   "Nothing",
+	"photo.discarded":        "No problem! The photo won't be saved.",
+	"photo.expired":          "Sorry, the photo timed out. Please send it again.",
+	"photo.no_account":       "Sorry, I couldn't find your account. Please try again.",
+	"photo.save_failed":      "Sorry, I couldn't save the photo. Please try again.",
+	"photo.saved":            "Got it! I'll attach this photo to your next workout upload automatically.",
+	"photo.sent_saved.one":   "Sent photo to 1 group and saved for your daily progress! 📸",
+	"photo.sent_saved.other": "Sent photo to {count} groups and saved for your daily progress! 📸",
+	"photo.sent.one":         "Sent to 1 group!",
+	"photo.sent.other":       "Sent to {count} groups!",
+	"streak.cheer.1":         "Keep up the streak, superhero!",
+	"streak.cheer.2":         "You're killing the streak, champ!",
+	"streak.cheer.3":         "Streaking! Keep pushing yourself!",
+	"streak.cheer.4":         "Streaking is your new hobby!",
+	"streak.cheer.5":         "Keep the streak alive, warrior!",
+	"streak.cheer.6":         "Keep up the streak, rockstar!",
+	"streak.cheer.7":         "Streaker alert! You're amazing!",
+	"streak.cheer.8":         "Keep the streak alive, legend!",
+	"streak.cheer.9":         "Keep up the streak, athlete!",
+	"streak.cheer.10":        "Streaking! Keep it up, superstar!",
+	"streak.cheer.11":        "Your streak is inspiring, warrior!",
+	"streak.cheer.12":        "Keep up the streak, fitness guru!",
+	"streak.cheer.13":        "Streaking! You got this, champ!",
+	"streak.cheer.14":        "Keep the streak going, legend!",
+	"streak.cheer.15":        "Streaking! Never give up, warrior!",
+	"streak.cheer.16":        "Keep up the streak, fitness freak!",
+	"streak.cheer.17":        "Streaking is your new lifestyle!",
+	"streak.cheer.18":        "Keep the streak alive, ironman!",
+	"streak.cheer.19":        "Streaking! Stay committed, superstar!",
+	"streak.cheer.20":        "Keep up the streak, fitness queen/king!",
+
+	// Canned replies for when the AI is down or over budget
+	"ai.workout.1": "Another one in the books. Stay hard! 💪🔥",
+	"ai.workout.2": "Nobody's coming to do it for you, and you showed up. 🏃💯",
+	"ai.workout.3": "Callus that mind, then come back tomorrow. 🧠⚡",
+	"ai.welcome.1": "welcome back! The work doesn't wait, good to see you on it. 💪",
+	"ai.welcome.2": "you're back, now keep showing up. 🔥",
+
+	// Workout announcements
+	"announce.completed":         "🏋️ {name} just completed a workout!",
+	"announce.completed_sport":   "🏋️ {name} just completed a {sport} workout!",
+	"announce.bonus":             "🏃 {name} added a bonus activity: {sport}",
+	"announce.bonus_not_counted": "(This activity is not counted as another workout.)",
+	"announce.activity":          "Activity: {value}",
+	"announce.activity_type":     "Activity Type: {value}",
+	"announce.distance":          "Distance: {value}",
+	"announce.pace":              "Pace: {value}",
+	"announce.time":              "Time: {value}",
+	"announce.duration":          "Duration: {value}",
+	"announce.avg_hr":            "Avg HR: {value}",
+	"announce.calories":          "Calories: {value}",
+	"announce.strain":            "Strain: {value}",
+	"announce.relative_effort":   "Relative Effort: {value} (~{strain} strain)",
+	"announce.device":            "Device: {value}",
+	"announce.manual_badge":      "✍️ Manual log",
+	"announce.manual":            "{name} logged {minutes} min of {activity}",
+
+	// Manual logs
+	"log.no_group":            "Join a group first, then you can log workouts with /log.",
+	"log.activity":            "What did you do?",
+	"log.activity.run":        "Run",
+	"log.activity.ride":       "Ride",
+	"log.activity.walk":       "Walk",
+	"log.activity.swim":       "Swim",
+	"log.activity.strength":   "Strength",
+	"log.activity.cardio":     "Cardio",
+	"log.activity.mobility":   "Mobility",
+	"log.activity.sport":      "Sport",
+	"log.activity.other":      "Other",
+	"log.duration":            "{activity} — how long?",
+	"log.note":                "Send a short note about it, or skip.",
+	"log.note_skip":           "Skip",
+	"log.note_text_only":      "Please send the note as text, or press Skip.",
+	"log.groups":              "Which groups should it count in?",
+	"log.submit":              "Log it",
+	"log.logging":             "Logging…",
+	"log.expired":             "This log expired. Start again with /log.",
+	"log.no_groups_picked":    "Pick at least one group.",
+	"log.result.logged":       "✅ {group}: logged",
+	"log.result.pending":      "⏳ {group}: waiting for an admin to approve",
+	"log.result.off":          "🚫 {group}: doesn't take manual logs",
+	"log.result.capped.one":   "🚫 {group}: only 1 manual log a week",
+	"log.result.capped.other": "🚫 {group}: only {count} manual logs a week",
+	"log.result.too_soon":     "🚫 {group}: too soon after your last workout",
+	"log.result.failed":       "⚠️ {group}: something went wrong, please try again",
+	"log.approved":            "Your {minutes} min of {activity} was approved in {group} ✅",
+	"log.rejected":            "Your {minutes} min of {activity} wasn't approved in {group}.",
+	"log.refused":             "Your {minutes} min of {activity} was approved, but can't be logged anymore:\n{reason}",
+
+	// Weekly goals
+	"goal.no_group":   "Join a group first, then you can set a weekly goal with /goal.",
+	"goal.none":       "You don't have a weekly goal yet. Commit to a number of workouts a week with /goal followed by it, like /goal 4.",
+	"goal.current":    "Your weekly goals:\n{goals}\n\nChange one with /goal followed by the number of workouts, /goal 0 clears it.",
+	"goal.progress":   "🎯 {group}: {done}/{goal} this week",
+	"goal.invalid":    "Send /goal followed by the number of workouts a week, from 0 to {max}, like /goal 4.",
+	"goal.pick_group": "Which group is the goal for?",
+	"goal.set":        "Your weekly goal in {group} is set: {done}/{goal} so far this week 🎯",
+	"goal.cleared":    "Your weekly goal in {group} is cleared.",
+	"goal.nudge":      "{name}, you're at {done}/{goal} workouts this week in {group}. {days} left to reach your goal 💪",
+
+	// Workout providers
+	"provider.connect.whoop":               "Connect your Whoop account to automatically sync workouts.",
+	"provider.connect.whoop.button":        "Connect Whoop",
+	"provider.connect.garmin":              "Connect your Garmin account to automatically sync workouts.",
+	"provider.connect.garmin.button":       "Connect Garmin",
+	"provider.connect.strava":              "Connect your Strava account to automatically sync workouts.\n\nNote: This will disconnect any existing Whoop or Garmin integration.",
+	"provider.connect.strava.button":       "Connect with Strava",
+	"provider.connected.whoop":             "Your Whoop account has been connected successfully!\n\nYour workouts will now be automatically synced. Note: Any previous Strava or Garmin integration has been disconnected.",
+	"provider.connected.garmin":            "Your Garmin account has been connected successfully!\n\nYour workouts will now be automatically synced. Note: Any previous Strava or Whoop integration has been disconnected.",
+	"provider.connected.strava":            "Your Strava account{athlete} has been connected successfully!\n\nYour workouts will now be automatically synced. Note: Any previous Whoop or Garmin integration has been disconnected.",
+	"provider.detected.whoop":              "I detected a workout: {sport}. Should this count as a workout?",
+	"provider.detected.garmin":             "I detected a Garmin activity: {activity}. Should this count as a workout?",
+	"provider.detected.strava_second":      "I detected a Strava activity: {activity} ({sport}). This is your second workout today. Should this count as a workout?",
+	"provider.detected.ignored":            "Understood. I won't report this activity.",
+	"provider.detected.already_registered": "This workout was already registered automatically.",
+	"provider.detected.failed":             "Sorry, there was an error processing your workout. Please try again.",
+	"provider.garmin.bulk_sync":            "I detected an unusually large Garmin sync and only kept the newest activity to prevent accidental bulk workout uploads.",
+	"provider.garmin.permissions_revoked":  "Your Garmin account has been disconnected because all permissions were revoked.",
+	"provider.garmin.disconnected":         "Your Garmin account has been disconnected.",
+	"provider.garmin.data_by":              "Data provided by Garmin",
+	"provider.strava.revoked":              "Your Strava account has been disconnected because you revoked access in Strava settings.",
+	"provider.strava.powered_by":           "Powered by Strava",
+	"provider.strava.view":                 "View on Strava",
+
+	// Instagram spotlight
+	"spotlight.no_handle":     "Set your Instagram handle first with /instagram your_handle 📸",
+	"spotlight.not_own":       "You can only request a spotlight for your own photos 🙅",
+	"spotlight.cooldown":      "You've already been featured recently! You can request again in {remaining} ⏳",
+	"spotlight.cooldown_soon": "You've already been featured recently! Try again in a bit ⏳",
+	"spotlight.processing":    "Your spotlight is already being processed, hang tight! ⚙️",
+	"spotlight.started":       "📸 Got it! Generating your Instagram spotlight... this may take a minute.",
+	"spotlight.featured":      "🚀 BOOM! You've been featured on FatBot Instagram!\n\nHandle: @{handle}\nStatus: {status}\nWorkouts: {workouts}\n\nCheck out the central account to see your spotlight! 💪\nhttps://www.instagram.com/fatbot.fit",
+}
@@ -0,0 +1,331 @@
+package i18n
+
+// hebrew adds the .two plural form, like "יומיים" for two days.
+var hebrew = map[string]string{
+	// Durations
+	"days.one":            "יום אחד",
+	"days.two":            "יומיים",
+	"days.other":          "{count} ימים",
+	"hours.one":           "שעה אחת",
+	"hours.two":           "שעתיים",
+	"hours.other":         "{count} שעות",
+	"duration.days_hours": "{days} ו-{hours}",
+	"ago.hours.one":       "לפני שעה",
+	"ago.hours.two":       "לפני שעתיים",
+	"ago.hours.other":     "לפני {count} שעות",
+	"ago.days_hours":      "לפני {days} ו-{hours}",
+	"weekday.0":           "יום ראשון",
+	"weekday.1":           "יום שני",
+	"weekday.2":           "יום שלישי",
+	"weekday.3":           "יום רביעי",
+	"weekday.4":           "יום חמישי",
+	"weekday.5":           "יום שישי",
+	"weekday.6":           "שבת",
+
+	// Buttons
+	"common.yes":       "כן",
+	"common.no":        "לא",
+	"common.cancel":    "ביטול",
+	"common.cancelled": "בוטל",
+
+	// Private commands
+	"start":                    "ברוכים הבאים ל-FatBot! שלחו /join כדי להצטרף לקבוצה.",
+	"help":                     "הצטרפות לקבוצה: /join\nיצירת קבוצה משלך: /creategroup\nבדיקת סטטוס: /status\nסטטיסטיקות: /stats\nרישום אימון בלי תמונה: /log\nהגדרת יעד שבועי: /goal\nביטול האימון האחרון (תוך כמה דקות): /cancel\nשינוי שפה: /language\nהורדת הנתונים שלך: /export\nמחיקת החשבון: /delete_me",
+	"command.unknown":          "פקודה לא מוכרת",
+	"private.try_help":         "נסו /help",
+	"user.unregistered":        "אינך רשום.",
+	"user.load_failed":         "טעינת המשתמש נכשלה.",
+	"user.blocked":             "נחסמת משימוש בבוט הזה.",
+	"rank.no_history":          "עדיין אין היסטוריית אימונים.",
+	"rank.highest":             "דרגה נוכחית: {rank} (הדרגה הגבוהה ביותר!)",
+	"rank.next":                "דרגה נוכחית: {rank}\nימים עד הדרגה הבאה ({next}): {days}",
+	"status.no_workout":        "עדיין אין לי את האימון האחרון שלך.",
+	"status.overdue":           "{name}, האימון האחרון שלך היה ב{weekday}\nעבר הזמן לאימון הבא!",
+	"status.days_left.one":     "{name}, האימון האחרון שלך היה ב{weekday}\nנשאר לך יום אחד להתאמן.",
+	"status.days_left.two":     "{name}, האימון האחרון שלך היה ב{weekday}\nנשארו לך יומיים להתאמן.",
+	"status.days_left.other":   "{name}, האימון האחרון שלך היה ב{weekday}\nנשארו לך {count} ימים להתאמן.",
+	"status.goal":              "יעד שבועי: {done}/{goal}",
+	"join.welcome":             "ברוכים הבאים!\nבקרוב יגיע קישור להצטרפות לקבוצה.\nאחרי ההצטרפות יש לך {grace} לשלוח תמונה של האימון הראשון בצ'אט הקבוצה.\nמשם והלאה, צריך לשלוח לפחות פעם ב{window} כדי להישאר!",
+	"join.already_active":      "כבר יש לך חשבון פעיל",
+	"join.wait":                "{name}, עברו רק {hours}, צריך לחכות {wait}",
+	"cancel.no_group":          "אינך חבר באף קבוצה.",
+	"cancel.nothing":           "אין לך אימונים לבטל.",
+	"cancel.too_late":          "האימון האחרון שלך היה לפני {ago} דקות — אפשר לבטל רק תוך {window} דקות.",
+	"cancel.group":             "{name} ביטל/ה את האימון האחרון מ-{time}.",
+	"cancel.done":              "האימון שלך מ-{time} בוטל.",
+	"instagram.missing_handle": "שלחו את שם המשתמש שלכם באינסטגרם: `/instagram your_handle`",
+	"instagram.registered":     "מעולה! רשמתי את שם המשתמש @{handle} והפעלתי סטוריז יומיים אוטומטיים. 🔥",
+	"instagram.disabled":       "הסטוריז היומיים האוטומטיים באינסטגרם כובו. 🫡",
+	"language.current":         "השפה שלך היא {language}. כדי לשנות אותה, שלחו /language ואחריו אחת מאלה:\n{languages}",
+	"language.unsupported":     "את השפה הזו אני עוד לא מדבר. שלחו /language ואחריו אחת מאלה:\n{languages}",
+	"language.changed":         "סגור, מעכשיו אדבר איתך בעברית. הודעות בקבוצות נשלחות בשפה שבחר/ה מנהל/ת הקבוצה.",
+	"admin.unauthorized":       "אין לך הרשאה לפקודות ניהול. רק מנהלי קבוצות יכולים להשתמש באפשרות הזו.",
+	"admin.unauthorized_again": "אזהרה: זה ניסיון מספר {attempts} שלך לגשת לפקודות ניהול. ניסיונות נוספים ללא הרשאה עלולים להוביל לחסימה.",
+	"admin.unauthorized_final": "אזהרה אחרונה: הניסיונות החוזרים שלך לגשת לאפשרויות ניהול נרשמו. ניסיונות נוספים יובילו לחסימה מהבוט.",
+
+	// Account
+	"account.no_data":                        "אין לי שום מידע עליך",
+	"account.export":                         "הנה כל מה שיש לי עליך: פרופיל, קבוצות, אימונים, אירועים ודרגה, ב-JSON וב-CSV.",
+	"account.delete.confirm":                 "הפעולה תסיר אותך מכל הקבוצות, תנתק את Whoop,‏ Garmin ו-Strava, תעצור את הסטוריז באינסטגרם ותשאיר את האימונים שלך בהיסטוריה של הקבוצה בלי השם שלך.\nאי אפשר לבטל את זה, אולי כדאי להוריד קודם את הנתונים עם /export. למחוק את החשבון?",
+	"account.delete.confirm_delete_workouts": "הפעולה תסיר אותך מכל הקבוצות, תנתק את Whoop,‏ Garmin ו-Strava, תעצור את הסטוריז באינסטגרם ותמחק את האימונים שלך.\nאי אפשר לבטל את זה, אולי כדאי להוריד קודם את הנתונים עם /export. למחוק את החשבון?",
+	"account.delete.button":                  "כן, למחוק את החשבון",
+	"account.delete.failed":                  "משהו השתבש במחיקת החשבון, פנו אל /support",
+	"account.delete.done":                    "החשבון שלך נמחק. שמרו על עצמכם 👋",
+
+	// Support
+	"support.unavailable":     "התמיכה לא זמינה כרגע. נסו שוב מאוחר יותר.",
+	"support.cooldown":        "חכו קצת לפני שליחת הודעה נוספת לתמיכה.",
+	"support.prompt":          "כתבו את ההודעה לתמיכה. אפשר לבקש עזרה או להציע פיצ'ר:",
+	"support.text_only_retry": "התמיכה מקבלת כרגע רק הודעות טקסט. שלחו /support ותארו את הבעיה במילים.",
+	"support.text_only":       "התמיכה מקבלת כרגע רק הודעות טקסט.",
+	"support.failed":          "שליחת ההודעה נכשלה. נסו שוב.",
+	"support.sent":            "ההודעה נשלחה לצוות התמיכה. התשובה תגיע לכאן.\n\nכדי להמשיך את השיחה, השיבו ישירות להודעה מהתמיכה.",
+	"support.reply":           "תשובה מהתמיכה:\n\n{reply}",
+	"support.follow_up_sent":  "ההודעה נשלחה לתמיכה.",
+
+	// Group setup
+	"creategroup.disabled":   "יצירת קבוצות כבויה כרגע.",
+	"creategroup.limit":      "כבר יש לך קבוצה. כל משתמש יכול ליצור קבוצה אחת.",
+	"creategroup.steps":      "בואו ניצור את הקבוצה שלך!\n\nאלה השלבים:\n\n1. פתחו את טלגרם וצרו קבוצה חדשה\n   תנו לה שם קצר (למשל \"לוחמים\")\n\n2. הוסיפו את @{bot} לקבוצה\n\n3. הפכו את @{bot} למנהל:\n   הקישו על שם הקבוצה > עריכה > מנהלים > הוספת @{bot}\n\n4. הקישו על @{bot} > הפעילו \"להישאר אנונימי\" > ואז כבו שוב\n\nזהו! אני אגדיר הכל אוטומטית ואשלח לכם קישור הזמנה לשתף עם חברים.",
+	"setup.added_group":      "תודה שהוספתם אותי!\n\nכדי לעבוד אני צריך להיות מנהל. ככה עושים את זה:\n\n1. הקישו על שם הקבוצה > עריכה > מנהלים\n2. הוסיפו את @{bot} כמנהל\n3. הפעילו \"להישאר אנונימי\" עבור @{bot}\n4. כבו את זה שוב\n\nכך הקבוצה מומרת ואני יכול לנהל אותה. את השאר אסיים אוטומטית!",
+	"setup.added_supergroup": "תודה שהוספתם אותי!\n\nנשאר צעד אחד - הפכו אותי למנהל:\nהקישו על שם הקבוצה > עריכה > מנהלים > הוספת @{bot}\n\nאת השאר אסיים אוטומטית!",
+	"setup.convert":          "כמעט שם!\n\n1. הקישו על שם הקבוצה > עריכה > מנהלים\n2. הקישו על @{bot} > הפעילו \"להישאר אנונימי\"\n3. כבו את זה שוב\n\nכך הקבוצה מומרת ואני יכול לנהל אותה. את השאר אסיים אוטומטית!",
+	"setup.activated":        "הקבוצה הופעלה!\n\nאיך זה עובד:\n- שולחים תמונת אימון לפחות פעם ב{window}\n- מפספסים את המועד = באן (אפשר לחזור אחרי {rejoin})\n- כולם מתחילים עם תקופת חסד של {grace}\n\n{admin} מנהל/ת את הקבוצה.\nחשוב❗: אל תוסיפו משתמשים בעצמכם, שתפו איתם את הקישור למטה כדי לרשום אותם לקבוצה.\n{link}",
+	"setup.creator":          "את/ה המנהל/ת של \"{title}\"!\n\nשלחו את הקישור הזה לחברים שתרצו להזמין:\n{link}\n\nכשהם ילחצו עליו, תקבלו הודעה לאשר אותם.\n\nכלי הניהול שלך (שלחו /admin בצ'אט הפרטי שלנו):\n- Group Link: קישור הזמנה חדש בכל זמן\n- Show Users: כל חברי הקבוצה\n- Ban User / Rejoin User: ניהול חברים\n- Push Workout: רישום אימון למישהו\n- Close Group: סגירת הקבוצה\n\nצריכים עזרה? שלחו /admin בכל זמן כדי לראות את כל האפשרויות.",
+	"setup.removed":          "הוסרתי מ-\"{title}\". הקבוצה הושבתה.\n\nאפשר ליצור קבוצה חדשה בכל זמן עם /creategroup.",
+	"group.not_activated":    "הקבוצה {title} לא מופעלת, שלחו את זה למנהל: `{chat_id}`",
+
+	// Bans and invites
+	"ban.group": "{name} לא התאמן/ה. 🦥⛔",
+	"ban.dm.one": `{name} קיבלת באן מהקבוצה
+כי לא התאמנת.
+אפשר לחזור בעוד שעה:
+
+1. הקישו כאן: /join
+2. חכו לאישור
+3. קבלו קישור להצטרפות לקבוצה
+
+*שימו לב!!* אחרי ההצטרפות לקבוצה
+יהיו לכם 60 דקות לשלוח אימון
+בצ'אט הקבוצה.`,
+	"ban.dm.two": `{name} קיבלת באן מהקבוצה
+כי לא התאמנת.
+אפשר לחזור בעוד שעתיים:
+
+1. הקישו כאן: /join
+2. חכו לאישור
+3. קבלו קישור להצטרפות לקבוצה
+
+*שימו לב!!* אחרי ההצטרפות לקבוצה
+יהיו לכם 60 דקות לשלוח אימון
+בצ'אט הקבוצה.`,
+	"ban.dm.other": `{name} קיבלת באן מהקבוצה
+כי לא התאמנת.
+אפשר לחזור בעוד {count} שעות:
+
+1. הקישו כאן: /join
+2. חכו לאישור
+3. קבלו קישור להצטרפות לקבוצה
+
+*שימו לב!!* אחרי ההצטרפות לקבוצה
+יהיו לכם 60 דקות לשלוח אימון
+בצ'אט הקבוצה.`,
+	"ban.lifted": "מנהל/ת הסיר/ה את הבאן שלך, אפשר לחזור לקבוצה כאן: {link}",
+	"invite.new": "הוזמנת להצטרף! יש לך {grace} לשלוח תמונה של האימון הראשון בקבוצה. משם והלאה, צריך לשלוח לפחות פעם ב{window} כדי להישאר. הנה הקישור שלך: {link}",
+
+	// Scheduled warnings
+	"strike.warning":         "{mention} נשאר לך {left} להתאמן",
+	"strike.end_of_day":      "עד סוף היום",
+	"strike.days_left.one":   "יום אחד",
+	"strike.days_left.two":   "יומיים",
+	"strike.days_left.other": "{count} ימים",
+	"strike.immunity":        "ניצל/ה בזכות חסינות: {name}",
+	"nudge.comeback":         "אולי הגיע הזמן לחזור?\nהקישו: /join",
+
+	// Disputes
+	"dispute.question":     "לבטל את האימון של {name} מ-{time}?",
+	"dispute.explanation":  "הצביעו כדי להחליט אם לבטל את האימון הזה",
+	"dispute.cancelled":    "הקבוצה החליטה לבטל את האימון של {name} מ-{time}.\nקולות: כן: {yes}, לא: {no} (נדרשים: {required})",
+	"dispute.cancelled_dm": "האימון שלך מ-{time} נפסל ובוטל בהצבעת הקבוצה.",
+	"dispute.kept":         "הקבוצה החליטה להשאיר את האימון של {name}.\nקולות: כן: {yes}, לא: {no} (נדרשים: {required})",
+
+	// Reports
+	"report.weekly.title":                   "סיכום שבועי:",
+	"report.weekly.leader.one":              "{name} הכוכב/ת ⭐ עם אימון אחד!",
+	"report.weekly.leader.two":              "{name} הכוכב/ת ⭐ עם שני אימונים!",
+	"report.weekly.leader.other":            "{name} הכוכב/ת ⭐ עם {count} אימונים!",
+	"report.weekly.leaders.one":             "⭐ המובילים של השבוע עם אימון אחד:",
+	"report.weekly.leaders.two":             "⭐ המובילים של השבוע עם שני אימונים:",
+	"report.weekly.leaders.other":           "⭐ המובילים של השבוע עם {count} אימונים:",
+	"report.monthly_standings":              "הטבלה החודשית:",
+	"report.monthly_standings.first.one":    "🥇 {name} מוביל/ה עם אימון אחד",
+	"report.monthly_standings.first.two":    "🥇 {name} מוביל/ה עם שני אימונים",
+	"report.monthly_standings.first.other":  "🥇 {name} מוביל/ה עם {count} אימונים",
+	"report.monthly_standings.second.one":   "🥈 {name} במקום השני עם אימון אחד",
+	"report.monthly_standings.second.two":   "🥈 {name} במקום השני עם שני אימונים",
+	"report.monthly_standings.second.other": "🥈 {name} במקום השני עם {count} אימונים",
+	"report.group_rank":                     "השבוע הקבוצה שלכם עשתה בממוצע {average} אימונים לחבר. אתם במקום {rank}/{groups} מבין הקבוצות הפעילות!",
+	"report.first_week":                     "זה השבוע הראשון שנרשם! השיא נקבע - נסו לשבור אותו בשבוע הבא!",
+	"report.best_week":                      "זה השבוע הכי טוב שלכם אי פעם!",
+	"report.below_best":                     "הייתם במרחק {diff} נקודות מהשבוע הכי טוב שלכם ({best}).",
+	"report.goals":                          "🎯 יעדים שבועיים שהושגו: {hit}/{count}",
+	"report.leader_message":                 "🎤 {mention}, בתור המוביל/ה הראשון/ה של השבוע, שתפו את ההודעה השבועית שלכם בתגובה להודעה הזו",
+	"report.leader_thanks":                  "תודה על ההודעה השבועית, {name}! היא נעוצה עד שיוכרז המנצח של השבוע הבא.",
+	"report.chart.last_week":                "שבוע שעבר",
+	"report.chart.workouts":                 "אימונים",
+	"report.monthly.one":                    "סיכום חודשי:\n🥇 {name} ניצח/ה החודש עם אימון אחד!\nמקבל/ת חסינות 🛡️",
+	"report.monthly.two":                    "סיכום חודשי:\n🥇 {name} ניצח/ה החודש עם שני אימונים!\nמקבל/ת חסינות 🛡️",
+	"report.monthly.other":                  "סיכום חודשי:\n🥇 {name} ניצח/ה החודש עם {count} אימונים!\nמקבל/ת חסינות 🛡️",
+	"rankings.title":                        "📊 דירוג אמצע השבוע 📊",
+	"rankings.empty":                        "עדיין אין נתוני אימונים השבוע.",
+	"rankings.leaderboard":                  "🏆 הטבלה כרגע:",
+	"rankings.entry.one":                    "{name}: אימון אחד",
+	"rankings.entry.two":                    "{name}: שני אימונים",
+	"rankings.entry.other":                  "{name}: {count} אימונים",
+	"rankings.comeback":                     "🔥 הקאמבק של השבוע: {name} (+{improvement} לעומת שבוע שעבר!)",
+	"rankings.close_race.one":               "⚡ מרוץ צמוד! {players} שחקנים בתיקו על אימון אחד!",
+	"rankings.close_race.two":               "⚡ מרוץ צמוד! {players} שחקנים בתיקו על שני אימונים!",
+	"rankings.close_race.other":             "⚡ מרוץ צמוד! {players} שחקנים בתיקו על {count} אימונים!",
+	"rankings.win_probability":              "💪 סיכויי ניצחון:",
+	"rankings.contender.leading":            "{name}: מוביל/ה! עוד אימון אחד וזה סגור 🏆",
+	"rankings.contender.one_behind":         "{name}: אימון אחד מאחור - עדיין במשחק! 🎯",
+	"rankings.contender.two_behind":         "{name}: צריך עוד 2 אימונים - יש זמן! ⏰",
+	"rankings.goals":                        "🎯 יעדים שבועיים שכבר הושגו: {hit}/{count}",
+
+	// Workout replies
+	"workout.first":          "{name} כל הכבוד!\nזה האימון הראשון שלך",
+	"workout.great_work":     "עבודה מצוינת!",
+	"workout.stats":          "{name} {cheer}\nהדרגה שלך: {rank}\nאימון אחרון: {weekday} ({ago})\nהשבוע: {week}\n{streak}",
+	"workout.streak":         "{count} ימים ברצף! {crowns} {cheer}",
+	"workout.streak_record":  "הרצף הארוך ביותר: {longest} · 🧊 הקפאות שנותרו: {freezes}",
+	"workout.photo_prompt":   "כל הכבוד על אימון ה{sport}!\n\nהשיבו להודעה הזו עם תמונה כדי לשלוח אותה לכל הקבוצות שלכם.",
+	"duplicate.warning":      "⚠️ {name}, התמונה הזו נראית כמו תמונה שכבר פורסמה ב-{date}. פרסמו תמונה חדשה לכל אימון.",
+	"duplicate.refused":      "{name}, התמונה הזו נראית כמו תמונה שכבר פורסמה ב-{date}, ולכן היא לא נספרת. פרסמו תמונה חדשה של האימון.",
+	"review.pending":         "👀 התמונה הזו לא ממש נראית כמו אימון, אז מנהל יבדוק אותה. עד אז היא נספרת.",
+	"review.rejected":        "האימון שלך מ-{date} ב-{group} נדחה על ידי מנהלי הקבוצה, התמונה לא נראתה כמו אימון. אפשר להשתמש ב-/support אם לדעתך זו טעות.",
+	"photo.prompt":           "תמונה יפה! מה לעשות איתה?",
+	"photo.prompt.now":       "לשלוח לקבוצות עכשיו",
+	"photo.prompt.save":      "לשמור לאימון הבא",
+	"photo.prompt.nothing":   "כלום",
+	"photo.discarded":        "אין בעיה! התמונה לא תישמר.",
+	"photo.expired":          "מצטער, התמונה פגה. שלחו אותה שוב.",
+	"photo.no_account":       "מצטער, לא מצאתי את החשבון שלך. נסו שוב.",
+	"photo.save_failed":      "מצטער, לא הצלחתי לשמור את התמונה. נסו שוב.",
+	"photo.saved":            "קיבלתי! אצרף את התמונה הזו אוטומטית לאימון הבא שלך.",
+	"photo.sent_saved.one":   "התמונה נשלחה לקבוצה אחת ונשמרה להתקדמות היומית שלך! 📸",
+	"photo.sent_saved.two":   "התמונה נשלחה לשתי קבוצות ונשמרה להתקדמות היומית שלך! 📸",
+	"photo.sent_saved.other": "התמונה נשלחה ל-{count} קבוצות ונשמרה להתקדמות היומית שלך! 📸",
+	"photo.sent.one":         "נשלח לקבוצה אחת!",
+	"photo.sent.two":         "נשלח לשתי קבוצות!",
+	"photo.sent.other":       "נשלח ל-{count} קבוצות!",
+	"streak.cheer.1":         "תמשיכו ברצף, גיבורי על!",
+	"streak.cheer.2":         "איזה רצף! תמשיכו לדחוף!",
+	"streak.cheer.3":         "הרצף חי ובועט, אלופים!",
+	"streak.cheer.4":         "שומרים על הרצף, לוחמים!",
+	"streak.cheer.5":         "רצף של אגדה!",
+	"streak.cheer.6":         "הרצף שלכם מעורר השראה!",
+	"streak.cheer.7":         "איזה רצף! לא מוותרים!",
+	"streak.cheer.8":         "תמשיכו ככה, אלופים!",
+
+	// Canned replies for when the AI is down or over budget
+	"ai.workout.1": "עוד אחד בספרים. תישארו קשוחים! 💪🔥",
+	"ai.workout.2": "אף אחד לא יעשה את זה בשבילך, והגעת. 🏃💯",
+	"ai.workout.3": "תחשל את הראש, ותחזור מחר. 🧠⚡",
+	"ai.welcome.1": "ברוך שובך! העבודה לא מחכה, טוב לראות אותך בה. 💪",
+	"ai.welcome.2": "חזרת, עכשיו תמשיך להופיע. 🔥",
+
+	// Workout announcements
+	"announce.completed":         "🏋️ {name} סיים/ה עכשיו אימון!",
+	"announce.completed_sport":   "🏋️ {name} סיים/ה עכשיו אימון {sport}!",
+	"announce.bonus":             "🏃 {name} הוסיף/ה פעילות בונוס: {sport}",
+	"announce.bonus_not_counted": "(הפעילות הזו לא נספרת כאימון נוסף.)",
+	"announce.activity":          "פעילות: {value}",
+	"announce.activity_type":     "סוג פעילות: {value}",
+	"announce.distance":          "מרחק: {value}",
+	"announce.pace":              "קצב: {value}",
+	"announce.time":              "זמן: {value}",
+	"announce.duration":          "משך: {value}",
+	"announce.avg_hr":            "דופק ממוצע: {value}",
+	"announce.calories":          "קלוריות: {value}",
+	"announce.strain":            "Strain: {value}",
+	"announce.relative_effort":   "מאמץ יחסי: {value} (~{strain} strain)",
+	"announce.device":            "מכשיר: {value}",
+	"announce.manual_badge":      "✍️ רישום ידני",
+	"announce.manual":            "{name} רשם/ה {minutes} דק׳ של {activity}",
+
+	// Manual logs
+	"log.no_group":            "קודם צריך להצטרף לקבוצה, ואז אפשר לרשום אימונים עם /log.",
+	"log.activity":            "מה עשית?",
+	"log.activity.run":        "ריצה",
+	"log.activity.ride":       "רכיבה",
+	"log.activity.walk":       "הליכה",
+	"log.activity.swim":       "שחייה",
+	"log.activity.strength":   "כוח",
+	"log.activity.cardio":     "קרדיו",
+	"log.activity.mobility":   "מוביליטי",
+	"log.activity.sport":      "ספורט",
+	"log.activity.other":      "אחר",
+	"log.duration":            "{activity} — כמה זמן?",
+	"log.note":                "אפשר לשלוח הערה קצרה, או לדלג.",
+	"log.note_skip":           "דילוג",
+	"log.note_text_only":      "שלחו את ההערה כטקסט, או לחצו על דילוג.",
+	"log.groups":              "באילו קבוצות זה ייספר?",
+	"log.submit":              "רישום",
+	"log.logging":             "רושם…",
+	"log.expired":             "הרישום הזה פג. אפשר להתחיל מחדש עם /log.",
+	"log.no_groups_picked":    "צריך לבחור לפחות קבוצה אחת.",
+	"log.result.logged":       "✅ {group}: נרשם",
+	"log.result.pending":      "⏳ {group}: ממתין לאישור מנהל",
+	"log.result.off":          "🚫 {group}: לא מקבלת רישומים ידניים",
+	"log.result.capped.one":   "🚫 {group}: רק רישום ידני אחד בשבוע",
+	"log.result.capped.other": "🚫 {group}: רק {count} רישומים ידניים בשבוע",
+	"log.result.too_soon":     "🚫 {group}: מוקדם מדי אחרי האימון האחרון",
+	"log.result.failed":       "⚠️ {group}: משהו השתבש, נסו שוב",
+	"log.approved":            "{minutes} הדקות של {activity} אושרו ב-{group} ✅",
+	"log.rejected":            "{minutes} הדקות של {activity} לא אושרו ב-{group}.",
+	"log.refused":             "{minutes} הדקות של {activity} אושרו, אבל כבר אי אפשר לרשום אותן:\n{reason}",
+
+	// Weekly goals
+	"goal.no_group":   "קודם הצטרפו לקבוצה, ואז תוכלו להגדיר יעד שבועי עם /goal.",
+	"goal.none":       "עדיין אין לך יעד שבועי. התחייבו למספר אימונים בשבוע עם /goal ואחריו המספר, למשל /goal 4.",
+	"goal.current":    "היעדים השבועיים שלך:\n{goals}\n\nכדי לשנות יעד שלחו /goal ואחריו מספר האימונים, /goal 0 מבטל אותו.",
+	"goal.progress":   "🎯 {group}: {done}/{goal} השבוע",
+	"goal.invalid":    "שלחו /goal ואחריו מספר האימונים בשבוע, מ-0 עד {max}, למשל /goal 4.",
+	"goal.pick_group": "לאיזו קבוצה היעד?",
+	"goal.set":        "היעד השבועי שלך ב-{group} נקבע: {done}/{goal} עד עכשיו השבוע 🎯",
+	"goal.cleared":    "היעד השבועי שלך ב-{group} בוטל.",
+	"goal.nudge":      "{name}, עשית {done}/{goal} אימונים השבוע ב-{group}. נשארו {days} כדי להגיע ליעד 💪",
+
+	// Workout providers
+	"provider.connect.whoop":               "חברו את חשבון ה-Whoop שלכם כדי לסנכרן אימונים אוטומטית.",
+	"provider.connect.whoop.button":        "חיבור Whoop",
+	"provider.connect.garmin":              "חברו את חשבון ה-Garmin שלכם כדי לסנכרן אימונים אוטומטית.",
+	"provider.connect.garmin.button":       "חיבור Garmin",
+	"provider.connect.strava":              "חברו את חשבון ה-Strava שלכם כדי לסנכרן אימונים אוטומטית.\n\nשימו לב: זה ינתק חיבור קיים ל-Whoop או Garmin.",
+	"provider.connect.strava.button":       "חיבור עם Strava",
+	"provider.connected.whoop":             "חשבון ה-Whoop שלכם חובר בהצלחה!\n\nמעכשיו האימונים שלכם יסונכרנו אוטומטית. שימו לב: חיבור קודם ל-Strava או Garmin נותק.",
+	"provider.connected.garmin":            "חשבון ה-Garmin שלכם חובר בהצלחה!\n\nמעכשיו האימונים שלכם יסונכרנו אוטומטית. שימו לב: חיבור קודם ל-Strava או Whoop נותק.",
+	"provider.connected.strava":            "חשבון ה-Strava שלכם{athlete} חובר בהצלחה!\n\nמעכשיו האימונים שלכם יסונכרנו אוטומטית. שימו לב: חיבור קודם ל-Whoop או Garmin נותק.",
+	"provider.detected.whoop":              "זיהיתי אימון: {sport}. לספור אותו כאימון?",
+	"provider.detected.garmin":             "זיהיתי פעילות Garmin: {activity}. לספור אותה כאימון?",
+	"provider.detected.strava_second":      "זיהיתי פעילות Strava: {activity} ({sport}). זה האימון השני שלכם היום. לספור אותה כאימון?",
+	"provider.detected.ignored":            "הבנתי. לא אדווח על הפעילות הזו.",
+	"provider.detected.already_registered": "האימון הזה כבר נרשם אוטומטית.",
+	"provider.detected.failed":             "מצטער, הייתה שגיאה בעיבוד האימון. נסו שוב.",
+	"provider.garmin.bulk_sync":            "זיהיתי סנכרון Garmin גדול במיוחד ושמרתי רק את הפעילות האחרונה כדי למנוע העלאה גורפת של אימונים בטעות.",
+	"provider.garmin.permissions_revoked":  "חשבון ה-Garmin שלכם נותק כי כל ההרשאות בוטלו.",
+	"provider.garmin.disconnected":         "חשבון ה-Garmin שלכם נותק.",
+	"provider.garmin.data_by":              "הנתונים באדיבות Garmin",
+	"provider.strava.revoked":              "חשבון ה-Strava שלכם נותק כי ביטלתם את הגישה בהגדרות של Strava.",
+	"provider.strava.powered_by":           "Powered by Strava",
+	"provider.strava.view":                 "צפייה ב-Strava",
+
+	// Instagram spotlight
+	"spotlight.no_handle":     "קודם רשמו את שם המשתמש באינסטגרם עם /instagram your_handle 📸",
+	"spotlight.not_own":       "אפשר לבקש ספוטלייט רק על תמונות שלכם 🙅",
+	"spotlight.cooldown":      "כבר הופעתם לאחרונה! אפשר לבקש שוב בעוד {remaining} ⏳",
+	"spotlight.cooldown_soon": "כבר הופעתם לאחרונה! נסו שוב בעוד קצת ⏳",
+	"spotlight.processing":    "הספוטלייט שלכם כבר בהכנה, רק רגע! ⚙️",
+	"spotlight.started":       "📸 קיבלתי! מכין את הספוטלייט שלכם לאינסטגרם... זה יכול לקחת דקה.",
+	"spotlight.featured":      "🚀 בום! הופעתם באינסטגרם של FatBot!\n\nשם משתמש: @{handle}\nסטטוס: {status}\nאימונים: {workouts}\n\nהציצו בחשבון הראשי כדי לראות את הספוטלייט שלכם! 💪\nhttps://www.instagram.com/fatbot.fit",
+}
@@ -0,0 +1,182 @@
+// Package i18n holds the catalog of user-facing messages. Messages have named
+// placeholders like {name} so translations can reorder them, and plural
+// messages have a key per plural form: key.one, key.two and key.other.
+package i18n
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+
+	"github.com/charmbracelet/log"
+	"github.com/spf13/viper"
+)
+
+type Lang string
+
+const (
+	English Lang = "en"
+	Italian Lang = "it"
+	Hebrew  Lang = "he"
+)
+
+// Supported lists the languages with a catalog, in display order.
+var Supported = []Lang{English, Italian, Hebrew}
+
+var catalogs = map[Lang]map[string]string{
+	English: english,
+	Italian: italian,
+	Hebrew:  hebrew,
+}
+
+var placeholder = regexp.MustCompile(`\{[a-z_]+\}`)
+
+var names = map[Lang]string{
+	English: "English",
+	Italian: "Italiano",
+	Hebrew:  "עברית",
+}
+
+// englishNames are used in AI prompts, which work best in English.
+var englishNames = map[Lang]string{
+	English: "English",
+	Italian: "Italian",
+	Hebrew:  "Hebrew",
+}
+
+// Parse returns the supported language for a code such as "it" or a
+// Telegram language_code such as "it-IT", and false for anything else.
+func Parse(code string) (Lang, bool) {
+	code = strings.ToLower(strings.TrimSpace(code))
+	if base, _, found := strings.Cut(code, "-"); found {
+		code = base
+	}
+	// Older clients still send the deprecated ISO 639 code for Hebrew
+	if code == "iw" {
+		code = string(Hebrew)
+	}
+	lang := Lang(code)
+	_, ok := catalogs[lang]
+	return lang, ok
+}
+
+// Default is the language from config.yaml, English if it isn't supported.
+func Default() Lang {
+	if lang, ok := Parse(viper.GetString("language")); ok {
+		return lang
+	}
+	return English
+}
+
+// Or returns the language for code, or fallback when code isn't supported.
+func Or(code string, fallback Lang) Lang {
+	if lang, ok := Parse(code); ok {
+		return lang
+	}
+	return fallback
+}
+
+// Name is the language's name in the language itself.
+func (lang Lang) Name() string {
+	return names[lang]
+}
+
+// EnglishName is the language's name in English.
+func (lang Lang) EnglishName() string {
+	return englishNames[lang]
+}
+
+// T returns the message for key with the placeholders filled in from args,
+// given as name, value pairs. Missing translations fall back to English.
+func T(lang Lang, key string, args ...interface{}) string {
+	message, ok := lookup(lang, key)
+	if !ok {
+		log.Warn("Missing message", "key", key, "lang", lang)
+		return key
+	}
+	return format(message, args)
+}
+
+// N returns the plural form of key matching count, which fills {count}.
+func N(lang Lang, key string, count int, args ...interface{}) string {
+	message, ok := lookup(lang, key+"."+pluralForm(lang, count))
+	if !ok {
+		if message, ok = lookup(lang, key+".other"); !ok {
+			log.Warn("Missing message", "key", key, "lang", lang)
+			return key
+		}
+	}
+	return format(message, append([]interface{}{"count", count}, args...))
+}
+
+// Variants returns the interchangeable messages key.1, key.2 and so on, used
+// to vary cheers. Languages can have fewer variants than English.
+func Variants(lang Lang, key string) (variants []string) {
+	for _, catalog := range []map[string]string{catalogs[lang], catalogs[English]} {
+		for i := 1; ; i++ {
+			message, ok := catalog[fmt.Sprintf("%s.%d", key, i)]
+			if !ok {
+				break
+			}
+			variants = append(variants, message)
+		}
+		if len(variants) > 0 {
+			return variants
+		}
+	}
+	return nil
+}
+
+// Sent reports whether text is the message for key in any language, whatever
+// its placeholders were filled with. It recognizes replies to the bot's prompts.
+func Sent(text, key string) bool {
+	for _, lang := range Supported {
+		message, ok := catalogs[lang][key]
+		if ok && matches(text, message) {
+			return true
+		}
+	}
+	return false
+}
+
+func matches(text, message string) bool {
+	for _, part := range placeholder.Split(message, -1) {
+		index := strings.Index(text, part)
+		if index < 0 {
+			return false
+		}
+		text = text[index+len(part):]
+	}
+	return true
+}
+
+func lookup(lang Lang, key string) (string, bool) {
+	if message, ok := catalogs[lang][key]; ok {
+		return message, true
+	}
+	message, ok := catalogs[English][key]
+	return message, ok
+}
+
+// pluralForm follows the CLDR cardinal rules for the supported languages.
+func pluralForm(lang Lang, count int) string {
+	switch {
+	case count == 1:
+		return "one"
+	case count == 2 && lang == Hebrew:
+		return "two"
+	default:
+		return "other"
+	}
+}
+
+func format(message string, args []interface{}) string {
+	if len(args) == 0 {
+		return message
+	}
+	replacements := make([]string, 0, len(args))
+	for i := 0; i+1 < len(args); i += 2 {
+		replacements = append(replacements, "{"+fmt.Sprint(args[i])+"}", fmt.Sprint(args[i+1]))
+	}
+	return strings.NewReplacer(replacements...).Replace(message)
+}
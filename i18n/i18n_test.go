@@ -0,0 +1,154 @@
+package i18n
+
+import (
+	"reflect"
+	"sort"
+	"strings"
+	"testing"
+)
+
+func placeholders(message string) []string {
+	names := placeholder.FindAllString(message, -1)
+	sort.Strings(names)
+	return names
+}
+
+// withoutCount drops {count}, which plural forms like "1 day" leave out.
+func withoutCount(names []string) []string {
+	var kept []string
+	for _, name := range names {
+		if name != "{count}" {
+			kept = append(kept, name)
+		}
+	}
+	return kept
+}
+
+func isPluralForm(key string) bool {
+	return strings.HasSuffix(key, ".one") || strings.HasSuffix(key, ".two") || strings.HasSuffix(key, ".other")
+}
+
+func TestCatalogsMatchEnglish(t *testing.T) {
+	for _, lang := range Supported[1:] {
+		catalog := catalogs[lang]
+		for key, message := range english {
+			// Languages can have fewer cheers than English
+			if strings.HasPrefix(key, "streak.cheer.") {
+				continue
+			}
+			translated, ok := catalog[key]
+			if !ok {
+				t.Errorf("%s: missing %q", lang, key)
+				continue
+			}
+			want, got := placeholders(message), placeholders(translated)
+			if isPluralForm(key) {
+				want, got = withoutCount(want), withoutCount(got)
+			}
+			if !reflect.DeepEqual(want, got) {
+				t.Errorf("%s: %q has placeholders %v, English has %v", lang, key, got, want)
+			}
+		}
+		for key := range catalog {
+			if _, ok := english[key]; ok {
+				continue
+			}
+			if _, ok := english[strings.TrimSuffix(key, ".two")+".other"]; ok && strings.HasSuffix(key, ".two") {
+				continue
+			}
+			if !strings.HasPrefix(key, "streak.cheer.") {
+				t.Errorf("%s: %q isn't in the English catalog", lang, key)
+			}
+		}
+		if len(Variants(lang, "streak.cheer")) == 0 {
+			t.Errorf("%s: no streak cheers", lang)
+		}
+	}
+}
+
+func TestParse(t *testing.T) {
+	tests := []struct {
+		code string
+		want Lang
+		ok   bool
+	}{
+		{"it", Italian, true},
+		{"it-IT", Italian, true},
+		{" HE ", Hebrew, true},
+		{"iw", Hebrew, true},
+		{"en-GB", English, true},
+		{"fr", Lang("fr"), false},
+		{"", Lang(""), false},
+	}
+	for _, test := range tests {
+		got, ok := Parse(test.code)
+		if got != test.want || ok != test.ok {
+			t.Errorf("Parse(%q) = %q, %v, want %q, %v", test.code, got, ok, test.want, test.ok)
+		}
+	}
+	if got := Or("fr", Italian); got != Italian {
+		t.Errorf("Or(fr, it) = %q, want it", got)
+	}
+}
+
+func TestPlurals(t *testing.T) {
+	tests := []struct {
+		lang  Lang
+		count int
+		want  string
+	}{
+		{English, 1, "1 day"},
+		{English, 2, "2 days"},
+		{English, 0, "0 days"},
+		{Italian, 1, "1 giorno"},
+		{Italian, 5, "5 giorni"},
+		{Hebrew, 1, "יום אחד"},
+		{Hebrew, 2, "יומיים"},
+		{Hebrew, 3, "3 ימים"},
+	}
+	for _, test := range tests {
+		if got := N(test.lang, "days", test.count); got != test.want {
+			t.Errorf("N(%s, days, %d) = %q, want %q", test.lang, test.count, got, test.want)
+		}
+	}
+	// Hebrew has a separate form for two
+	if got := N(Hebrew, "photo.sent", 2); got != "נשלח לשתי קבוצות!" {
+		t.Errorf("Hebrew two form = %q", got)
+	}
+	if got := N(Hebrew, "ago.hours", 2); got != "לפני שעתיים" {
+		t.Errorf("Hebrew two form = %q", got)
+	}
+	if got := N(Hebrew, "rankings.contender", 2); got != "rankings.contender" {
+		t.Errorf("message without plural forms = %q, want the key", got)
+	}
+	if got := N(Hebrew, "status.days_left", 2, "name", "Dana", "weekday", "שבת"); !strings.Contains(got, "יומיים") {
+		t.Errorf("Hebrew two form = %q", got)
+	}
+}
+
+func TestT(t *testing.T) {
+	got := T(Italian, "cancel.too_late", "ago", 7, "window", 5)
+	want := "Il tuo ultimo allenamento è di 7 minuti fa — puoi annullarlo solo entro 5 minuti."
+	if got != want {
+		t.Errorf("T = %q, want %q", got, want)
+	}
+	if got := T(Lang("fr"), "common.yes"); got != "Yes" {
+		t.Errorf("unsupported language = %q, want the English message", got)
+	}
+	if got := T(English, "no.such.key"); got != "no.such.key" {
+		t.Errorf("missing key = %q, want the key", got)
+	}
+}
+
+func TestSent(t *testing.T) {
+	text := T(Hebrew, "report.leader_message", "mention", "Dana")
+	if !Sent(text, "report.leader_message") {
+		t.Errorf("Sent didn't recognize %q", text)
+	}
+	if !Sent(T(English, "workout.photo_prompt", "sport", "Running"), "workout.photo_prompt") {
+		t.Error("Sent didn't recognize the English photo prompt")
+	}
+	if Sent("Reply to this message please", "workout.photo_prompt") {
+		t.Error("Sent matched an unrelated message")
+	}
+}
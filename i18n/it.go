@@ -0,0 +1,307 @@
+package i18n
+
+var italian = map[string]string{
+	// Durations
+	"days.one":            "1 giorno",
+	"days.other":          "{count} giorni",
+	"hours.one":           "1 ora",
+	"hours.other":         "{count} ore",
+	"duration.days_hours": "{days}, {hours}",
+	"ago.hours.one":       "1 ora fa",
+	"ago.hours.other":     "{count} ore fa",
+	"ago.days_hours":      "{days} e {hours} fa",
+	"weekday.0":           "domenica",
+	"weekday.1":           "lunedì",
+	"weekday.2":           "martedì",
+	"weekday.3":           "mercoledì",
+	"weekday.4":           "giovedì",
+	"weekday.5":           "venerdì",
+	"weekday.6":           "sabato",
+
+	// Buttons
+	"common.yes":       "Sì",
+	"common.no":        "No",
+	"common.cancel":    "Annulla",
+	"common.cancelled": "Annullato",
+
+	// Private commands
+	"start":                    "Benvenuto su FatBot! Usa /join per entrare in un gruppo.",
+	"help":                     "Entra in un gruppo: /join\nCrea il tuo gruppo: /creategroup\nControlla il tuo stato: /status\nStatistiche: /stats\nRegistra un allenamento senza foto: /log\nFissa un obiettivo settimanale: /goal\nAnnulla l'ultimo allenamento (entro pochi minuti): /cancel\nCambia lingua: /language\nScarica i tuoi dati: /export\nElimina il tuo account: /delete_me",
+	"command.unknown":          "Comando sconosciuto",
+	"private.try_help":         "Prova /help",
+	"user.unregistered":        "Non sei registrato.",
+	"user.load_failed":         "Impossibile caricare l'utente.",
+	"user.blocked":             "Sei bloccato e non puoi usare questo bot.",
+	"rank.no_history":          "Ancora nessun allenamento registrato.",
+	"rank.highest":             "Grado attuale: {rank} (il più alto!)",
+	"rank.next":                "Grado attuale: {rank}\nGiorni al prossimo grado ({next}): {days}",
+	"status.no_workout":        "Non ho ancora il tuo ultimo allenamento.",
+	"status.overdue":           "{name}, il tuo ultimo allenamento è stato {weekday}\nSei in ritardo con l'allenamento!",
+	"status.days_left.one":     "{name}, il tuo ultimo allenamento è stato {weekday}\nTi resta 1 giorno per allenarti.",
+	"status.days_left.other":   "{name}, il tuo ultimo allenamento è stato {weekday}\nTi restano {count} giorni per allenarti.",
+	"status.goal":              "Obiettivo settimanale: {done}/{goal}",
+	"join.welcome":             "Benvenuto!\nA breve riceverai un link per entrare nel gruppo.\nUna volta dentro, hai {grace} per postare la foto del tuo primo allenamento nella chat del gruppo.\nDopo, posta almeno una volta ogni {window} per restare!",
+	"join.already_active":      "Sei già attivo",
+	"join.wait":                "{name}, sono passate solo {hours}, devi aspettare {wait}",
+	"cancel.no_group":          "Non fai parte di nessun gruppo.",
+	"cancel.nothing":           "Non hai allenamenti da annullare.",
+	"cancel.too_late":          "Il tuo ultimo allenamento è di {ago} minuti fa — puoi annullarlo solo entro {window} minuti.",
+	"cancel.group":             "{name} ha annullato il suo ultimo allenamento del {time}.",
+	"cancel.done":              "Ho annullato il tuo allenamento del {time}.",
+	"instagram.missing_handle": "Indica il tuo profilo Instagram: `/instagram tuo_profilo`",
+	"instagram.registered":     "Fantastico! Ho registrato il tuo profilo Instagram @{handle} e attivato le storie automatiche giornaliere. 🔥",
+	"instagram.disabled":       "Storie Instagram automatiche giornaliere disattivate. 🫡",
+	"language.current":         "La tua lingua è {language}. Per cambiarla, invia /language seguito da una di queste:\n{languages}",
+	"language.unsupported":     "Questa lingua non la parlo ancora. Invia /language seguito da una di queste:\n{languages}",
+	"language.changed":         "Fatto, da ora ti parlerò in italiano. I messaggi nei gruppi seguono la lingua scelta dall'admin del gruppo.",
+	"admin.unauthorized":       "Non sei autorizzato a usare i comandi admin. Solo gli amministratori dei gruppi possono usare questa funzione.",
+	"admin.unauthorized_again": "Attenzione: questo è il tuo tentativo numero {attempts} di accedere ai comandi admin. Altri tentativi non autorizzati possono portare al blocco.",
+	"admin.unauthorized_final": "ULTIMO AVVISO: i tuoi ripetuti tentativi di accedere alle funzioni admin sono stati registrati. Altri tentativi porteranno al blocco dal bot.",
+
+	// Account
+	"account.no_data":                        "Non ho nessun dato su di te",
+	"account.export":                         "Ecco tutto quello che ho su di te: profilo, gruppi, allenamenti, eventi e grado, in JSON e CSV.",
+	"account.delete.confirm":                 "Questo ti rimuove da tutti i tuoi gruppi, scollega Whoop, Garmin e Strava, ferma le storie Instagram e mantiene i tuoi allenamenti nello storico del gruppo senza il tuo nome.\nNon si può annullare, potresti voler prima scaricare i tuoi dati con /export. Eliminare il tuo account?",
+	"account.delete.confirm_delete_workouts": "Questo ti rimuove da tutti i tuoi gruppi, scollega Whoop, Garmin e Strava, ferma le storie Instagram ed elimina i tuoi allenamenti.\nNon si può annullare, potresti voler prima scaricare i tuoi dati con /export. Eliminare il tuo account?",
+	"account.delete.button":                  "Sì, elimina il mio account",
+	"account.delete.failed":                  "Qualcosa è andato storto durante l'eliminazione del tuo account, contatta /support",
+	"account.delete.done":                    "Il tuo account è stato eliminato. Stammi bene 👋",
+
+	// Support
+	"support.unavailable":     "Il supporto non è disponibile al momento. Riprova più tardi.",
+	"support.cooldown":        "Aspetta un po' prima di inviare un altro messaggio al supporto.",
+	"support.prompt":          "Scrivi il tuo messaggio per il supporto. Puoi chiedere aiuto o suggerire una funzione:",
+	"support.text_only_retry": "Il supporto accetta solo messaggi di testo. Usa /support e descrivi il problema a parole.",
+	"support.text_only":       "Il supporto accetta solo messaggi di testo.",
+	"support.failed":          "Impossibile inviare il tuo messaggio. Riprova.",
+	"support.sent":            "Il tuo messaggio è stato inviato al team di supporto. Riceverai una risposta qui.\n\nPer continuare la conversazione, rispondi direttamente al messaggio del supporto.",
+	"support.reply":           "Risposta del supporto:\n\n{reply}",
+	"support.follow_up_sent":  "Il tuo messaggio è stato inviato al supporto.",
+
+	// Group setup
+	"creategroup.disabled":   "La creazione di gruppi è disattivata al momento.",
+	"creategroup.limit":      "Hai già un gruppo. Ogni utente può crearne uno solo.",
+	"creategroup.steps":      "Creiamo il tuo gruppo!\n\nSegui questi passaggi:\n\n1. Apri Telegram e crea un nuovo gruppo\n   Dagli un nome breve (es. \"Guerrieri\")\n\n2. Aggiungi @{bot} al gruppo\n\n3. Rendi @{bot} amministratore:\n   Tocca il nome del gruppo > Modifica > Amministratori > Aggiungi @{bot}\n\n4. Tocca @{bot} > attiva \"Resta anonimo\" > poi disattivalo di nuovo\n\nFatto! Configuro tutto in automatico e ti mando un link di invito da condividere con gli amici.",
+	"setup.added_group":      "Grazie per avermi aggiunto!\n\nPer funzionare devo essere amministratore. Ecco come:\n\n1. Tocca il nome del gruppo > Modifica > Amministratori\n2. Aggiungi @{bot} come amministratore\n3. Attiva \"Resta anonimo\" per @{bot}\n4. Poi disattivalo di nuovo\n\nCosì il gruppo viene convertito e posso gestirlo. Completo la configurazione in automatico!",
+	"setup.added_supergroup": "Grazie per avermi aggiunto!\n\nManca un passaggio - rendimi amministratore:\nTocca il nome del gruppo > Modifica > Amministratori > Aggiungi @{bot}\n\nCompleto la configurazione in automatico!",
+	"setup.convert":          "Ci siamo quasi!\n\n1. Tocca il nome del gruppo > Modifica > Amministratori\n2. Tocca @{bot} > attiva \"Resta anonimo\"\n3. Poi disattivalo di nuovo\n\nCosì il gruppo viene convertito e posso gestirlo. Completo la configurazione in automatico!",
+	"setup.activated":        "Gruppo attivato!\n\nCome funziona:\n- Posta una foto di un allenamento ogni {window}\n- Salti la scadenza = bannato (puoi rientrare dopo {rejoin})\n- Tutti partono con {grace} di tolleranza\n\n{admin} è l'admin del gruppo.\nIMPORTANTE❗: Non aggiungere altri utenti tu stesso, condividi con loro il link qui sotto per registrarli nel gruppo.\n{link}",
+	"setup.creator":          "Sei l'admin di \"{title}\"!\n\nInvia questo link agli amici che vuoi invitare:\n{link}\n\nQuando lo aprono, riceverai un messaggio per approvarli.\n\nI tuoi strumenti admin (scrivi /admin nella nostra chat privata):\n- Group Link: un nuovo link di invito quando vuoi\n- Show Users: vedi tutti i membri\n- Ban User / Rejoin User: gestisci i membri\n- Push Workout: accredita un allenamento a qualcuno\n- Close Group: chiudi il gruppo\n\nServe aiuto? Scrivi /admin in qualsiasi momento per vedere tutte le opzioni.",
+	"setup.removed":          "Sono stato rimosso da \"{title}\". Il gruppo è stato disattivato.\n\nPuoi creare un nuovo gruppo quando vuoi con /creategroup.",
+	"group.not_activated":    "Il gruppo {title} non è attivo, invia questo all'admin: `{chat_id}`",
+
+	// Bans and invites
+	"ban.group": "{name} non si stava allenando. 🦥⛔",
+	"ban.dm.one": `{name} sei stato bannato dal gruppo
+perché non ti sei allenato.
+Puoi rientrare tra 1 ora:
+
+1. Tocca qui: /join
+2. Aspetta l'approvazione
+3. Ricevi il link per entrare nel gruppo
+
+*ATTENZIONE!!* Dopo essere rientrato, avrai
+60 minuti per inviare un allenamento
+nella chat del gruppo.`,
+	"ban.dm.other": `{name} sei stato bannato dal gruppo
+perché non ti sei allenato.
+Puoi rientrare tra {count} ore:
+
+1. Tocca qui: /join
+2. Aspetta l'approvazione
+3. Ricevi il link per entrare nel gruppo
+
+*ATTENZIONE!!* Dopo essere rientrato, avrai
+60 minuti per inviare un allenamento
+nella chat del gruppo.`,
+	"ban.lifted": "Un admin ha rimosso il tuo ban, puoi rientrare nel gruppo qui: {link}",
+	"invite.new": "Sei invitato! Hai {grace} per postare la foto del tuo primo allenamento nel gruppo. Dopo, posta almeno una volta ogni {window} per restare. Ecco il tuo link: {link}",
+
+	// Scheduled warnings
+	"strike.warning":         "{mention} hai {left} per allenarti",
+	"strike.end_of_day":      "tempo fino a fine giornata",
+	"strike.days_left.one":   "ancora un giorno",
+	"strike.days_left.other": "ancora {count} giorni",
+	"strike.immunity":        "Salvato grazie all'immunità: {name}",
+	"nudge.comeback":         "Forse è ora di tornare?\nTocca: /join",
+
+	// Disputes
+	"dispute.question":     "Annullare l'allenamento di {name} del {time}?",
+	"dispute.explanation":  "Vota per decidere se questo allenamento va annullato",
+	"dispute.cancelled":    "Il gruppo ha deciso di annullare l'allenamento di {name} del {time}.\nVoti: Sì: {yes}, No: {no} (Necessari: {required})",
+	"dispute.cancelled_dm": "Il tuo allenamento del {time} è stato contestato e annullato con il voto del gruppo.",
+	"dispute.kept":         "Il gruppo ha deciso di tenere l'allenamento di {name}.\nVoti: Sì: {yes}, No: {no} (Necessari: {required})",
+
+	// Reports
+	"report.weekly.title":                   "Riepilogo settimanale:",
+	"report.weekly.leader.one":              "{name} è la ⭐ con 1 allenamento!",
+	"report.weekly.leader.other":            "{name} è la ⭐ con {count} allenamenti!",
+	"report.weekly.leaders.one":             "⭐ I leader della settimana con 1 allenamento:",
+	"report.weekly.leaders.other":           "⭐ I leader della settimana con {count} allenamenti:",
+	"report.monthly_standings":              "Classifica del mese:",
+	"report.monthly_standings.first.one":    "🥇 {name} è in testa con 1 allenamento",
+	"report.monthly_standings.first.other":  "🥇 {name} è in testa con {count} allenamenti",
+	"report.monthly_standings.second.one":   "🥈 {name} è secondo con 1 allenamento",
+	"report.monthly_standings.second.other": "🥈 {name} è secondo con {count} allenamenti",
+	"report.group_rank":                     "Questa settimana il vostro gruppo ha fatto in media {average} allenamenti a testa. Siete {rank}/{groups} tra i gruppi attivi!",
+	"report.first_week":                     "Questa è la vostra prima settimana registrata! Il record è fissato - provate a batterlo la prossima settimana!",
+	"report.best_week":                      "Questa è la vostra settimana migliore di sempre!",
+	"report.below_best":                     "Eravate a {diff} punti dalla vostra settimana migliore ({best}).",
+	"report.goals":                          "🎯 Obiettivi settimanali raggiunti: {hit}/{count}",
+	"report.leader_message":                 "🎤 {mention}, come primo leader della settimana, condividi il tuo messaggio settimanale rispondendo a questo messaggio",
+	"report.leader_thanks":                  "Grazie per il tuo messaggio settimanale, {name}! Resta fissato fino al vincitore della prossima settimana.",
+	"report.chart.last_week":                "Settimana scorsa",
+	"report.chart.workouts":                 "Allenamenti",
+	"report.monthly.one":                    "Riepilogo mensile:\n🥇 {name} ha vinto il mese con 1 allenamento!\nOttiene l'immunità 🛡️",
+	"report.monthly.other":                  "Riepilogo mensile:\n🥇 {name} ha vinto il mese con {count} allenamenti!\nOttiene l'immunità 🛡️",
+	"rankings.title":                        "📊 Classifica di metà settimana 📊",
+	"rankings.empty":                        "Ancora nessun allenamento questa settimana.",
+	"rankings.leaderboard":                  "🏆 Classifica attuale:",
+	"rankings.entry.one":                    "{name}: 1 allenamento",
+	"rankings.entry.other":                  "{name}: {count} allenamenti",
+	"rankings.comeback":                     "🔥 Rimonta della settimana: {name} (+{improvement} rispetto alla settimana scorsa!)",
+	"rankings.close_race.one":               "⚡ TESTA A TESTA! {players} giocatori pari a 1 allenamento!",
+	"rankings.close_race.other":             "⚡ TESTA A TESTA! {players} giocatori pari a {count} allenamenti!",
+	"rankings.win_probability":              "💪 Probabilità di vittoria:",
+	"rankings.contender.leading":            "{name}: In testa! Un altro allenamento e la vittoria è tua 🏆",
+	"rankings.contender.one_behind":         "{name}: 1 allenamento indietro - ancora in gioco! 🎯",
+	"rankings.contender.two_behind":         "{name}: servono 2 allenamenti - c'è ancora tempo! ⏰",
+	"rankings.goals":                        "🎯 Obiettivi settimanali già raggiunti: {hit}/{count}",
+
+	// Workout replies
+	"workout.first":          "{name} ottimo lavoro!\nQuesto è il tuo primo allenamento",
+	"workout.great_work":     "Grande lavoro!",
+	"workout.stats":          "{name} {cheer}\nIl tuo grado: {rank}\nUltimo allenamento: {weekday} ({ago})\nQuesta settimana: {week}\n{streak}",
+	"workout.streak":         "{count} giorni di fila! {crowns} {cheer}",
+	"workout.streak_record":  "Serie più lunga: {longest} · 🧊 Congelamenti rimasti: {freezes}",
+	"workout.photo_prompt":   "Ottimo allenamento di {sport}!\n\nRispondi a questo messaggio con una foto per inviarla a tutti i tuoi gruppi.",
+	"duplicate.warning":      "⚠️ {name}, questa foto sembra una già pubblicata il {date}. Pubblica una foto nuova per ogni allenamento.",
+	"duplicate.refused":      "{name}, questa foto sembra una già pubblicata il {date}, quindi non conta. Pubblica una foto nuova del tuo allenamento.",
+	"review.pending":         "👀 Questa foto non sembra proprio un allenamento, quindi la controllerà un admin. Fino ad allora conta.",
+	"review.rejected":        "Il tuo allenamento del {date} in {group} è stato rifiutato dagli admin del gruppo, la foto non sembrava un allenamento. Usa /support se pensi che sia un errore.",
+	"photo.prompt":           "Bella foto! Cosa vuoi farne?",
+	"photo.prompt.now":       "Inviala ora ai gruppi",
+	"photo.prompt.save":      "Tienila per il prossimo allenamento",
+	"photo.prompt.nothing":   "Niente",
+	"photo.discarded":        "Nessun problema! La foto non verrà salvata.",
+	"photo.expired":          "Scusa, la foto è scaduta. Inviala di nuovo.",
+	"photo.no_account":       "Scusa, non trovo il tuo account. Riprova.",
+	"photo.save_failed":      "Scusa, non sono riuscito a salvare la foto. Riprova.",
+	"photo.saved":            "Ricevuto! Allegherò questa foto in automatico al tuo prossimo allenamento.",
+	"photo.sent_saved.one":   "Foto inviata a 1 gruppo e salvata per i tuoi progressi giornalieri! 📸",
+	"photo.sent_saved.other": "Foto inviata a {count} gruppi e salvata per i tuoi progressi giornalieri! 📸",
+	"photo.sent.one":         "Inviata a 1 gruppo!",
+	"photo.sent.other":       "Inviata a {count} gruppi!",
+	"streak.cheer.1":         "Continua così, supereroe!",
+	"streak.cheer.2":         "Stai dominando la serie, campione!",
+	"streak.cheer.3":         "Che serie! Continua a spingere!",
+	"streak.cheer.4":         "Tieni viva la serie, guerriero!",
+	"streak.cheer.5":         "Continua così, rockstar!",
+	"streak.cheer.6":         "Tieni viva la serie, leggenda!",
+	"streak.cheer.7":         "La tua serie è d'ispirazione!",
+	"streak.cheer.8":         "Che serie! Non mollare mai!",
+	"streak.cheer.9":         "Continua così, atleta!",
+	"streak.cheer.10":        "Che serie! Resta concentrato, superstar!",
+
+	// Canned replies for when the AI is down or over budget
+	"ai.workout.1": "Un altro in archivio. Stay hard! 💪🔥",
+	"ai.workout.2": "Nessuno lo farà al posto tuo, e tu ti sei presentato. 🏃💯",
+	"ai.workout.3": "Allena la mente, poi torna domani. 🧠⚡",
+	"ai.welcome.1": "bentornato! Il lavoro non aspetta, bello vederti all'opera. 💪",
+	"ai.welcome.2": "sei tornato, ora continua a presentarti. 🔥",
+
+	// Workout announcements
+	"announce.completed":         "🏋️ {name} ha appena completato un allenamento!",
+	"announce.completed_sport":   "🏋️ {name} ha appena completato un allenamento di {sport}!",
+	"announce.bonus":             "🏃 {name} ha aggiunto un'attività extra: {sport}",
+	"announce.bonus_not_counted": "(Questa attività non conta come un altro allenamento.)",
+	"announce.activity":          "Attività: {value}",
+	"announce.activity_type":     "Tipo di attività: {value}",
+	"announce.distance":          "Distanza: {value}",
+	"announce.pace":              "Passo: {value}",
+	"announce.time":              "Tempo: {value}",
+	"announce.duration":          "Durata: {value}",
+	"announce.avg_hr":            "FC media: {value}",
+	"announce.calories":          "Calorie: {value}",
+	"announce.strain":            "Strain: {value}",
+	"announce.relative_effort":   "Sforzo relativo: {value} (~{strain} strain)",
+	"announce.device":            "Dispositivo: {value}",
+	"announce.manual_badge":      "✍️ Registrazione manuale",
+	"announce.manual":            "{name} ha registrato {minutes} min di {activity}",
+
+	// Manual logs
+	"log.no_group":            "Prima entra in un gruppo, poi potrai registrare allenamenti con /log.",
+	"log.activity":            "Cosa hai fatto?",
+	"log.activity.run":        "Corsa",
+	"log.activity.ride":       "Bici",
+	"log.activity.walk":       "Camminata",
+	"log.activity.swim":       "Nuoto",
+	"log.activity.strength":   "Forza",
+	"log.activity.cardio":     "Cardio",
+	"log.activity.mobility":   "Mobilità",
+	"log.activity.sport":      "Sport",
+	"log.activity.other":      "Altro",
+	"log.duration":            "{activity} — quanto è durato?",
+	"log.note":                "Mandami una breve nota, oppure salta.",
+	"log.note_skip":           "Salta",
+	"log.note_text_only":      "Manda la nota come testo, oppure premi Salta.",
+	"log.groups":              "In quali gruppi deve contare?",
+	"log.submit":              "Registra",
+	"log.logging":             "Sto registrando…",
+	"log.expired":             "Questa registrazione è scaduta. Ricomincia con /log.",
+	"log.no_groups_picked":    "Scegli almeno un gruppo.",
+	"log.result.logged":       "✅ {group}: registrato",
+	"log.result.pending":      "⏳ {group}: in attesa dell'approvazione di un admin",
+	"log.result.off":          "🚫 {group}: non accetta registrazioni manuali",
+	"log.result.capped.one":   "🚫 {group}: solo 1 registrazione manuale a settimana",
+	"log.result.capped.other": "🚫 {group}: solo {count} registrazioni manuali a settimana",
+	"log.result.too_soon":     "🚫 {group}: troppo presto dopo l'ultimo allenamento",
+	"log.result.failed":       "⚠️ {group}: qualcosa è andato storto, riprova",
+	"log.approved":            "I tuoi {minutes} min di {activity} sono stati approvati in {group} ✅",
+	"log.rejected":            "I tuoi {minutes} min di {activity} non sono stati approvati in {group}.",
+	"log.refused":             "I tuoi {minutes} min di {activity} sono stati approvati, ma non possono più essere registrati:\n{reason}",
+
+	// Weekly goals
+	"goal.no_group":   "Prima entra in un gruppo, poi potrai fissare un obiettivo settimanale con /goal.",
+	"goal.none":       "Non hai ancora un obiettivo settimanale. Impegnati per un numero di allenamenti a settimana con /goal seguito dal numero, come /goal 4.",
+	"goal.current":    "I tuoi obiettivi settimanali:\n{goals}\n\nPer cambiarne uno invia /goal seguito dal numero di allenamenti, /goal 0 lo cancella.",
+	"goal.progress":   "🎯 {group}: {done}/{goal} questa settimana",
+	"goal.invalid":    "Invia /goal seguito dal numero di allenamenti a settimana, da 0 a {max}, come /goal 4.",
+	"goal.pick_group": "Per quale gruppo è l'obiettivo?",
+	"goal.set":        "Il tuo obiettivo settimanale in {group} è fissato: {done}/{goal} finora questa settimana 🎯",
+	"goal.cleared":    "Il tuo obiettivo settimanale in {group} è stato cancellato.",
+	"goal.nudge":      "{name}, sei a {done}/{goal} allenamenti questa settimana in {group}. Mancano {days} per raggiungere il tuo obiettivo 💪",
+
+	// Workout providers
+	"provider.connect.whoop":               "Collega il tuo account Whoop per sincronizzare gli allenamenti in automatico.",
+	"provider.connect.whoop.button":        "Collega Whoop",
+	"provider.connect.garmin":              "Collega il tuo account Garmin per sincronizzare gli allenamenti in automatico.",
+	"provider.connect.garmin.button":       "Collega Garmin",
+	"provider.connect.strava":              "Collega il tuo account Strava per sincronizzare gli allenamenti in automatico.\n\nNota: questo scollega eventuali integrazioni Whoop o Garmin.",
+	"provider.connect.strava.button":       "Collega con Strava",
+	"provider.connected.whoop":             "Il tuo account Whoop è stato collegato!\n\nD'ora in poi i tuoi allenamenti verranno sincronizzati in automatico. Nota: eventuali integrazioni Strava o Garmin sono state scollegate.",
+	"provider.connected.garmin":            "Il tuo account Garmin è stato collegato!\n\nD'ora in poi i tuoi allenamenti verranno sincronizzati in automatico. Nota: eventuali integrazioni Strava o Whoop sono state scollegate.",
+	"provider.connected.strava":            "Il tuo account Strava{athlete} è stato collegato!\n\nD'ora in poi i tuoi allenamenti verranno sincronizzati in automatico. Nota: eventuali integrazioni Whoop o Garmin sono state scollegate.",
+	"provider.detected.whoop":              "Ho rilevato un allenamento: {sport}. Deve contare come allenamento?",
+	"provider.detected.garmin":             "Ho rilevato un'attività Garmin: {activity}. Deve contare come allenamento?",
+	"provider.detected.strava_second":      "Ho rilevato un'attività Strava: {activity} ({sport}). È il tuo secondo allenamento di oggi. Deve contare come allenamento?",
+	"provider.detected.ignored":            "Capito. Non segnalerò questa attività.",
+	"provider.detected.already_registered": "Questo allenamento è già stato registrato in automatico.",
+	"provider.detected.failed":             "Scusa, c'è stato un errore nell'elaborare il tuo allenamento. Riprova.",
+	"provider.garmin.bulk_sync":            "Ho rilevato una sincronizzazione Garmin insolitamente grande e ho tenuto solo l'attività più recente per evitare caricamenti in blocco per errore.",
+	"provider.garmin.permissions_revoked":  "Il tuo account Garmin è stato scollegato perché tutti i permessi sono stati revocati.",
+	"provider.garmin.disconnected":         "Il tuo account Garmin è stato scollegato.",
+	"provider.garmin.data_by":              "Dati forniti da Garmin",
+	"provider.strava.revoked":              "Il tuo account Strava è stato scollegato perché hai revocato l'accesso nelle impostazioni di Strava.",
+	"provider.strava.powered_by":           "Powered by Strava",
+	"provider.strava.view":                 "Vedi su Strava",
+
+	// Instagram spotlight
+	"spotlight.no_handle":     "Prima registra il tuo profilo Instagram con /instagram tuo_profilo 📸",
+	"spotlight.not_own":       "Puoi chiedere lo spotlight solo per le tue foto 🙅",
+	"spotlight.cooldown":      "Sei già stato in vetrina di recente! Potrai richiederlo di nuovo tra {remaining} ⏳",
+	"spotlight.cooldown_soon": "Sei già stato in vetrina di recente! Riprova tra poco ⏳",
+	"spotlight.processing":    "Il tuo spotlight è già in lavorazione, tieni duro! ⚙️",
+	"spotlight.started":       "📸 Ricevuto! Sto preparando il tuo spotlight Instagram... può volerci un minuto.",
+	"spotlight.featured":      "🚀 BOOM! Sei in vetrina sull'Instagram di FatBot!\n\nProfilo: @{handle}\nStato: {status}\nAllenamenti: {workouts}\n\nGuarda l'account principale per vedere il tuo spotlight! 💪\nhttps://www.instagram.com/fatbot.fit",
+}
@@ -0,0 +1,98 @@
+// Package imagehash finds recycled workout photos. It computes a difference
+// hash, which stays the same when a photo is re-compressed, resized or
+// slightly cropped, so near-duplicates are a few bits apart.
+package imagehash
+
+import (
+	"bytes"
+	"fmt"
+	"image"
+	"image/color"
+	"image/draw"
+	"image/jpeg"
+	_ "image/png"
+	"math/bits"
+	"strconv"
+
+	xdraw "golang.org/x/image/draw"
+)
+
+// Hash is a 64 bit difference hash.
+type Hash uint64
+
+// Compute hashes an encoded JPEG or PNG image. The image is shrunk to 9x8
+// grayscale pixels and every bit tells whether a pixel is brighter than its
+// right neighbour.
+func Compute(imageBytes []byte) (Hash, error) {
+	img, _, err := image.Decode(bytes.NewReader(imageBytes))
+	if err != nil {
+		return 0, err
+	}
+	small := image.NewGray(image.Rect(0, 0, 9, 8))
+	// A filtering kernel averages every source pixel, point sampling would
+	// hash the photo's noise and change with every resize
+	xdraw.CatmullRom.Scale(small, small.Bounds(), img, img.Bounds(), xdraw.Src, nil)
+	var hash Hash
+	for y := 0; y < 8; y++ {
+		for x := 0; x < 8; x++ {
+			hash <<= 1
+			if small.GrayAt(x, y).Y > small.GrayAt(x+1, y).Y {
+				hash |= 1
+			}
+		}
+	}
+	return hash, nil
+}
+
+// Distance counts the bits two hashes differ in, from 0 for the same photo
+// to 64.
+func Distance(a, b Hash) int {
+	return bits.OnesCount64(uint64(a ^ b))
+}
+
+// String is the hash as 16 hex digits, the way it's stored.
+func (hash Hash) String() string {
+	return fmt.Sprintf("%016x", uint64(hash))
+}
+
+func Parse(value string) (Hash, error) {
+	hash, err := strconv.ParseUint(value, 16, 64)
+	return Hash(hash), err
+}
+
+// sideBySideHeight is the height both photos are scaled to.
+const sideBySideHeight = 640
+
+// SideBySide puts two photos next to each other in one JPEG, so admins can
+// compare them at a glance.
+func SideBySide(left, right []byte) ([]byte, error) {
+	var images []image.Image
+	for _, imageBytes := range [][]byte{left, right} {
+		img, _, err := image.Decode(bytes.NewReader(imageBytes))
+		if err != nil {
+			return nil, err
+		}
+		images = append(images, img)
+	}
+	const gap = 16
+	widths := make([]int, len(images))
+	total := gap * (len(images) - 1)
+	for i, img := range images {
+		bounds := img.Bounds()
+		widths[i] = bounds.Dx() * sideBySideHeight / max(bounds.Dy(), 1)
+		total += widths[i]
+	}
+	canvas := image.NewRGBA(image.Rect(0, 0, total, sideBySideHeight))
+	draw.Draw(canvas, canvas.Bounds(), image.NewUniform(color.White), image.Point{}, draw.Src)
+	x := 0
+	for i, img := range images {
+		target := image.Rect(x, 0, x+widths[i], sideBySideHeight)
+		xdraw.CatmullRom.Scale(canvas, target, img, img.Bounds(), xdraw.Over, nil)
+		x += widths[i] + gap
+	}
+	var out bytes.Buffer
+	if err := jpeg.Encode(&out, canvas, &jpeg.Options{Quality: 85}); err != nil {
+		return nil, err
+	}
+	return out.Bytes(), nil
+}
@@ -0,0 +1,92 @@
+package imagehash
+
+import (
+	"bytes"
+	"image"
+	"image/color"
+	"image/jpeg"
+	"image/png"
+	"math/rand"
+	"testing"
+
+	xdraw "golang.org/x/image/draw"
+)
+
+// photo draws a diagonal gradient with noise on every pixel, like the grain
+// of a real photo, flipped for a different picture.
+func photo(width, height int, flip bool) image.Image {
+	noise := rand.New(rand.NewSource(1))
+	img := image.NewRGBA(image.Rect(0, 0, width, height))
+	for y := 0; y < height; y++ {
+		for x := 0; x < width; x++ {
+			value := (x*255/width + y*255/height) / 2
+			if flip && (x/(width/4)+y/(height/4))%2 == 0 {
+				value = 255 - value
+			}
+			value = min(max(value+noise.Intn(81)-40, 0), 255)
+			img.Set(x, y, color.RGBA{uint8(value), uint8(value / 2), uint8(255 - value), 255})
+		}
+	}
+	return img
+}
+
+func resize(img image.Image, width, height int) image.Image {
+	resized := image.NewRGBA(image.Rect(0, 0, width, height))
+	xdraw.BiLinear.Scale(resized, resized.Bounds(), img, img.Bounds(), xdraw.Src, nil)
+	return resized
+}
+
+func encodeJPEG(t *testing.T, img image.Image, quality int) []byte {
+	var buffer bytes.Buffer
+	if err := jpeg.Encode(&buffer, img, &jpeg.Options{Quality: quality}); err != nil {
+		t.Fatal(err)
+	}
+	return buffer.Bytes()
+}
+
+func TestNearDuplicates(t *testing.T) {
+	picture := photo(1280, 960, false)
+	original, err := Compute(encodeJPEG(t, picture, 95))
+	if err != nil {
+		t.Fatal(err)
+	}
+	// Re-posted smaller and more compressed, the way Telegram does it
+	recycled, err := Compute(encodeJPEG(t, resize(picture, 800, 600), 70))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if distance := Distance(original, recycled); distance > 2 {
+		t.Errorf("resized copy is %d bits away", distance)
+	}
+	var lossless bytes.Buffer
+	png.Encode(&lossless, resize(picture, 400, 300))
+	if shrunk, err := Compute(lossless.Bytes()); err != nil || Distance(original, shrunk) > 2 {
+		t.Errorf("PNG copy is %d bits away, %v", Distance(original, shrunk), err)
+	}
+	other, err := Compute(encodeJPEG(t, photo(800, 600, true), 95))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if distance := Distance(original, other); distance < 10 {
+		t.Errorf("different photo is only %d bits away", distance)
+	}
+
+	parsed, err := Parse(original.String())
+	if err != nil || parsed != original {
+		t.Errorf("Parse(%s) = %s, %v", original, parsed, err)
+	}
+}
+
+func TestSideBySide(t *testing.T) {
+	combined, err := SideBySide(encodeJPEG(t, photo(300, 600, false), 90), encodeJPEG(t, photo(800, 400, true), 90))
+	if err != nil {
+		t.Fatal(err)
+	}
+	img, err := jpeg.Decode(bytes.NewReader(combined))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if bounds := img.Bounds(); bounds.Dy() != sideBySideHeight || bounds.Dx() != 320+16+1280 {
+		t.Errorf("got a %dx%d image", bounds.Dx(), bounds.Dy())
+	}
+}
@@ -2,6 +2,7 @@ package instagram
 
 import (
 	"encoding/json"
+	"fatbot/metrics"
 	"fmt"
 	"io"
 	"net/http"
@@ -12,6 +13,8 @@ import (
 	"github.com/spf13/viper"
 )
 
+var httpClient = metrics.InstrumentClient("instagram", &http.Client{Timeout: 60 * time.Second})
+
 type ContainerResponse struct {
 	ID string `json:"id"`
 }
@@ -40,7 +43,7 @@ func PublishStory(imageURL, caption string) (string, error) {
 	params.Set("access_token", accessToken)
 
 	log.Debug("Creating story container")
-	resp, err := http.PostForm(containerURL, params)
+	resp, err := httpClient.PostForm(containerURL, params)
 	if err != nil {
 		return "", err
 	}
@@ -69,7 +72,7 @@ func PublishStory(imageURL, caption string) (string, error) {
 	params.Set("access_token", accessToken)
 
 	log.Debug("Publishing story container")
-	resp, err = http.PostForm(publishURL, params)
+	resp, err = httpClient.PostForm(publishURL, params)
 	if err != nil {
 		return "", err
 	}
@@ -106,7 +109,7 @@ func PublishPost(imageURL, caption string) (string, error) {
 	params.Set("access_token", accessToken)
 
 	log.Debug("Creating post container")
-	resp, err := http.PostForm(containerURL, params)
+	resp, err := httpClient.PostForm(containerURL, params)
 	if err != nil {
 		return "", err
 	}
@@ -135,7 +138,7 @@ func PublishPost(imageURL, caption string) (string, error) {
 	params.Set("access_token", accessToken)
 
 	log.Debug("Publishing post container")
-	resp, err = http.PostForm(publishURL, params)
+	resp, err = httpClient.PostForm(publishURL, params)
 	if err != nil {
 		return "", err
 	}
@@ -164,7 +167,7 @@ func waitForContainer(containerID, accessToken string) error {
 	log.Debug("Waiting for media container to be processed by Meta...", "containerID", containerID)
 
 	for i := 0; i < 12; i++ { // Wait up to 60 seconds (12 * 5s)
-		resp, err := http.Get(checkURL + "?" + params.Encode())
+		resp, err := httpClient.Get(checkURL + "?" + params.Encode())
 		if err != nil {
 			return err
 		}
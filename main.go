@@ -1,13 +1,22 @@
 package main
 
 import (
+	"context"
+	"fatbot/backup"
 	"fatbot/db"
+	"fatbot/metrics"
+	"fatbot/migrations"
 	"fatbot/schedule"
+	"fatbot/state"
 	"fatbot/updates"
-	"fatbot/users"
+	"fatbot/vision"
+	"flag"
+	"fmt"
 	"net/http"
 	"os"
+	"os/signal"
 	"strings"
+	"syscall"
 	"time"
 
 	"github.com/charmbracelet/log"
@@ -51,6 +60,14 @@ func setupBotCommands(bot *tgbotapi.BotAPI) {
 			Command:     "cancel",
 			Description: "Cancel your last workout (within a few minutes)",
 		},
+		{
+			Command:     "log",
+			Description: "Log a workout without a photo",
+		},
+		{
+			Command:     "goal",
+			Description: "Set a weekly workout goal (e.g. /goal 4)",
+		},
 		{
 			Command:     "whoop",
 			Description: "Connect Whoop Account",
@@ -75,6 +92,18 @@ func setupBotCommands(bot *tgbotapi.BotAPI) {
 			Command:     "support",
 			Description: "Send a message to the support team",
 		},
+		{
+			Command:     "language",
+			Description: "Change the language I talk to you in",
+		},
+		{
+			Command:     "export",
+			Description: "Download all your data",
+		},
+		{
+			Command:     "delete_me",
+			Description: "Delete your account and data",
+		},
 		{
 			Command:     "creategroup",
 			Description: "Create your own workout group",
@@ -101,12 +130,131 @@ func setupBotCommands(bot *tgbotapi.BotAPI) {
 	log.Info("Bot commands menu has been set up successfully")
 }
 
+// shutdown stops taking updates and lets in-flight handlers, scheduled jobs
+// and HTTP requests finish within shutdown.timeout_seconds before closing
+// the connections, so a deploy doesn't leave half-applied bans or workouts.
+func shutdown(bot *tgbotapi.BotAPI, server *http.Server, dispatcher *updates.Dispatcher, updatesChannel tgbotapi.UpdatesChannel) {
+	timeout := time.Duration(viper.GetInt("shutdown.timeout_seconds")) * time.Second
+	if timeout <= 0 {
+		timeout = 25 * time.Second
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), timeout)
+	defer cancel()
+	log.Info("Shutting down", "timeout", timeout)
+
+	if updates.TelegramWebhookEnabled() {
+		updates.StopTelegramWebhook()
+	} else {
+		bot.StopReceivingUpdates()
+	}
+	if err := server.Shutdown(ctx); err != nil {
+		log.Error("HTTP server shutdown", "err", err)
+	}
+	// Updates already received won't be redelivered by Telegram
+	for pending := true; pending; {
+		select {
+		case update, ok := <-updatesChannel:
+			if pending = ok; ok {
+				dispatcher.Submit(update)
+			}
+		default:
+			pending = false
+		}
+	}
+	if err := schedule.Stop(ctx); err != nil {
+		log.Error(err)
+	}
+	if err := dispatcher.Drain(ctx); err != nil {
+		log.Error(err)
+	}
+
+	// Opt-in: during a rolling deploy the old instance would otherwise delete
+	// the webhook the new instance has just registered.
+	if updates.TelegramWebhookEnabled() && viper.GetBool("telegram.webhook.delete_on_shutdown") {
+		if err := updates.DeleteTelegramWebhook(bot); err != nil {
+			log.Error(err)
+		} else {
+			log.Info("Telegram webhook deleted")
+		}
+	}
+	if err := state.Close(); err != nil {
+		log.Error("Closing state store", "err", err)
+	}
+	if err := db.Close(); err != nil {
+		log.Error("Closing database", "err", err)
+	}
+	log.Info("Shutdown complete")
+}
+
+// runMigrateCommand handles the -migrate flag, which manages the schema
+// without starting the bot.
+func runMigrateCommand(command string) {
+	var err error
+	switch command {
+	case "up":
+		err = migrations.Run()
+	case "status":
+		err = migrations.PrintStatus(os.Stdout)
+	case "down":
+		err = migrations.Rollback()
+	default:
+		err = fmt.Errorf("unknown migrate command %s, expected up, status or down", command)
+	}
+	if err != nil {
+		log.Fatal(err)
+	}
+}
+
+// runBackupCommand handles the -backup flag: list prints the stored backups,
+// restore <key> <path> downloads one to a new file.
+func runBackupCommand(command string, args []string) {
+	store, err := backup.NewS3Store()
+	if err != nil {
+		log.Fatal(err)
+	}
+	switch command {
+	case "list":
+		var objects []backup.Object
+		if objects, err = backup.Newest(store); err == nil {
+			for _, object := range objects {
+				fmt.Printf("%s\t%s\t%d\n", object.LastModified.Format(time.RFC3339), object.Key, object.Size)
+			}
+		}
+	case "restore":
+		if len(args) != 2 {
+			log.Fatal("usage: -backup restore <key> <path>")
+		}
+		if err = backup.Restore(store, args[0], args[1]); err == nil {
+			log.Info("Backup restored", "key", args[0], "path", args[1])
+		}
+	default:
+		err = fmt.Errorf("unknown backup command %s, expected list or restore", command)
+	}
+	if err != nil {
+		log.Fatal(err)
+	}
+}
+
 func main() {
 	var bot *tgbotapi.BotAPI
 	var err error
 	var updatesChannel tgbotapi.UpdatesChannel
+	var server *http.Server
+	migrateCommand := flag.String("migrate", "", "run a migration command and exit: up, status or down (rolls back the last migration)")
+	backupCommand := flag.String("backup", "", "run a backup command and exit: list, or restore <key> <path>")
+	flag.Parse()
 	// Init Config
 	initViper()
+	if *backupCommand != "" {
+		runBackupCommand(*backupCommand, flag.Args())
+		return
+	}
+	if err := state.InitStore(); err != nil {
+		log.Fatal(err)
+	}
+	if err := vision.Init(); err != nil {
+		log.Fatal(err)
+	}
 	// Init DB
 	db.DBCon = db.GetDB()
 	log.SetLevel(log.DebugLevel)
@@ -122,7 +270,11 @@ func main() {
 		}
 		defer sentry.Flush(2 * time.Second)
 	}
-	if err := users.InitDB(); err != nil {
+	if *migrateCommand != "" {
+		runMigrateCommand(*migrateCommand)
+		return
+	}
+	if err := migrations.Run(); err != nil {
 		log.Fatal(err)
 	}
 
@@ -134,6 +286,11 @@ func main() {
 		bot.Debug = os.Getenv("DEBUG") == "true"
 		log.Infof("Authorized on account %s", bot.Self.UserName)
 
+		port := os.Getenv("PORT")
+		if port == "" {
+			port = "8080"
+		}
+		server = &http.Server{Addr: ":" + port}
 		go func() {
 			http.HandleFunc("/whoop-callback", updates.HandleWhoopCallback)
 			http.HandleFunc("/whoop-webhook", updates.HandleWhoopWebhook)
@@ -143,12 +300,12 @@ func main() {
 			http.HandleFunc("/garmin-permissions-change", updates.HandleGarminPermissionsChange)
 			http.HandleFunc("/strava-callback", updates.HandleStravaCallback)
 			http.HandleFunc("/strava-webhook", updates.HandleStravaWebhook)
-			port := os.Getenv("PORT")
-			if port == "" {
-				port = "8080"
-			}
+			http.HandleFunc("/telegram-webhook", updates.HandleTelegramWebhook)
+			http.Handle("/metrics", metrics.Handler())
+			http.HandleFunc("/healthz", updates.HandleHealthz)
+			http.HandleFunc("/readyz", updates.HandleReadyz)
 			log.Infof("Starting HTTP server on port %s", port)
-			if err := http.ListenAndServe(":"+port, nil); err != nil {
+			if err := server.ListenAndServe(); err != nil && err != http.ErrServerClosed {
 				log.Errorf("HTTP server failed: %s", err)
 			}
 		}()
@@ -156,17 +313,47 @@ func main() {
 		// Set up the bot commands menu
 		setupBotCommands(bot)
 
-		u := tgbotapi.NewUpdate(0)
-		u.Timeout = 60
-		u.AllowedUpdates = []string{"message", "callback_query", "poll", "poll_answer", "my_chat_member"}
-		updatesChannel = bot.GetUpdatesChan(u)
+		if updates.TelegramWebhookEnabled() {
+			if updatesChannel, err = updates.SetTelegramWebhook(bot); err != nil {
+				log.Fatal(err)
+			}
+		} else {
+			// getUpdates is refused while a webhook is set, e.g. after switching modes
+			if err := updates.DeleteTelegramWebhook(bot); err != nil {
+				log.Error(err)
+				sentry.CaptureException(err)
+			}
+			u := tgbotapi.NewUpdate(0)
+			u.Timeout = 60
+			u.AllowedUpdates = updates.AllowedUpdates
+			updatesChannel = bot.GetUpdatesChan(u)
+		}
 	}
-	fatBotUpdate := updates.FatBotUpdate{Bot: bot}
-	for update := range updatesChannel {
-		fatBotUpdate.Update = update
-		if err := updates.HandleUpdates(fatBotUpdate); err != nil {
-			log.Error(err)
-			sentry.CaptureException(err)
+	dispatcher := updates.NewDispatcher(
+		bot,
+		viper.GetInt("dispatcher.workers"),
+		viper.GetInt("dispatcher.queue_size"),
+		time.Duration(viper.GetInt("dispatcher.timeout_seconds"))*time.Second,
+	)
+	dispatcher.Start()
+	go dispatcher.LogQueueDepth(time.Minute, viper.GetInt64("dispatcher.queue_warn_depth"))
+
+	stop, stopSignals := signal.NotifyContext(context.Background(), syscall.SIGINT, syscall.SIGTERM)
+	defer stopSignals()
+	for {
+		select {
+		case update, ok := <-updatesChannel:
+			if ok {
+				dispatcher.Submit(update)
+				continue
+			}
+			log.Warn("Update channel closed")
+			shutdown(bot, server, dispatcher, updatesChannel)
+			return
+		case <-stop.Done():
+			stopSignals()
+			shutdown(bot, server, dispatcher, updatesChannel)
+			return
 		}
 	}
 }
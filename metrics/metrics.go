@@ -0,0 +1,146 @@
+package metrics
+
+import (
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
+)
+
+const namespace = "fatbot"
+
+var (
+	UpdatesHandled = promauto.NewCounterVec(prometheus.CounterOpts{
+		Namespace: namespace,
+		Name:      "updates_handled_total",
+		Help:      "Telegram updates handled, by update type.",
+	}, []string{"type"})
+
+	HandlerErrors = promauto.NewCounterVec(prometheus.CounterOpts{
+		Namespace: namespace,
+		Name:      "handler_errors_total",
+		Help:      "Update handlers that returned an error or panicked, by update type.",
+	}, []string{"type"})
+
+	UpdatesQueued = promauto.NewGauge(prometheus.GaugeOpts{
+		Namespace: namespace,
+		Name:      "updates_queued",
+		Help:      "Updates waiting in the dispatcher queue.",
+	})
+
+	UpdatesRunning = promauto.NewGauge(prometheus.GaugeOpts{
+		Namespace: namespace,
+		Name:      "updates_running",
+		Help:      "Updates currently being handled.",
+	})
+
+	WorkoutsCreated = promauto.NewCounterVec(prometheus.CounterOpts{
+		Namespace: namespace,
+		Name:      "workouts_created_total",
+		Help:      "Workouts created, by source (photo, whoop, garmin, strava, immunity).",
+	}, []string{"source"})
+
+	Bans = promauto.NewCounter(prometheus.CounterOpts{
+		Namespace: namespace,
+		Name:      "bans_total",
+		Help:      "Users banned from a group.",
+	})
+
+	Rejoins = promauto.NewCounter(prometheus.CounterOpts{
+		Namespace: namespace,
+		Name:      "rejoins_total",
+		Help:      "Banned users that rejoined.",
+	})
+
+	ProviderRequestDuration = promauto.NewHistogramVec(prometheus.HistogramOpts{
+		Namespace: namespace,
+		Name:      "provider_request_duration_seconds",
+		Help:      "Latency of requests to external APIs, by provider and status class.",
+		Buckets:   prometheus.DefBuckets,
+	}, []string{"provider", "status"})
+
+	ProviderRequestFailures = promauto.NewCounterVec(prometheus.CounterOpts{
+		Namespace: namespace,
+		Name:      "provider_request_failures_total",
+		Help:      "Requests to external APIs that failed or got a 4xx/5xx response.",
+	}, []string{"provider"})
+
+	AIRequestDuration = promauto.NewHistogramVec(prometheus.HistogramOpts{
+		Namespace: namespace,
+		Name:      "ai_request_duration_seconds",
+		Help:      "Latency of OpenAI and Rekognition calls, by service, operation and result.",
+		Buckets:   []float64{0.25, 0.5, 1, 2, 4, 8, 16, 32},
+	}, []string{"service", "operation", "result"})
+
+	JobDuration = promauto.NewHistogramVec(prometheus.HistogramOpts{
+		Namespace: namespace,
+		Name:      "job_duration_seconds",
+		Help:      "Duration of scheduled jobs.",
+		Buckets:   []float64{0.1, 0.5, 1, 5, 15, 30, 60, 120, 300, 600},
+	}, []string{"job"})
+
+	RedisErrors = promauto.NewCounterVec(prometheus.CounterOpts{
+		Namespace: namespace,
+		Name:      "redis_errors_total",
+		Help:      "Failed Redis commands.",
+	}, []string{"command"})
+)
+
+// Handler serves the metrics in the Prometheus text format.
+func Handler() http.Handler {
+	return promhttp.Handler()
+}
+
+// ObserveAI records the latency of an OpenAI or Rekognition call started at start.
+func ObserveAI(service, operation string, start time.Time, err error) {
+	result := "ok"
+	if err != nil {
+		result = "error"
+	}
+	AIRequestDuration.WithLabelValues(service, operation, result).Observe(time.Since(start).Seconds())
+}
+
+// TimeJob wraps a scheduled job so its duration is recorded.
+func TimeJob(job string, fn func()) func() {
+	return func() {
+		start := time.Now()
+		defer func() {
+			JobDuration.WithLabelValues(job).Observe(time.Since(start).Seconds())
+		}()
+		fn()
+	}
+}
+
+// InstrumentClient returns a copy of the client that records latency and
+// failures of every request under the given provider name.
+func InstrumentClient(provider string, client *http.Client) *http.Client {
+	instrumented := *client
+	base := client.Transport
+	if base == nil {
+		base = http.DefaultTransport
+	}
+	instrumented.Transport = &providerTransport{provider: provider, base: base}
+	return &instrumented
+}
+
+type providerTransport struct {
+	provider string
+	base     http.RoundTripper
+}
+
+func (transport *providerTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	start := time.Now()
+	resp, err := transport.base.RoundTrip(req)
+	status := "error"
+	if err == nil {
+		status = fmt.Sprintf("%dxx", resp.StatusCode/100)
+	}
+	ProviderRequestDuration.WithLabelValues(transport.provider, status).Observe(time.Since(start).Seconds())
+	if err != nil || resp.StatusCode >= 400 {
+		ProviderRequestFailures.WithLabelValues(transport.provider).Inc()
+	}
+	return resp, err
+}
@@ -0,0 +1,34 @@
+package metrics
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/prometheus/client_golang/prometheus/testutil"
+)
+
+func TestInstrumentClientCountsFailures(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/fail" {
+			w.WriteHeader(http.StatusBadGateway)
+		}
+	}))
+	defer server.Close()
+
+	client := InstrumentClient("test", server.Client())
+	for _, path := range []string{"/ok", "/fail", "/ok"} {
+		resp, err := client.Get(server.URL + path)
+		if err != nil {
+			t.Fatal(err)
+		}
+		resp.Body.Close()
+	}
+
+	if got := testutil.ToFloat64(ProviderRequestFailures.WithLabelValues("test")); got != 1 {
+		t.Errorf("got %v failures, want 1", got)
+	}
+	if got := testutil.CollectAndCount(ProviderRequestDuration, "fatbot_provider_request_duration_seconds"); got != 2 {
+		t.Errorf("got %d status series, want 2xx and 5xx", got)
+	}
+}
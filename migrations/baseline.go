@@ -0,0 +1,141 @@
+package migrations
+
+import (
+	"time"
+
+	"gorm.io/gorm"
+)
+
+// The schema from before versioned migrations, frozen for create_schema.
+// The models in users keep changing, and later migrations add their columns,
+// so these snapshots must never be edited.
+
+type baselineUser struct {
+	gorm.Model
+	Username       string
+	Name           string
+	NickName       string
+	TelegramUserID int64
+	Active         bool
+	IsAdmin        bool
+	OnProbation    bool
+	Immuned        bool
+
+	Rank          int
+	RankUpdatedAt *time.Time
+
+	WhoopID           string
+	WhoopUserID       int64
+	WhoopAccessToken  string
+	WhoopRefreshToken string
+	WhoopTokenExpiry  time.Time
+
+	GarminAccessToken  string
+	GarminRefreshToken string
+	GarminTokenExpiry  time.Time
+	GarminUserID       string
+
+	StravaAccessToken  string
+	StravaRefreshToken string
+	StravaTokenExpiry  time.Time
+	StravaAthleteID    string
+
+	InstagramHandle string
+
+	Workouts []baselineWorkout `gorm:"foreignKey:UserID"`
+	Events   []baselineEvent   `gorm:"foreignKey:UserID"`
+}
+
+func (baselineUser) TableName() string { return "users" }
+
+type baselineBlacklist struct {
+	gorm.Model
+	TelegramUserID int64
+}
+
+func (baselineBlacklist) TableName() string { return "blacklists" }
+
+type baselineGroup struct {
+	gorm.Model
+	ChatID              int64
+	Approved            bool
+	Title               string
+	BestAverageWorkouts float64 `gorm:"default:0"`
+	Slug                string
+	CreatorID           int64
+	Autonomous          bool
+	Workouts            []baselineWorkout     `gorm:"foreignKey:GroupID"`
+	Settings            baselineGroupSettings `gorm:"foreignKey:GroupID"`
+}
+
+func (baselineGroup) TableName() string { return "groups" }
+
+type baselineWorkout struct {
+	gorm.Model
+	UserID          uint
+	GroupID         uint
+	PhotoMessageID  int
+	PhotoFileID     string
+	Flagged         bool
+	Streak          int
+	WhoopID         string
+	GarminID        string
+	StravaID        string
+	NotifyMessageID int
+	NotifyChatID    int64
+}
+
+func (baselineWorkout) TableName() string { return "workouts" }
+
+type baselineEvent struct {
+	gorm.Model
+	UserID  uint
+	GroupID int64
+	Event   string
+}
+
+func (baselineEvent) TableName() string { return "events" }
+
+type baselineWorkoutDisputePoll struct {
+	gorm.Model
+	PollID    string `gorm:"uniqueIndex"`
+	GroupID   uint
+	UserID    uint
+	WorkoutID uint
+	MessageID int
+}
+
+func (baselineWorkoutDisputePoll) TableName() string { return "workout_dispute_polls" }
+
+type baselineUserGroup struct {
+	UserID    uint `gorm:"primaryKey"`
+	GroupID   uint `gorm:"primaryKey"`
+	CreatedAt time.Time
+}
+
+func (baselineUserGroup) TableName() string { return "user_groups" }
+
+type baselineGroupAdmin struct {
+	GroupID uint          `gorm:"primaryKey"`
+	UserID  uint          `gorm:"primaryKey"`
+	Group   baselineGroup `gorm:"foreignKey:GroupID"`
+	User    baselineUser  `gorm:"foreignKey:UserID"`
+}
+
+func (baselineGroupAdmin) TableName() string { return "groups_admins" }
+
+type baselineGroupSettings struct {
+	gorm.Model
+	GroupID              uint `gorm:"uniqueIndex"`
+	UploadWindowDays     *int
+	WarningLeadDays      *int
+	WarningHour          *int
+	NewUserGraceDays     *int
+	WorkoutPeriodMinutes *int
+	RejoinWaitHours      *int
+	Timezone             *string
+	ReportDay            *string
+	ReportHour           *int
+}
+
+func (baselineGroupSettings) TableName() string { return "group_settings" }
@@ -2,51 +2,47 @@ package migrations
 
 import (
 	"fatbot/db"
+	"fmt"
 
 	"github.com/charmbracelet/log"
+	"gorm.io/gorm"
 )
 
-// AddProviderWorkoutUniqueIndexes adds partial unique indexes that prevent the
-// same integration activity from being inserted twice for the same group.
-func AddProviderWorkoutUniqueIndexes() {
-	database := db.DBCon
+var providerWorkoutColumns = []string{"whoop_id", "garmin_id", "strava_id"}
 
-	indexes := []struct {
-		name  string
-		query string
-	}{
-		{
-			name: "idx_workouts_group_whoop_id",
-			query: `
-				CREATE UNIQUE INDEX IF NOT EXISTS idx_workouts_group_whoop_id
-				ON workouts(group_id, whoop_id)
-				WHERE whoop_id IS NOT NULL AND whoop_id != ''
-			`,
-		},
-		{
-			name: "idx_workouts_group_garmin_id",
-			query: `
-				CREATE UNIQUE INDEX IF NOT EXISTS idx_workouts_group_garmin_id
-				ON workouts(group_id, garmin_id)
-				WHERE garmin_id IS NOT NULL AND garmin_id != ''
-			`,
-		},
-		{
-			name: "idx_workouts_group_strava_id",
-			query: `
-				CREATE UNIQUE INDEX IF NOT EXISTS idx_workouts_group_strava_id
-				ON workouts(group_id, strava_id)
-				WHERE strava_id IS NOT NULL AND strava_id != ''
-			`,
-		},
+// addProviderWorkoutUniqueIndexes adds partial unique indexes that prevent the
+// same integration activity from being inserted twice for the same group.
+func addProviderWorkoutUniqueIndexes(database *gorm.DB) error {
+	for _, column := range providerWorkoutColumns {
+		name := "idx_workouts_group_" + column
+		if err := database.Exec(partialUniqueIndexQuery(database.Dialector.Name(), name, column)).Error; err != nil {
+			return fmt.Errorf("failed to create index %s: %w", name, err)
+		}
+		log.Info("Successfully ensured provider workout unique index", "index", name)
 	}
+	return nil
+}
 
-	for _, index := range indexes {
-		result := database.Exec(index.query)
-		if result.Error != nil {
-			log.Error("Failed to create provider workout unique index", "index", index.name, "error", result.Error)
-			continue
+func dropProviderWorkoutUniqueIndexes(database *gorm.DB) error {
+	for _, column := range providerWorkoutColumns {
+		if err := database.Exec("DROP INDEX IF EXISTS idx_workouts_group_" + column).Error; err != nil {
+			return err
 		}
-		log.Info("Successfully ensured provider workout unique index", "index", index.name)
 	}
+	return nil
+}
+
+// partialUniqueIndexQuery builds a unique index on (group_id, column) that
+// skips empty ids. Postgres builds it concurrently so the workouts table stays
+// writable while the index is created on a large table.
+func partialUniqueIndexQuery(dialect, name, column string) string {
+	create := "CREATE UNIQUE INDEX"
+	if dialect == db.Postgres {
+		create = "CREATE UNIQUE INDEX CONCURRENTLY"
+	}
+	return fmt.Sprintf(`
+		%s IF NOT EXISTS %s
+		ON workouts(group_id, %s)
+		WHERE %s IS NOT NULL AND %s <> ''
+	`, create, name, column, column, column)
 }
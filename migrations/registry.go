@@ -0,0 +1,284 @@
+package migrations
+
+import (
+	"fatbot/ai"
+	"fatbot/users"
+
+	"gorm.io/gorm"
+)
+
+// registry is the ordered list of migrations. Append new ones with the next
+// version number and never renumber or edit one that has shipped.
+var registry = []Migration{
+	{
+		// Brings a fresh database up to the schema that predates versioned
+		// migrations. On existing databases it's a no-op.
+		Version: 1,
+		Name:    "create_schema",
+		Up: func(tx *gorm.DB) error {
+			return tx.AutoMigrate(
+				&baselineUser{},
+				&baselineGroup{},
+				&baselineWorkout{},
+				&baselineEvent{},
+				&baselineBlacklist{},
+				&baselineWorkoutDisputePoll{},
+				&baselineUserGroup{},
+				&baselineGroupAdmin{},
+				&baselineGroupSettings{},
+			)
+		},
+	},
+	{
+		Version: 2,
+		Name:    "backfill_group_slugs",
+		Up: func(tx *gorm.DB) error {
+			var groups []users.Group
+			if err := tx.Where("slug IS NULL OR slug = ''").Find(&groups).Error; err != nil {
+				return err
+			}
+			for _, group := range groups {
+				slug := users.GenerateUniqueSlugIn(tx, group.Title)
+				if err := tx.Model(&group).Update("slug", slug).Error; err != nil {
+					return err
+				}
+			}
+			return nil
+		},
+	},
+	{
+		Version:       3,
+		Name:          "provider_workout_unique_indexes",
+		Up:            addProviderWorkoutUniqueIndexes,
+		Down:          dropProviderWorkoutUniqueIndexes,
+		NoTransaction: true,
+	},
+	{
+		Version: 4,
+		Name:    "create_audit_entries",
+		Up: func(tx *gorm.DB) error {
+			return tx.AutoMigrate(&users.AuditEntry{})
+		},
+		Down: func(tx *gorm.DB) error {
+			return tx.Migrator().DropTable(&users.AuditEntry{})
+		},
+	},
+	{
+		Version: 5,
+		Name:    "create_job_runs",
+		Up: func(tx *gorm.DB) error {
+			return tx.AutoMigrate(&users.JobRun{})
+		},
+		Down: func(tx *gorm.DB) error {
+			return tx.Migrator().DropTable(&users.JobRun{})
+		},
+	},
+	{
+		// Databases created while create_schema still used the live models
+		// already have the columns of this and later migrations
+		Version: 6,
+		Name:    "add_languages",
+		Up: func(tx *gorm.DB) error {
+			for _, model := range []interface{}{&users.User{}, &users.GroupSettings{}} {
+				if tx.Migrator().HasColumn(model, "Language") {
+					continue
+				}
+				if err := tx.Migrator().AddColumn(model, "Language"); err != nil {
+					return err
+				}
+			}
+			return nil
+		},
+		Down: func(tx *gorm.DB) error {
+			if err := tx.Migrator().DropColumn(&users.User{}, "Language"); err != nil {
+				return err
+			}
+			return tx.Migrator().DropColumn(&users.GroupSettings{}, "Language")
+		},
+	},
+	{
+		Version: 7,
+		Name:    "add_workout_metrics",
+		Up: func(tx *gorm.DB) error {
+			for _, field := range workoutMetricFields {
+				if tx.Migrator().HasColumn(&users.Workout{}, field) {
+					continue
+				}
+				if err := tx.Migrator().AddColumn(&users.Workout{}, field); err != nil {
+					return err
+				}
+			}
+			return nil
+		},
+		Down: func(tx *gorm.DB) error {
+			for _, field := range workoutMetricFields {
+				if err := tx.Migrator().DropColumn(&users.Workout{}, field); err != nil {
+					return err
+				}
+			}
+			return nil
+		},
+	},
+	{
+		Version: 8,
+		Name:    "add_manual_logs",
+		Up: func(tx *gorm.DB) error {
+			columns := []struct {
+				model interface{}
+				field string
+			}{
+				{&users.Workout{}, "Note"},
+				{&users.GroupSettings{}, "ManualLogs"},
+				{&users.GroupSettings{}, "ManualWeeklyCap"},
+			}
+			for _, column := range columns {
+				if tx.Migrator().HasColumn(column.model, column.field) {
+					continue
+				}
+				if err := tx.Migrator().AddColumn(column.model, column.field); err != nil {
+					return err
+				}
+			}
+			return nil
+		},
+		Down: func(tx *gorm.DB) error {
+			if err := tx.Migrator().DropColumn(&users.Workout{}, "Note"); err != nil {
+				return err
+			}
+			if err := tx.Migrator().DropColumn(&users.GroupSettings{}, "ManualLogs"); err != nil {
+				return err
+			}
+			return tx.Migrator().DropColumn(&users.GroupSettings{}, "ManualWeeklyCap")
+		},
+	},
+	{
+		Version: 9,
+		Name:    "add_photo_hashes",
+		Up: func(tx *gorm.DB) error {
+			columns := []struct {
+				model interface{}
+				field string
+			}{
+				{&users.Workout{}, "PhotoHash"},
+				{&users.Workout{}, "DuplicateOfID"},
+				{&users.GroupSettings{}, "DuplicatePhotos"},
+			}
+			for _, column := range columns {
+				if tx.Migrator().HasColumn(column.model, column.field) {
+					continue
+				}
+				if err := tx.Migrator().AddColumn(column.model, column.field); err != nil {
+					return err
+				}
+			}
+			if tx.Migrator().HasIndex(&users.Workout{}, "PhotoHash") {
+				return nil
+			}
+			return tx.Migrator().CreateIndex(&users.Workout{}, "PhotoHash")
+		},
+		Down: func(tx *gorm.DB) error {
+			if err := tx.Migrator().DropIndex(&users.Workout{}, "PhotoHash"); err != nil {
+				return err
+			}
+			if err := tx.Migrator().DropColumn(&users.Workout{}, "PhotoHash"); err != nil {
+				return err
+			}
+			if err := tx.Migrator().DropColumn(&users.Workout{}, "DuplicateOfID"); err != nil {
+				return err
+			}
+			return tx.Migrator().DropColumn(&users.GroupSettings{}, "DuplicatePhotos")
+		},
+	},
+	{
+		Version: 10,
+		Name:    "add_workout_reviews",
+		Up: func(tx *gorm.DB) error {
+			columns := []struct {
+				model interface{}
+				field string
+			}{
+				{&users.Workout{}, "Plausibility"},
+				{&users.Workout{}, "Review"},
+				{&users.GroupSettings{}, "Plausibility"},
+			}
+			for _, column := range columns {
+				if tx.Migrator().HasColumn(column.model, column.field) {
+					continue
+				}
+				if err := tx.Migrator().AddColumn(column.model, column.field); err != nil {
+					return err
+				}
+			}
+			if tx.Migrator().HasIndex(&users.Workout{}, "Review") {
+				return nil
+			}
+			return tx.Migrator().CreateIndex(&users.Workout{}, "Review")
+		},
+		Down: func(tx *gorm.DB) error {
+			if err := tx.Migrator().DropIndex(&users.Workout{}, "Review"); err != nil {
+				return err
+			}
+			if err := tx.Migrator().DropColumn(&users.Workout{}, "Plausibility"); err != nil {
+				return err
+			}
+			if err := tx.Migrator().DropColumn(&users.Workout{}, "Review"); err != nil {
+				return err
+			}
+			return tx.Migrator().DropColumn(&users.GroupSettings{}, "Plausibility")
+		},
+	},
+	{
+		Version: 11,
+		Name:    "create_ai_token_usages",
+		Up: func(tx *gorm.DB) error {
+			return tx.AutoMigrate(&ai.TokenUsage{})
+		},
+		Down: func(tx *gorm.DB) error {
+			return tx.Migrator().DropTable(&ai.TokenUsage{})
+		},
+	},
+	{
+		Version: 12,
+		Name:    "add_streak_freeze_days",
+		Up: func(tx *gorm.DB) error {
+			if tx.Migrator().HasColumn(&users.GroupSettings{}, "StreakFreezeDays") {
+				return nil
+			}
+			return tx.Migrator().AddColumn(&users.GroupSettings{}, "StreakFreezeDays")
+		},
+		Down: func(tx *gorm.DB) error {
+			return tx.Migrator().DropColumn(&users.GroupSettings{}, "StreakFreezeDays")
+		},
+	},
+	{
+		Version: 13,
+		Name:    "add_weekly_goals",
+		Up: func(tx *gorm.DB) error {
+			if tx.Migrator().HasColumn(&users.UserGroup{}, "WeeklyGoal") {
+				return nil
+			}
+			return tx.Migrator().AddColumn(&users.UserGroup{}, "WeeklyGoal")
+		},
+		Down: func(tx *gorm.DB) error {
+			return tx.Migrator().DropColumn(&users.UserGroup{}, "WeeklyGoal")
+		},
+	},
+	{
+		Version: 14,
+		Name:    "add_metrics_backfill_failures",
+		Up: func(tx *gorm.DB) error {
+			if tx.Migrator().HasColumn(&users.Workout{}, "MetricsBackfillFailures") {
+				return nil
+			}
+			return tx.Migrator().AddColumn(&users.Workout{}, "MetricsBackfillFailures")
+		},
+		Down: func(tx *gorm.DB) error {
+			return tx.Migrator().DropColumn(&users.Workout{}, "MetricsBackfillFailures")
+		},
+	},
+}
+
+var workoutMetricFields = []string{
+	"Sport", "Category", "StartedAt", "EndedAt", "DurationMinutes", "DistanceMeters", "Calories",
+	"AvgHeartRate", "MaxHeartRate", "Strain", "MetricsSource", "Device", "Manual",
+}
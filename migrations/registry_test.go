@@ -0,0 +1,52 @@
+package migrations
+
+import (
+	"fatbot/ai"
+	"fatbot/users"
+	"testing"
+)
+
+func TestRegistryBuildsCurrentSchema(t *testing.T) {
+	database := openTestDB(t)
+	if err := up(database, registry); err != nil {
+		t.Fatal(err)
+	}
+	models := []interface{}{
+		&users.User{}, &users.Group{}, &users.Workout{}, &users.Event{}, &users.Blacklist{},
+		&users.WorkoutDisputePoll{}, &users.UserGroup{}, &users.GroupSettings{},
+		&users.AuditEntry{}, &users.JobRun{}, &ai.TokenUsage{},
+	}
+	for _, model := range models {
+		statement := database.Model(model).Statement
+		if err := statement.Parse(model); err != nil {
+			t.Fatal(err)
+		}
+		for _, field := range statement.Schema.Fields {
+			if field.DBName != "" && !database.Migrator().HasColumn(model, field.DBName) {
+				t.Errorf("%s.%s is missing", statement.Schema.Table, field.DBName)
+			}
+		}
+	}
+}
+
+func TestCreateSchemaIsFrozen(t *testing.T) {
+	database := openTestDB(t)
+	if err := registry[0].Up(database); err != nil {
+		t.Fatal(err)
+	}
+	// Added by later migrations, which would be no-ops if create_schema made them
+	for _, column := range []struct {
+		model interface{}
+		name  string
+	}{
+		{&users.User{}, "language"},
+		{&users.Workout{}, "note"},
+		{&users.Workout{}, "metrics_backfill_failures"},
+		{&users.GroupSettings{}, "streak_freeze_days"},
+		{&users.UserGroup{}, "weekly_goal"},
+	} {
+		if database.Migrator().HasColumn(column.model, column.name) {
+			t.Errorf("create_schema made %s", column.name)
+		}
+	}
+}
@@ -0,0 +1,204 @@
+package migrations
+
+import (
+	"fatbot/db"
+	"fmt"
+	"io"
+	"sort"
+	"text/tabwriter"
+	"time"
+
+	"github.com/charmbracelet/log"
+	"gorm.io/gorm"
+)
+
+// Migration is a single versioned schema or data change. Versions are applied
+// in ascending order and each one runs exactly once per database.
+type Migration struct {
+	Version int
+	Name    string
+	Up      func(tx *gorm.DB) error
+	// Down reverts Up. Migrations without one can't be rolled back.
+	Down func(tx *gorm.DB) error
+	// NoTransaction runs the migration outside a transaction, for statements
+	// that refuse to run in one (e.g. CREATE INDEX CONCURRENTLY on Postgres).
+	NoTransaction bool
+}
+
+// SchemaMigration records an applied migration.
+type SchemaMigration struct {
+	Version   int `gorm:"primaryKey;autoIncrement:false"`
+	Name      string
+	AppliedAt time.Time
+}
+
+// MigrationStatus is a migration along with when it was applied, if ever.
+type MigrationStatus struct {
+	Migration
+	AppliedAt *time.Time
+}
+
+// Run applies all pending migrations against db.DBCon.
+func Run() error {
+	return up(db.DBCon, registry)
+}
+
+// Rollback reverts the most recently applied migration.
+func Rollback() error {
+	return down(db.DBCon, registry)
+}
+
+// Status lists every known migration and whether it was applied.
+func Status() ([]MigrationStatus, error) {
+	return status(db.DBCon, registry)
+}
+
+// PrintStatus writes the migration status as a table.
+func PrintStatus(w io.Writer) error {
+	statuses, err := Status()
+	if err != nil {
+		return err
+	}
+	table := tabwriter.NewWriter(w, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(table, "VERSION\tNAME\tAPPLIED AT")
+	for _, migration := range statuses {
+		appliedAt := "pending"
+		if migration.AppliedAt != nil {
+			appliedAt = migration.AppliedAt.Format(time.RFC3339)
+		}
+		fmt.Fprintf(table, "%d\t%s\t%s\n", migration.Version, migration.Name, appliedAt)
+	}
+	return table.Flush()
+}
+
+func up(database *gorm.DB, migrations []Migration) error {
+	if err := validate(migrations); err != nil {
+		return err
+	}
+	applied, err := appliedVersions(database)
+	if err != nil {
+		return err
+	}
+	for _, migration := range sorted(migrations) {
+		if _, ok := applied[migration.Version]; ok {
+			continue
+		}
+		log.Info("Applying migration", "version", migration.Version, "name", migration.Name)
+		err := runInTransaction(database, migration.NoTransaction, func(tx *gorm.DB) error {
+			if err := migration.Up(tx); err != nil {
+				return err
+			}
+			return tx.Create(&SchemaMigration{
+				Version:   migration.Version,
+				Name:      migration.Name,
+				AppliedAt: time.Now(),
+			}).Error
+		})
+		if err != nil {
+			return fmt.Errorf("migration %d %s failed: %w", migration.Version, migration.Name, err)
+		}
+	}
+	return nil
+}
+
+func down(database *gorm.DB, migrations []Migration) error {
+	var last SchemaMigration
+	if err := ensureTable(database); err != nil {
+		return err
+	}
+	result := database.Order("version DESC").Limit(1).Find(&last)
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return fmt.Errorf("no migrations to roll back")
+	}
+	var migration *Migration
+	for i := range migrations {
+		if migrations[i].Version == last.Version {
+			migration = &migrations[i]
+		}
+	}
+	if migration == nil {
+		return fmt.Errorf("migration %d %s is not known to this build", last.Version, last.Name)
+	}
+	if migration.Down == nil {
+		return fmt.Errorf("migration %d %s can't be rolled back", migration.Version, migration.Name)
+	}
+	log.Info("Rolling back migration", "version", migration.Version, "name", migration.Name)
+	err := runInTransaction(database, migration.NoTransaction, func(tx *gorm.DB) error {
+		if err := migration.Down(tx); err != nil {
+			return err
+		}
+		return tx.Delete(&SchemaMigration{}, migration.Version).Error
+	})
+	if err != nil {
+		return fmt.Errorf("rollback of migration %d %s failed: %w", migration.Version, migration.Name, err)
+	}
+	return nil
+}
+
+func status(database *gorm.DB, migrations []Migration) ([]MigrationStatus, error) {
+	applied, err := appliedVersions(database)
+	if err != nil {
+		return nil, err
+	}
+	var statuses []MigrationStatus
+	for _, migration := range sorted(migrations) {
+		migrationStatus := MigrationStatus{Migration: migration}
+		if record, ok := applied[migration.Version]; ok {
+			migrationStatus.AppliedAt = &record.AppliedAt
+		}
+		statuses = append(statuses, migrationStatus)
+	}
+	return statuses, nil
+}
+
+func runInTransaction(database *gorm.DB, noTransaction bool, fn func(tx *gorm.DB) error) error {
+	if noTransaction {
+		return fn(database)
+	}
+	return database.Transaction(fn)
+}
+
+func ensureTable(database *gorm.DB) error {
+	return database.AutoMigrate(&SchemaMigration{})
+}
+
+func appliedVersions(database *gorm.DB) (map[int]SchemaMigration, error) {
+	if err := ensureTable(database); err != nil {
+		return nil, err
+	}
+	var records []SchemaMigration
+	if err := database.Find(&records).Error; err != nil {
+		return nil, err
+	}
+	applied := make(map[int]SchemaMigration, len(records))
+	for _, record := range records {
+		applied[record.Version] = record
+	}
+	return applied, nil
+}
+
+func validate(migrations []Migration) error {
+	seen := make(map[int]string, len(migrations))
+	for _, migration := range migrations {
+		if migration.Version <= 0 {
+			return fmt.Errorf("migration %s has an invalid version %d", migration.Name, migration.Version)
+		}
+		if name, ok := seen[migration.Version]; ok {
+			return fmt.Errorf("migrations %s and %s share version %d", name, migration.Name, migration.Version)
+		}
+		if migration.Up == nil {
+			return fmt.Errorf("migration %d %s has no Up", migration.Version, migration.Name)
+		}
+		seen[migration.Version] = migration.Name
+	}
+	return nil
+}
+
+func sorted(migrations []Migration) []Migration {
+	result := append([]Migration(nil), migrations...)
+	sort.Slice(result, func(i, j int) bool { return result[i].Version < result[j].Version })
+	return result
+}
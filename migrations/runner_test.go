@@ -0,0 +1,156 @@
+package migrations
+
+import (
+	"fmt"
+	"testing"
+
+	"gorm.io/driver/sqlite"
+	"gorm.io/gorm"
+	"gorm.io/gorm/logger"
+)
+
+type widget struct {
+	ID   uint
+	Name string
+}
+
+func openTestDB(t *testing.T) *gorm.DB {
+	database, err := gorm.Open(sqlite.Open(fmt.Sprintf("file:%s?mode=memory&cache=shared", t.Name())),
+		&gorm.Config{Logger: logger.Default.LogMode(logger.Silent)})
+	if err != nil {
+		t.Fatal(err)
+	}
+	return database
+}
+
+func widgetMigrations(applied *[]int) []Migration {
+	return []Migration{
+		{
+			Version: 2,
+			Name:    "seed_widget",
+			Up: func(tx *gorm.DB) error {
+				*applied = append(*applied, 2)
+				return tx.Create(&widget{Name: "first"}).Error
+			},
+		},
+		{
+			Version: 1,
+			Name:    "create_widgets",
+			Up: func(tx *gorm.DB) error {
+				*applied = append(*applied, 1)
+				return tx.AutoMigrate(&widget{})
+			},
+			Down: func(tx *gorm.DB) error {
+				return tx.Migrator().DropTable(&widget{})
+			},
+		},
+	}
+}
+
+func TestUpAppliesInOrderOnce(t *testing.T) {
+	database := openTestDB(t)
+	var applied []int
+	migrations := widgetMigrations(&applied)
+
+	for i := 0; i < 2; i++ {
+		if err := up(database, migrations); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if fmt.Sprint(applied) != "[1 2]" {
+		t.Errorf("applied %v, want [1 2]", applied)
+	}
+
+	statuses, err := status(database, migrations)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, migrationStatus := range statuses {
+		if migrationStatus.AppliedAt == nil {
+			t.Errorf("migration %d not recorded", migrationStatus.Version)
+		}
+	}
+}
+
+func TestUpRollsBackFailedMigration(t *testing.T) {
+	database := openTestDB(t)
+	var applied []int
+	migrations := append(widgetMigrations(&applied), Migration{
+		Version: 3,
+		Name:    "broken",
+		Up: func(tx *gorm.DB) error {
+			if err := tx.Create(&widget{Name: "second"}).Error; err != nil {
+				return err
+			}
+			return fmt.Errorf("boom")
+		},
+	})
+
+	if err := up(database, migrations); err == nil {
+		t.Fatal("expected the broken migration to fail")
+	}
+	var count int64
+	database.Model(&widget{}).Count(&count)
+	if count != 1 {
+		t.Errorf("got %d widgets, want the failed insert rolled back", count)
+	}
+	statuses, err := status(database, migrations)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if statuses[2].AppliedAt != nil {
+		t.Error("failed migration was recorded as applied")
+	}
+}
+
+func TestDown(t *testing.T) {
+	database := openTestDB(t)
+	var applied []int
+	migrations := widgetMigrations(&applied)
+	if err := up(database, migrations); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := down(database, migrations); err == nil {
+		t.Error("expected migration without Down to refuse the rollback")
+	}
+
+	if err := down(database, migrations[1:]); err == nil {
+		t.Error("expected rollback of an unknown migration to fail")
+	}
+
+	database.Delete(&SchemaMigration{}, 2)
+	if err := down(database, migrations); err != nil {
+		t.Fatal(err)
+	}
+	if database.Migrator().HasTable(&widget{}) {
+		t.Error("widgets table still exists after rollback")
+	}
+	if err := down(database, migrations); err == nil {
+		t.Error("expected an error with nothing left to roll back")
+	}
+}
+
+func TestValidateRejectsDuplicateVersions(t *testing.T) {
+	noop := func(tx *gorm.DB) error { return nil }
+	err := validate([]Migration{
+		{Version: 1, Name: "a", Up: noop},
+		{Version: 1, Name: "b", Up: noop},
+	})
+	if err == nil {
+		t.Error("expected duplicate versions to be rejected")
+	}
+}
+
+func TestRegistry(t *testing.T) {
+	database := openTestDB(t)
+	if err := up(database, registry); err != nil {
+		t.Fatal(err)
+	}
+	if err := down(database, registry); err != nil {
+		t.Fatal(err)
+	}
+	if err := up(database, registry); err != nil {
+		t.Fatal(err)
+	}
+}
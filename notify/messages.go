@@ -0,0 +1,67 @@
+package notify
+
+import (
+	"fatbot/i18n"
+	"fatbot/users"
+	"fmt"
+	"strings"
+	"time"
+)
+
+// StatsMessage is the reply to a workout: the AI cheer, rank, time since the
+// last workout, workouts this week out of the weekly goal if there is one
+// and the streak with its record and the freezes left. user must have this
+// cycle's workouts loaded.
+func StatsMessage(lang i18n.Lang, user users.User, lastWorkout users.Workout, streak users.Streak, goal int, aiResponse string) string {
+	if lastWorkout.CreatedAt.IsZero() {
+		return i18n.T(lang, "workout.first", "name", user.GetName())
+	}
+	if aiResponse == "" {
+		aiResponse = i18n.T(lang, "workout.great_work")
+	}
+	ranks := users.GetRanks()
+	userRank, ok := ranks[user.Rank]
+	if !ok {
+		userRank = ranks[1]
+	}
+	var streakMessage string
+	if streak.Current > 1 {
+		streakMessage = i18n.T(lang, "workout.streak",
+			"count", streak.Current, "crowns", strings.Repeat("👑", streak.Current), "cheer", users.GetRandomStreakMessage(lang)) + "\n"
+	}
+	if streak.Longest > 1 {
+		streakMessage += i18n.T(lang, "workout.streak_record",
+			"longest", i18n.N(lang, "days", streak.Longest), "freezes", streak.Freezes)
+	}
+	return i18n.T(lang, "workout.stats",
+		"name", user.GetName(),
+		"cheer", aiResponse,
+		"rank", fmt.Sprintf("%s %s (%d/%d)", userRank.Name, userRank.Emoji, user.Rank, len(ranks)),
+		"weekday", Weekday(lang, lastWorkout.CreatedAt.Weekday()),
+		"ago", TimeAgo(lang, time.Since(lastWorkout.CreatedAt)),
+		"week", weekProgress(len(user.Workouts), goal),
+		"streak", streakMessage,
+	)
+}
+
+// weekProgress reads like "3/4" toward a weekly goal, or just "3" without one.
+func weekProgress(done, goal int) string {
+	if goal == 0 {
+		return fmt.Sprint(done)
+	}
+	return fmt.Sprintf("%d/%d", done, goal)
+}
+
+// TimeAgo reads like "5 hours ago" or "2 days and 3 hours ago".
+func TimeAgo(lang i18n.Lang, since time.Duration) string {
+	hours := int(since.Hours())
+	if hours < 24 {
+		return i18n.N(lang, "ago.hours", hours)
+	}
+	return i18n.T(lang, "ago.days_hours",
+		"days", i18n.N(lang, "days", hours/24), "hours", i18n.N(lang, "hours", hours%24))
+}
+
+func Weekday(lang i18n.Lang, weekday time.Weekday) string {
+	return i18n.T(lang, fmt.Sprintf("weekday.%d", weekday))
+}
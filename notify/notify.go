@@ -3,12 +3,13 @@ package notify
 import (
 	"fatbot/ai"
 	"fatbot/db"
+	"fatbot/i18n"
 	"fatbot/state"
 	"fatbot/strava"
 	"fatbot/users"
 	"fatbot/whoop"
 	"fmt"
-	"time"
+	"html"
 
 	"github.com/charmbracelet/log"
 	tgbotapi "github.com/go-telegram-bot-api/telegram-bot-api/v5"
@@ -16,67 +17,45 @@ import (
 
 func NotifyWorkout(bot *tgbotapi.BotAPI, user users.User, workout users.Workout, sportName string, strain float64, calories float64, avgHR int, durationMins float64, distance float64, deviceName string, activityType string) {
 	group, _ := users.GetGroupByID(workout.GroupID)
+	lang := group.Lang()
 
-	// Calculate streak for this specific group
 	lastWorkout, err := user.GetLastXWorkout(2, group.ChatID) // 2 because the current one is already in DB
-	streak := 0
-
-	if err == nil {
-		if users.IsTodayOrWasYesterday(lastWorkout.CreatedAt) {
-			if lastWorkout.Streak > 0 {
-				streak = lastWorkout.Streak + 1
-			} else {
-				streak = 2
-			}
-		}
+	streak, streakErr := workout.UpdateStreak(&user, *group)
+	if streakErr != nil {
+		log.Errorf("Failed to update the streak of %s: %s", user.GetName(), streakErr)
 	}
 
-	workout.Streak = streak
-	db.DBCon.Save(&workout)
-
 	// Main Announcement
 	var msgText string
 	if workout.GarminID != "" {
 		msgText = fmt.Sprintf("<b>GARMIN</b>\n\n")
-		msgText += fmt.Sprintf("🏋️ %s just completed a workout!\n\n", user.GetName())
+		msgText += i18n.T(lang, "announce.completed", "name", user.GetName()) + "\n\n"
 		displayActivityType := activityType
 		if displayActivityType == "" {
 			displayActivityType = sportName
 		}
-		msgText += fmt.Sprintf("• Activity Type: %s\n", displayActivityType)
+		msgText += "• " + i18n.T(lang, "announce.activity_type", "value", displayActivityType) + "\n"
 		if distance > 0 {
 			distanceKm := distance / 1000.0
-			msgText += fmt.Sprintf("• Distance: %.2f km\n", distanceKm)
+			msgText += "• " + i18n.T(lang, "announce.distance", "value", fmt.Sprintf("%.2f km", distanceKm)) + "\n"
 			if durationMins > 0 {
 				pace := durationMins / distanceKm
 				paceMins := int(pace)
 				paceSecs := int((pace - float64(paceMins)) * 60)
-				msgText += fmt.Sprintf("• Pace/Speed: %d:%02d min/km\n", paceMins, paceSecs)
+				msgText += "• " + i18n.T(lang, "announce.pace", "value", fmt.Sprintf("%d:%02d min/km", paceMins, paceSecs)) + "\n"
 			}
 		}
-		msgText += fmt.Sprintf("• Time: %.0f min\n", durationMins)
-		msgText += fmt.Sprintf("• Average HR: %d bpm\n", avgHR)
+		msgText += "• " + i18n.T(lang, "announce.time", "value", fmt.Sprintf("%.0f min", durationMins)) + "\n"
+		msgText += "• " + i18n.T(lang, "announce.avg_hr", "value", fmt.Sprintf("%d bpm", avgHR)) + "\n"
 		if calories > 0 {
-			msgText += fmt.Sprintf("• Calories: %.0f kcal\n", calories)
+			msgText += "• " + i18n.T(lang, "announce.calories", "value", fmt.Sprintf("%.0f kcal", calories)) + "\n"
 		}
 		if deviceName != "" {
-			msgText += fmt.Sprintf("• Device Model: %s\n", deviceName)
+			msgText += "• " + i18n.T(lang, "announce.device", "value", deviceName) + "\n"
 		}
-		msgText += "\n<i>Data provided by Garmin</i>"
+		msgText += "\n<i>" + i18n.T(lang, "provider.garmin.data_by") + "</i>"
 	} else {
-		msgText = fmt.Sprintf(
-			"🏋️ %s just completed a %s workout!\n\n",
-			user.GetName(),
-			sportName,
-		)
-		if strain > 0 {
-			msgText += fmt.Sprintf("Strain: %.1f\n", strain)
-		}
-		msgText += fmt.Sprintf("Calories: %.0f\nAvg HR: %d\nDuration: %.0f min",
-			calories,
-			avgHR,
-			durationMins,
-		)
+		msgText = whoopAnnouncement(lang, user, sportName, strain, calories, avgHR, durationMins)
 	}
 	msg := tgbotapi.NewMessage(group.ChatID, msgText)
 	msg.ParseMode = "HTML"
@@ -94,59 +73,20 @@ func NotifyWorkout(bot *tgbotapi.BotAPI, user users.User, workout users.Workout,
 		log.Errorf("Failed to load workouts for user %s: %s", user.GetName(), err)
 	}
 
-	ranks := users.GetRanks()
-	var userRank users.Rank
-	if user.Rank >= 0 && user.Rank < len(ranks) {
-		userRank = ranks[user.Rank]
-	} else if len(ranks) > 0 {
-		userRank = ranks[0]
-	}
-
-	timeAgo := ""
-	if err == nil && !lastWorkout.CreatedAt.IsZero() {
-		hours := time.Now().Sub(lastWorkout.CreatedAt).Hours()
-		if int(hours/24) == 0 {
-			timeAgo = fmt.Sprintf("%d hours ago", int(hours))
-		} else {
-			days := int(hours / 24)
-			timeAgo = fmt.Sprintf("%d days and %d hours ago", days, int(hours)-days*24)
-		}
-	}
-
-	var streakMessage string
-	if streak > 0 {
-		streakSigns := ""
-		for i := 0; i < streak; i++ {
-			streakSigns += "👑"
-		}
-		streakMessage = fmt.Sprintf("%d in a row! %s %s", streak, streakSigns, users.GetRandomStreakMessage())
+	if err != nil {
+		lastWorkout = users.Workout{}
 	}
-
-	aiResponse := ai.GetAiWhoopResponse(sportName, strain, calories, avgHR, durationMins)
-	if aiResponse == "" {
-		aiResponse = "Great work!"
+	var aiResponse string
+	if !lastWorkout.CreatedAt.IsZero() {
+		aiResponse = ai.GetAiWhoopResponse(lang, group.ChatID, sportName, strain, calories, avgHR, durationMins)
 	}
-
-	var statsMessage string
-	if err != nil || lastWorkout.CreatedAt.IsZero() {
-		statsMessage = fmt.Sprintf("%s nice work!\nThis is your first workout", user.GetName())
-	} else {
-		statsMessage = fmt.Sprintf("%s %s\nYour rank: %s\nLast workout: %s (%s)\nThis week: %d\n%s",
-			user.GetName(),
-			aiResponse,
-			fmt.Sprintf("%s %s (%d/%d)",
-				userRank.Name,
-				userRank.Emoji,
-				user.Rank,
-				len(ranks)),
-			lastWorkout.CreatedAt.Weekday(),
-			timeAgo,
-			len(user.Workouts),
-			streakMessage,
-		)
+	goal, err := user.GetWeeklyGoal(*group)
+	if err != nil {
+		log.Errorf("Failed to get the weekly goal of %s: %s", user.GetName(), err)
 	}
+	statsMessage := StatsMessage(lang, user, lastWorkout, streak, goal, aiResponse)
 	if workout.GarminID != "" {
-		statsMessage += "\n\n<i>Data provided by Garmin</i>"
+		statsMessage += "\n\n<i>" + i18n.T(lang, "provider.garmin.data_by") + "</i>"
 	}
 	msg = tgbotapi.NewMessage(group.ChatID, statsMessage)
 	msg.ParseMode = "HTML"
@@ -159,7 +99,7 @@ func SendWorkoutPM(bot *tgbotapi.BotAPI, user users.User, sportName string) {
 	if _, err := state.GetPendingPhoto(user.TelegramUserID); err == nil {
 		return
 	}
-	pm := tgbotapi.NewMessage(user.TelegramUserID, fmt.Sprintf("Great job on your %s workout!\n\nReply to this message with a photo to send it to all your groups.", sportName))
+	pm := tgbotapi.NewMessage(user.TelegramUserID, i18n.T(user.Lang(), "workout.photo_prompt", "sport", sportName))
 	bot.Send(pm)
 }
 
@@ -204,40 +144,30 @@ func ApplyPendingPhoto(bot *tgbotapi.BotAPI, user users.User, workouts []users.W
 // NotifyStravaWorkout sends a Strava-specific workout notification to the group
 func NotifyStravaWorkout(bot *tgbotapi.BotAPI, user users.User, workout users.Workout, activity *strava.ActivityData, durationMins float64) {
 	group, _ := users.GetGroupByID(workout.GroupID)
+	lang := group.Lang()
 
-	// Calculate streak for this specific group
 	lastWorkout, err := user.GetLastXWorkout(2, group.ChatID) // 2 because the current one is already in DB
-	streak := 0
-
-	if err == nil {
-		if users.IsTodayOrWasYesterday(lastWorkout.CreatedAt) {
-			if lastWorkout.Streak > 0 {
-				streak = lastWorkout.Streak + 1
-			} else {
-				streak = 2
-			}
-		}
+	streak, streakErr := workout.UpdateStreak(&user, *group)
+	if streakErr != nil {
+		log.Errorf("Failed to update the streak of %s: %s", user.GetName(), streakErr)
 	}
 
-	workout.Streak = streak
-	db.DBCon.Save(&workout)
-
 	// Build Strava-specific message
 	msgText := fmt.Sprintf("<b>STRAVA</b>\n\n")
-	msgText += fmt.Sprintf("🏋️ %s just completed a workout!\n", user.GetName())
-	msgText += fmt.Sprintf("Activity: %s\n", activity.Name)
+	msgText += i18n.T(lang, "announce.completed", "name", user.GetName()) + "\n"
+	msgText += i18n.T(lang, "announce.activity", "value", activity.Name) + "\n"
 
 	// Distance
 	if activity.Distance > 0 {
 		distanceKm := activity.Distance / 1000.0
-		msgText += fmt.Sprintf("Distance: %.2f km\n", distanceKm)
+		msgText += i18n.T(lang, "announce.distance", "value", fmt.Sprintf("%.2f km", distanceKm)) + "\n"
 
 		// Pace (for running/cycling activities)
 		if durationMins > 0 && distanceKm > 0 {
 			pace := durationMins / distanceKm
 			paceMins := int(pace)
 			paceSecs := int((pace - float64(paceMins)) * 60)
-			msgText += fmt.Sprintf("Pace: %d:%02d /km\n", paceMins, paceSecs)
+			msgText += i18n.T(lang, "announce.pace", "value", fmt.Sprintf("%d:%02d /km", paceMins, paceSecs)) + "\n"
 		}
 	}
 
@@ -245,33 +175,35 @@ func NotifyStravaWorkout(bot *tgbotapi.BotAPI, user users.User, workout users.Wo
 	hours := int(durationMins) / 60
 	mins := int(durationMins) % 60
 	if hours > 0 {
-		msgText += fmt.Sprintf("Time: %d:%02d\n", hours, mins)
+		msgText += i18n.T(lang, "announce.time", "value", fmt.Sprintf("%d:%02d", hours, mins)) + "\n"
 	} else {
-		msgText += fmt.Sprintf("Time: %d min\n", mins)
+		msgText += i18n.T(lang, "announce.time", "value", fmt.Sprintf("%d min", mins)) + "\n"
 	}
 
 	// Heart Rate
 	if activity.AverageHeartrate > 0 {
-		msgText += fmt.Sprintf("Avg HR: %.0f bpm\n", activity.AverageHeartrate)
+		msgText += i18n.T(lang, "announce.avg_hr", "value", fmt.Sprintf("%.0f bpm", activity.AverageHeartrate)) + "\n"
 	}
 
 	// Calories
 	if activity.Calories > 0 {
-		msgText += fmt.Sprintf("Calories: %.0f kcal\n", activity.Calories)
+		msgText += i18n.T(lang, "announce.calories", "value", fmt.Sprintf("%.0f kcal", activity.Calories)) + "\n"
 	}
 
 	// Suffer Score / Relative Effort (if available - Strava Premium feature)
 	if activity.SufferScore != nil && *activity.SufferScore > 0 {
 		strainEquiv := strava.SufferScoreToStrain(*activity.SufferScore)
-		msgText += fmt.Sprintf("Relative Effort: %.0f (~%.1f strain)\n", *activity.SufferScore, strainEquiv)
+		msgText += i18n.T(lang, "announce.relative_effort",
+			"value", fmt.Sprintf("%.0f", *activity.SufferScore), "strain", fmt.Sprintf("%.1f", strainEquiv)) + "\n"
 	}
 
 	// Device
 	if activity.DeviceName != "" {
-		msgText += fmt.Sprintf("Device: %s\n", activity.DeviceName)
+		msgText += i18n.T(lang, "announce.device", "value", activity.DeviceName) + "\n"
 	}
 
-	msgText += fmt.Sprintf("\n<i>Powered by Strava</i> | <a href=\"https://www.strava.com/activities/%d\">View on Strava</a>", activity.ID)
+	msgText += fmt.Sprintf("\n<i>%s</i> | <a href=\"https://www.strava.com/activities/%d\">%s</a>",
+		i18n.T(lang, "provider.strava.powered_by"), activity.ID, i18n.T(lang, "provider.strava.view"))
 
 	msg := tgbotapi.NewMessage(group.ChatID, msgText)
 	msg.ParseMode = "HTML"
@@ -282,64 +214,25 @@ func NotifyStravaWorkout(bot *tgbotapi.BotAPI, user users.User, workout users.Wo
 		log.Errorf("Failed to load workouts for user %s: %s", user.GetName(), err)
 	}
 
-	ranks := users.GetRanks()
-	var userRank users.Rank
-	if user.Rank >= 0 && user.Rank < len(ranks) {
-		userRank = ranks[user.Rank]
-	} else if len(ranks) > 0 {
-		userRank = ranks[0]
-	}
-
-	timeAgo := ""
-	if err == nil && !lastWorkout.CreatedAt.IsZero() {
-		hours := time.Now().Sub(lastWorkout.CreatedAt).Hours()
-		if int(hours/24) == 0 {
-			timeAgo = fmt.Sprintf("%d hours ago", int(hours))
-		} else {
-			days := int(hours / 24)
-			timeAgo = fmt.Sprintf("%d days and %d hours ago", days, int(hours)-days*24)
-		}
-	}
-
-	var streakMessage string
-	if streak > 0 {
-		streakSigns := ""
-		for i := 0; i < streak; i++ {
-			streakSigns += "👑"
-		}
-		streakMessage = fmt.Sprintf("%d in a row! %s %s", streak, streakSigns, users.GetRandomStreakMessage())
-	}
-
 	// Use the activity sport type for AI response
 	sportName := activity.SportType
 	if sportName == "" {
 		sportName = activity.Type
 	}
 
-	aiResponse := ai.GetAiWhoopResponse(sportName, 0, activity.Calories, int(activity.AverageHeartrate), durationMins)
-	if aiResponse == "" {
-		aiResponse = "Great work!"
+	if err != nil {
+		lastWorkout = users.Workout{}
 	}
-
-	var statsMessage string
-	if err != nil || lastWorkout.CreatedAt.IsZero() {
-		statsMessage = fmt.Sprintf("%s nice work!\nThis is your first workout", user.GetName())
-	} else {
-		statsMessage = fmt.Sprintf("%s %s\nYour rank: %s\nLast workout: %s (%s)\nThis week: %d\n%s",
-			user.GetName(),
-			aiResponse,
-			fmt.Sprintf("%s %s (%d/%d)",
-				userRank.Name,
-				userRank.Emoji,
-				user.Rank,
-				len(ranks)),
-			lastWorkout.CreatedAt.Weekday(),
-			timeAgo,
-			len(user.Workouts),
-			streakMessage,
-		)
-	}
-	statsMessage += "\n\n<i>Powered by Strava</i>"
+	var aiResponse string
+	if !lastWorkout.CreatedAt.IsZero() {
+		aiResponse = ai.GetAiWhoopResponse(lang, group.ChatID, sportName, 0, activity.Calories, int(activity.AverageHeartrate), durationMins)
+	}
+	goal, err := user.GetWeeklyGoal(*group)
+	if err != nil {
+		log.Errorf("Failed to get the weekly goal of %s: %s", user.GetName(), err)
+	}
+	statsMessage := StatsMessage(lang, user, lastWorkout, streak, goal, aiResponse)
+	statsMessage += "\n\n<i>" + i18n.T(lang, "provider.strava.powered_by") + "</i>"
 
 	msg = tgbotapi.NewMessage(group.ChatID, statsMessage)
 	msg.ParseMode = "HTML"
@@ -357,19 +250,8 @@ func EditWhoopNotification(bot *tgbotapi.BotAPI, user users.User, workout users.
 	duration := record.End.Sub(record.Start)
 
 	// Rebuild the notification text in the same format as NotifyWorkout (Whoop path)
-	msgText := fmt.Sprintf(
-		"🏋️ %s just completed a %s workout!\n\n",
-		user.GetName(),
-		record.SportName,
-	)
-	if record.Score.Strain > 0 {
-		msgText += fmt.Sprintf("Strain: %.1f\n", record.Score.Strain)
-	}
-	msgText += fmt.Sprintf("Calories: %.0f\nAvg HR: %d\nDuration: %.0f min",
-		record.Score.Kilojoule/4.184,
-		record.Score.AverageHeartRate,
-		duration.Minutes(),
-	)
+	msgText := whoopAnnouncement(users.GroupLang(workout.NotifyChatID), user, record.SportName,
+		record.Score.Strain, record.Score.Kilojoule/4.184, record.Score.AverageHeartRate, duration.Minutes())
 
 	edit := tgbotapi.NewEditMessageText(workout.NotifyChatID, workout.NotifyMessageID, msgText)
 	edit.ParseMode = "HTML"
@@ -377,3 +259,58 @@ func EditWhoopNotification(bot *tgbotapi.BotAPI, user users.User, workout users.
 		log.Errorf("Failed to edit Whoop notification (chat=%d, msg=%d): %s", workout.NotifyChatID, workout.NotifyMessageID, err)
 	}
 }
+
+func whoopAnnouncement(lang i18n.Lang, user users.User, sportName string, strain float64, calories float64, avgHR int, durationMins float64) string {
+	msgText := i18n.T(lang, "announce.completed_sport", "name", user.GetName(), "sport", sportName) + "\n\n"
+	if strain > 0 {
+		msgText += i18n.T(lang, "announce.strain", "value", fmt.Sprintf("%.1f", strain)) + "\n"
+	}
+	msgText += i18n.T(lang, "announce.calories", "value", fmt.Sprintf("%.0f", calories)) + "\n"
+	msgText += i18n.T(lang, "announce.avg_hr", "value", avgHR) + "\n"
+	msgText += i18n.T(lang, "announce.duration", "value", fmt.Sprintf("%.0f min", durationMins))
+	return msgText
+}
+
+// NotifyManualWorkout announces a workout logged with /log. The badge makes
+// it clear there's no photo or tracker behind it.
+func NotifyManualWorkout(bot *tgbotapi.BotAPI, user users.User, workout users.Workout) {
+	group, err := users.GetGroupByID(workout.GroupID)
+	if err != nil {
+		log.Errorf("Failed to get group %d for manual workout %d: %s", workout.GroupID, workout.ID, err)
+		return
+	}
+	lang := group.Lang()
+
+	msgText := "<b>" + i18n.T(lang, "announce.manual_badge") + "</b>\n\n"
+	msgText += i18n.T(lang, "announce.manual", "name", user.GetName(),
+		"activity", i18n.T(lang, "log.activity."+workout.Category), "minutes", fmt.Sprintf("%.0f", workout.DurationMinutes))
+	if workout.Note != "" {
+		msgText += "\n📝 " + html.EscapeString(workout.Note)
+	}
+	msg := tgbotapi.NewMessage(group.ChatID, msgText)
+	msg.ParseMode = "HTML"
+	if sentMsg, err := bot.Send(msg); err == nil {
+		workout.NotifyMessageID = sentMsg.MessageID
+		workout.NotifyChatID = group.ChatID
+		db.DBCon.Save(&workout)
+	}
+
+	if err := user.LoadWorkoutsThisCycle(group.ChatID); err != nil {
+		log.Errorf("Failed to load workouts for user %s: %s", user.GetName(), err)
+	}
+	lastWorkout, err := user.GetLastXWorkout(2, group.ChatID) // 2 because the current one is already in DB
+	if err != nil {
+		lastWorkout = users.Workout{}
+	}
+	streak, err := user.GetStreak(*group, workout.CreatedAt)
+	if err != nil {
+		log.Errorf("Failed to get the streak of %s: %s", user.GetName(), err)
+	}
+	goal, err := user.GetWeeklyGoal(*group)
+	if err != nil {
+		log.Errorf("Failed to get the weekly goal of %s: %s", user.GetName(), err)
+	}
+	msg = tgbotapi.NewMessage(group.ChatID, StatsMessage(lang, user, lastWorkout, streak, goal, ""))
+	msg.ParseMode = "HTML"
+	bot.Send(msg)
+}
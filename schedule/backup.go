@@ -0,0 +1,34 @@
+package schedule
+
+import (
+	"context"
+	"fatbot/backup"
+	"time"
+)
+
+// backupDatabase uploads a snapshot of the database and removes the backups
+// the retention policy no longer keeps.
+func backupDatabase(run *Run) error {
+	store, err := backup.NewS3Store()
+	if err != nil {
+		return err
+	}
+	key := backup.NewKey(time.Now())
+	if run.Act("upload a snapshot as %s", key) {
+		if _, err := backup.Create(context.Background(), store, key); err != nil {
+			return err
+		}
+	}
+	objects, err := backup.Newest(store)
+	if err != nil {
+		return err
+	}
+	for _, object := range backup.Expired(objects, time.Now(), backup.RetentionFromConfig()) {
+		if run.Act("delete %s from %s", object.Key, object.LastModified.Format("2006-01-02")) {
+			if err := store.Delete(object.Key); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
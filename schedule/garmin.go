@@ -4,6 +4,7 @@ import (
 	"encoding/json"
 	"fatbot/db"
 	"fatbot/garmin"
+	"fatbot/i18n"
 	"fatbot/notify"
 	"fatbot/state"
 	"fatbot/users"
@@ -14,31 +15,16 @@ import (
 	tgbotapi "github.com/go-telegram-bot-api/telegram-bot-api/v5"
 )
 
-func calculateStrain(avgHR int) float64 {
-	if avgHR < 60 {
-		return 0
-	}
-	// Simplified linear-ish mapping for a 1.0-21.0 scale
-	// (AvgHR - 60) / (MaxHR - 60) * 21
-	// Assuming MaxHR around 190
-	maxHR := 190.0
-	minHR := 60.0
-	strain := (float64(avgHR) - minHR) / (maxHR - minHR) * 21.0
-	if strain > 21.0 {
-		strain = 21.0
-	}
-	if strain < 0 {
-		strain = 0
-	}
-	return strain
-}
-
 func ProcessGarminActivity(bot *tgbotapi.BotAPI, user users.User, activity garmin.ActivityData) {
 	baseID := garmin.NormalizeSummaryID(activity.SummaryID)
 	activity.SummaryID = baseID
 
-	// 1. Check if already in DB
+	// 1. Check if already in DB. Garmin redelivers activities we asked to
+	// backfill, so this is where their metrics are filled in.
 	if users.GarminWorkoutExists(baseID) {
+		if err := users.SaveProviderMetrics(users.MetricsGarmin, baseID, users.GarminWorkoutMetrics(activity)); err != nil {
+			log.Errorf("Failed to save Garmin metrics for %s: %s", baseID, err)
+		}
 		return
 	}
 
@@ -74,6 +60,7 @@ func ProcessGarminActivity(bot *tgbotapi.BotAPI, user users.User, activity garmi
 	if err == nil && existing.ID != 0 && existing.GarminID == "" {
 		log.Infof("Skipping Garmin activity %s for user %s: matched existing workout %d", activity.SummaryID, user.GetName(), existing.ID)
 		existing.GarminID = activity.SummaryID
+		existing.AdoptMetrics(users.GarminWorkoutMetrics(activity))
 		db.DBCon.Save(&existing)
 		return
 	}
@@ -99,9 +86,9 @@ func ProcessGarminActivity(bot *tgbotapi.BotAPI, user users.User, activity garmi
 		state.SetWithTTL("garmin:data:"+activity.SummaryID, string(activityJSON), 86400)
 
 		// Send Question
-		msg := tgbotapi.NewMessage(user.TelegramUserID, fmt.Sprintf("I detected a Garmin activity: %s. Should this count as a workout?", activity.ActivityName))
-		yesBtn := tgbotapi.NewInlineKeyboardButtonData("Yes", fmt.Sprintf("garmin:yes:%s", activity.SummaryID))
-		noBtn := tgbotapi.NewInlineKeyboardButtonData("No", fmt.Sprintf("garmin:no:%s", activity.SummaryID))
+		msg := tgbotapi.NewMessage(user.TelegramUserID, i18n.T(user.Lang(), "provider.detected.garmin", "activity", activity.ActivityName))
+		yesBtn := tgbotapi.NewInlineKeyboardButtonData(i18n.T(user.Lang(), "common.yes"), fmt.Sprintf("garmin:yes:%s", activity.SummaryID))
+		noBtn := tgbotapi.NewInlineKeyboardButtonData(i18n.T(user.Lang(), "common.no"), fmt.Sprintf("garmin:no:%s", activity.SummaryID))
 		msg.ReplyMarkup = tgbotapi.NewInlineKeyboardMarkup(tgbotapi.NewInlineKeyboardRow(yesBtn, noBtn))
 		bot.Send(msg)
 
@@ -111,17 +98,18 @@ func ProcessGarminActivity(bot *tgbotapi.BotAPI, user users.User, activity garmi
 	}
 
 	// --- MAIN WORKOUT LOGIC ---
-	strain := calculateStrain(activity.AverageHeartRate)
+	metrics := users.GarminWorkoutMetrics(activity)
 	var workouts []users.Workout
 	for _, group := range user.Groups {
 		workout := users.Workout{
-			UserID:   user.ID,
-			GroupID:  group.ID,
-			GarminID: activity.SummaryID,
+			UserID:         user.ID,
+			GroupID:        group.ID,
+			GarminID:       activity.SummaryID,
+			WorkoutMetrics: metrics,
 		}
 		db.DBCon.Create(&workout)
 		workouts = append(workouts, workout)
-		notify.NotifyWorkout(bot, user, workout, activity.ActivityName, strain, activity.Calories, activity.AverageHeartRate, duration.Minutes(), activity.DistanceInMeters, activity.DeviceName, activity.ActivityType)
+		notify.NotifyWorkout(bot, user, workout, activity.ActivityName, metrics.Strain, activity.Calories, activity.AverageHeartRate, duration.Minutes(), activity.DistanceInMeters, activity.DeviceName, activity.ActivityType)
 	}
 
 	// If the user had pre-uploaded a photo, attach it automatically.
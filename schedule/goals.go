@@ -0,0 +1,66 @@
+package schedule
+
+import (
+	"fatbot/i18n"
+	"fatbot/users"
+	"time"
+
+	"github.com/charmbracelet/log"
+	"github.com/getsentry/sentry-go"
+	tgbotapi "github.com/go-telegram-bot-api/telegram-bot-api/v5"
+)
+
+// nudgeBehindGoals DMs the members of the group who are behind the pace of
+// their weekly goal.
+func nudgeBehindGoals(run *Run, group users.Group, rules users.GroupRules, now time.Time) {
+	goals, err := group.GetWeeklyGoals()
+	if err != nil {
+		log.Error("Error getting weekly goals", "group_id", group.ChatID, "error", err)
+		sentry.CaptureException(err)
+		return
+	}
+	daysLeft := rules.DaysUntilReport(now)
+	for i := range group.Users {
+		user := &group.Users[i]
+		goal := goals[user.ID]
+		if goal == 0 {
+			continue
+		}
+		if err := user.LoadWorkoutsThisCycle(group.ChatID); err != nil {
+			log.Error("Error loading workouts for user", "user_id", user.ID, "error", err)
+			continue
+		}
+		done := len(user.Workouts)
+		if done >= rules.GoalPace(goal, now) ||
+			!run.Act("nudge %s toward their weekly goal in %s (%d/%d)", user.GetName(), group.Title, done, goal) {
+			continue
+		}
+		msg := tgbotapi.NewMessage(user.TelegramUserID, goalNudgeText(user.Lang(), *user, group, done, goal, daysLeft))
+		if _, err := run.Bot.Request(msg); err != nil {
+			log.Error("can't send private message", "error", err)
+		}
+	}
+}
+
+// goalNudgeText tells the member where they stand and how many days are
+// left, from DaysUntilReport so a partial day counts.
+func goalNudgeText(lang i18n.Lang, user users.User, group users.Group, done, goal, daysLeft int) string {
+	return i18n.T(lang, "goal.nudge", "name", user.GetName(), "done", done, "goal", goal,
+		"group", group.Title, "days", i18n.N(lang, "days", daysLeft))
+}
+
+// goalsReached counts the members in workouts who have a weekly goal, and
+// how many of them reached it. Both maps are by user ID.
+func goalsReached(goals, workouts map[uint]int) (reached, set int) {
+	for userID, done := range workouts {
+		goal := goals[userID]
+		if goal == 0 {
+			continue
+		}
+		set++
+		if done >= goal {
+			reached++
+		}
+	}
+	return
+}
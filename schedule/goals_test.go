@@ -0,0 +1,29 @@
+package schedule
+
+import (
+	"fatbot/i18n"
+	"fatbot/users"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/spf13/viper"
+)
+
+func TestGoalNudgeDaysLeft(t *testing.T) {
+	viper.Set("timezone", "Europe/Rome")
+	viper.Set("report.day", "Saturday")
+	viper.Set("report.hour", 20)
+	rules := users.GroupSettings{}.Rules()
+	// The hourly job runs a few seconds after the standings on Wednesday
+	now := time.Date(2024, 5, 15, 20, 0, 5, 0, rules.Location())
+
+	text := goalNudgeText(i18n.English, users.User{Name: "Dana"}, users.Group{Title: "Lifters"},
+		1, 4, rules.DaysUntilReport(now))
+	if !strings.Contains(text, "1/4") || !strings.Contains(text, "3 days left") {
+		t.Errorf("got %q, want 1/4 with 3 days left", text)
+	}
+	if pace := rules.GoalPace(4, now); pace != 2 {
+		t.Errorf("got pace %d, want 2 of 4 by Wednesday", pace)
+	}
+}
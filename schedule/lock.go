@@ -0,0 +1,75 @@
+package schedule
+
+import (
+	"encoding/json"
+	"fatbot/state"
+	"fmt"
+	"os"
+	"time"
+
+	"github.com/charmbracelet/log"
+)
+
+// JobClaim records which instance ran a job and when.
+type JobClaim struct {
+	Instance string    `json:"instance"`
+	At       time.Time `json:"at"`
+}
+
+// instanceID names this process in job claims: the Fly machine when
+// deployed, otherwise the host and pid.
+var instanceID = func() string {
+	if machine := os.Getenv("FLY_MACHINE_ID"); machine != "" {
+		return machine
+	}
+	host, _ := os.Hostname()
+	return fmt.Sprintf("%s-%d", host, os.Getpid())
+}()
+
+func jobLockKey(name string) string {
+	return "schedule:lock:" + name
+}
+
+func jobLastRunKey(name string) string {
+	return "schedule:lastrun:" + name
+}
+
+// claimJobRun makes sure a job runs once per window across every instance
+// sharing the state store. The lock isn't released when the job finishes,
+// it expires with the window so a later tick on another instance is skipped.
+// Without the store nobody runs the job, running it twice is worse than
+// skipping a tick.
+func claimJobRun(name string, window time.Duration) bool {
+	claim := JobClaim{Instance: instanceID, At: time.Now()}
+	value, err := json.Marshal(claim)
+	if err != nil {
+		log.Errorf("Failed to encode claim for job %s: %s", name, err)
+		return false
+	}
+	acquired, err := state.SetNX(jobLockKey(name), string(value), int(window.Seconds()))
+	if err != nil {
+		log.Errorf("Failed to acquire lock for job %s, skipping it: %s", name, err)
+		return false
+	}
+	if !acquired {
+		if holder, err := state.Get(jobLockKey(name)); err == nil {
+			log.Debug("Job already ran on another instance", "job", name, "claim", holder)
+		}
+		return false
+	}
+	if err := state.SetWithTTL(jobLastRunKey(name), string(value), 0); err != nil {
+		log.Errorf("Failed to record run of job %s: %s", name, err)
+	}
+	log.Info("Running job", "job", name, "instance", instanceID)
+	return true
+}
+
+// LastJobClaim returns the last run of the job on any instance.
+func LastJobClaim(name string) (claim JobClaim, err error) {
+	value, err := state.Get(jobLastRunKey(name))
+	if err != nil {
+		return claim, err
+	}
+	err = json.Unmarshal([]byte(value), &claim)
+	return claim, err
+}
@@ -0,0 +1,29 @@
+package schedule
+
+import (
+	"fatbot/state"
+	"testing"
+	"time"
+)
+
+func TestClaimJobRunOncePerWindow(t *testing.T) {
+	state.SetStore(state.NewMemoryStore())
+
+	if !claimJobRun("scan_users", time.Hour) {
+		t.Fatal("first claim should run the job")
+	}
+	if claimJobRun("scan_users", time.Hour) {
+		t.Error("second claim within the window should be skipped")
+	}
+	if !claimJobRun("update_ranks", time.Hour) {
+		t.Error("claims of other jobs should not conflict")
+	}
+
+	claim, err := LastJobClaim("scan_users")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if claim.Instance != instanceID || claim.At.IsZero() {
+		t.Errorf("got claim %+v, want one by %s", claim, instanceID)
+	}
+}
@@ -0,0 +1,140 @@
+package schedule
+
+import (
+	"fatbot/garmin"
+	"fatbot/state"
+	"fatbot/strava"
+	"fatbot/users"
+	"fatbot/whoop"
+	"fmt"
+	"strconv"
+	"time"
+
+	"github.com/charmbracelet/log"
+	"github.com/spf13/viper"
+)
+
+// backfillWorkoutMetrics fills in the metrics of recent provider workouts
+// that don't have them, like the ones from before metrics were stored.
+// Whoop and Strava workouts are fetched again by ID. Garmin can't be polled,
+// so it's asked to send the user's activities again and the webhook fills
+// them in.
+func backfillWorkoutMetrics(run *Run) error {
+	since := time.Now().AddDate(0, 0, -viper.GetInt("workout.metrics.backfill_days"))
+	workouts, err := users.WorkoutsMissingMetrics(since, viper.GetInt("workout.metrics.backfill_batch"))
+	if err != nil {
+		return err
+	}
+	garminRequested := map[uint]bool{}
+	for _, workout := range workouts {
+		user, err := users.GetUser(workout.UserID)
+		if err != nil {
+			log.Warnf("Skipping metrics of workout %d: %s", workout.ID, err)
+			recordBackfillFailure(workout)
+			continue
+		}
+		switch {
+		case workout.WhoopID != "":
+			err = backfillWhoopMetrics(run, user, workout.WhoopID)
+			if err != nil {
+				log.Errorf("Failed to backfill Whoop workout %s of %s: %s", workout.WhoopID, user.GetName(), err)
+			}
+		case workout.StravaID != "":
+			err = backfillStravaMetrics(run, user, workout.StravaID)
+			if err != nil {
+				log.Errorf("Failed to backfill Strava activity %s of %s: %s", workout.StravaID, user.GetName(), err)
+			}
+		case workout.GarminID != "":
+			if garminRequested[user.ID] {
+				continue
+			}
+			garminRequested[user.ID] = true
+			err = requestGarminBackfill(run, user, since)
+			if err != nil {
+				log.Errorf("Failed to request Garmin backfill for %s: %s", user.GetName(), err)
+			}
+		}
+		if err != nil {
+			recordBackfillFailure(workout)
+		}
+	}
+	return nil
+}
+
+// recordBackfillFailure counts the failure toward giving up on the workout,
+// see users.MaxMetricsBackfillFailures.
+func recordBackfillFailure(workout users.Workout) {
+	if err := users.RecordMetricsBackfillFailure(workout); err != nil {
+		log.Errorf("Failed to record the metrics backfill failure of workout %d: %s", workout.ID, err)
+	}
+}
+
+func backfillWhoopMetrics(run *Run, user users.User, whoopID string) error {
+	if !run.Act("fetch Whoop workout %s of %s", whoopID, user.GetName()) {
+		return nil
+	}
+	accessToken, err := user.GetValidWhoopAccessToken()
+	if err != nil {
+		return err
+	}
+	record, err := whoop.GetWorkoutById(accessToken, whoopID)
+	if err != nil {
+		return err
+	}
+	return users.SaveProviderMetrics(users.MetricsWhoop, whoopID, users.WhoopWorkoutMetrics(record))
+}
+
+func backfillStravaMetrics(run *Run, user users.User, stravaID string) error {
+	activityID, err := strconv.ParseInt(stravaID, 10, 64)
+	if err != nil {
+		return err
+	}
+	if !run.Act("fetch Strava activity %s of %s", stravaID, user.GetName()) {
+		return nil
+	}
+	accessToken, err := user.GetValidStravaAccessToken()
+	if err != nil {
+		return err
+	}
+	activity, err := strava.GetActivity(accessToken, activityID)
+	if err != nil {
+		return err
+	}
+	return users.SaveProviderMetrics(users.MetricsStrava, stravaID, users.StravaWorkoutMetrics(activity))
+}
+
+// requestGarminBackfill stops a day short of now, so activities from the
+// last day are still taken as new when they arrive.
+func requestGarminBackfill(run *Run, user users.User, since time.Time) error {
+	if !run.Act("ask Garmin to resend the activities of %s since %s", user.GetName(), since.Format("2006-01-02")) {
+		return nil
+	}
+	accessToken, err := user.GetValidGarminAccessToken()
+	if err != nil {
+		return err
+	}
+	cutoff := time.Now().Add(-24 * time.Hour)
+	if err := garmin.RequestBackfill(accessToken, since, cutoff); err != nil {
+		return err
+	}
+	return state.SetWithTTL(garminBackfillKey(user.ID), strconv.FormatInt(cutoff.Unix(), 10), 86400)
+}
+
+func garminBackfillKey(userID uint) string {
+	return fmt.Sprintf("garmin:backfill:%d", userID)
+}
+
+// GarminBackfillCutoff is when the user's last Garmin backfill ends. Until
+// it's over, activities from before it are resent history and never new
+// workouts. It's zero when there's no backfill.
+func GarminBackfillCutoff(userID uint) time.Time {
+	value, err := state.Get(garminBackfillKey(userID))
+	if err != nil {
+		return time.Time{}
+	}
+	seconds, err := strconv.ParseInt(value, 10, 64)
+	if err != nil {
+		return time.Time{}
+	}
+	return time.Unix(seconds, 0)
+}
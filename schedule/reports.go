@@ -1,6 +1,7 @@
 package schedule
 
 import (
+	"fatbot/i18n"
 	"fatbot/users"
 	"fmt"
 	"os"
@@ -12,7 +13,6 @@ import (
 	"github.com/getsentry/sentry-go"
 	tgbotapi "github.com/go-telegram-bot-api/telegram-bot-api/v5"
 	quickchartgo "github.com/henomis/quickchart-go"
-	"github.com/spf13/viper"
 )
 
 type Leader struct {
@@ -30,7 +30,7 @@ type GroupScore struct {
 	IsFirstWeek     bool
 }
 
-func nudgeBannedUsers(bot *tgbotapi.BotAPI) {
+func nudgeBannedUsers(run *Run) error {
 	inactiveUsers := users.GetInactiveUsers(0)
 	for _, user := range inactiveUsers {
 		lastBanDate, err := user.GetLastBanDate()
@@ -39,170 +39,182 @@ func nudgeBannedUsers(bot *tgbotapi.BotAPI) {
 			continue
 		}
 		timeSinceBan := int(time.Now().Sub(lastBanDate).Hours())
-		waitHours := viper.GetInt("ban.wait.hours")
-		if timeSinceBan > waitHours {
-			msg := tgbotapi.NewMessage(user.TelegramUserID, "Maybe it's time to comeback?\nTap: /join")
-			if _, err := bot.Request(msg); err != nil {
+		waitHours := user.RejoinWaitHours()
+		if timeSinceBan > waitHours && run.Act("nudge %s to rejoin", user.GetName()) {
+			msg := tgbotapi.NewMessage(user.TelegramUserID, i18n.T(user.Lang(), "nudge.comeback"))
+			if _, err := run.Bot.Request(msg); err != nil {
 				log.Error("can't send private message", "error", err)
 			}
 			log.Info("sent a nudge to user", "name", user.GetName())
 		}
 	}
+	return nil
 }
 
+// CreateChart sends the weekly report to all groups right away,
+// regardless of their configured report schedule.
 func CreateChart(bot *tgbotapi.BotAPI) {
-	// Calculate group scores and rankings before sending reports
 	groupScores := calculateGroupScores()
-	totalActiveGroups := len(groupScores)
+	for _, group := range users.GetGroupsWithUsers() {
+		sendWeeklyReport(bot, group, groupScores)
+	}
+}
 
-	// Build a map for quick lookup: chatId -> rank info including historical comparison
-	type groupRankInfo struct {
-		rank         int
-		average      float64
-		isNewBest    bool
-		previousBest float64
-		isFirstWeek  bool
+func sendWeeklyReport(bot *tgbotapi.BotAPI, group users.Group, groupScores []GroupScore) {
+	if len(group.Users) == 0 {
+		return
 	}
-	rankMap := make(map[int64]groupRankInfo)
-	for i, score := range groupScores {
-		rankMap[score.Group.ChatID] = groupRankInfo{
-			rank:         i + 1,
-			average:      score.AverageWorkouts,
-			isNewBest:    score.IsNewBest,
-			previousBest: score.PreviousBest,
-			isFirstWeek:  score.IsFirstWeek,
-		}
+
+	// Skip groups with fewer than 4 members
+	if len(group.Users) < 4 {
+		log.Debug("Skipping weekly report for small group",
+			"group_id", group.ChatID,
+			"name", group.Title,
+			"member_count", len(group.Users))
+		return
 	}
 
-	groups := users.GetGroupsWithUsers()
-	for _, group := range groups {
-		if len(group.Users) == 0 {
-			continue
+	// Find this group's rank and compare it with its historical best
+	totalActiveGroups := len(groupScores)
+	var rank int
+	var score GroupScore
+	for i := range groupScores {
+		if groupScores[i].Group.ChatID == group.ChatID {
+			rank = i + 1
+			score = groupScores[i]
 		}
+	}
+	if rank > 0 {
+		var err error
+		score.IsNewBest, score.PreviousBest, err = score.Group.UpdateBestAverageIfHigher(score.AverageWorkouts)
+		if err != nil {
+			log.Error("Error updating best average for group", "group_id", group.ChatID, "error", err)
+		}
+		// Check if this is the first week (no previous record)
+		score.IsFirstWeek = score.PreviousBest == 0
+	}
 
-		// Skip groups with fewer than 4 members
-		if len(group.Users) < 4 {
-			log.Debug("Skipping weekly report for small group",
-				"group_id", group.ChatID,
-				"name", group.Title,
-				"member_count", len(group.Users))
-			continue
+	lang := group.Lang()
+	fileName := fmt.Sprintf("%d.png", group.ChatID)
+	usersWorkouts, previousWeekWorkouts, leaders := collectUsersData(group)
+	userNames := group.GetUserFixedNamesList()
+	usersStringSlice := "'" + strings.Join(userNames, "', '") + "'"
+	previousWorkoutsStringSlice := strings.Join(previousWeekWorkouts, ", ")
+	workoutsStringSlice := strings.Join(usersWorkouts, ", ")
+	chartConfig := createChartConfig(lang, usersStringSlice, previousWorkoutsStringSlice, workoutsStringSlice)
+	qc := createQuickChart(chartConfig)
+	file, err := os.Create(fileName)
+	if err != nil {
+		panic(err)
+	}
+	defer file.Close()
+	qc.Write(file)
+
+	// Get monthly standings
+	monthlyLeaders := getMonthlyLeaders(group)
+
+	msg := tgbotapi.NewPhoto(group.ChatID, tgbotapi.FilePath(fileName))
+	caption := i18n.T(lang, "report.weekly.title") + "\n"
+
+	// Add weekly leader info and select a winner
+	var selectedWinner users.User
+
+	if len(leaders) == 0 {
+		// No leaders this week
+		log.Debug("No leaders this week")
+	} else if len(leaders) == 1 {
+		// Only one leader
+		leader := leaders[0]
+		selectedWinner = leader.User
+		caption += i18n.N(lang, "report.weekly.leader", leader.Workouts, "name", leader.User.GetName())
+		if err := leader.User.RegisterWeeklyLeaderEvent(group.ChatID); err != nil {
+			log.Errorf("Error while registering weekly leader event: %s", err)
+			sentry.CaptureException(err)
 		}
+	} else {
+		// Multiple leaders
+		caption += i18n.N(lang, "report.weekly.leaders", leaders[0].Workouts) + "\n"
 
-		fileName := fmt.Sprintf("%d.png", group.ChatID)
-		usersWorkouts, previousWeekWorkouts, leaders := collectUsersData(group)
-		userNames := group.GetUserFixedNamesList()
-		usersStringSlice := "'" + strings.Join(userNames, "', '") + "'"
-		previousWorkoutsStringSlice := strings.Join(previousWeekWorkouts, ", ")
-		workoutsStringSlice := strings.Join(usersWorkouts, ", ")
-		chartConfig := createChartConfig(usersStringSlice, previousWorkoutsStringSlice, workoutsStringSlice)
-		qc := createQuickChart(chartConfig)
-		file, err := os.Create(fileName)
-		if err != nil {
-			panic(err)
-		}
-		defer file.Close()
-		qc.Write(file)
-
-		// Get monthly standings
-		monthlyLeaders := getMonthlyLeaders(group)
-
-		msg := tgbotapi.NewPhoto(group.ChatID, tgbotapi.FilePath(fileName))
-		caption := "Weekly summary:\n"
-
-		// Add weekly leader info and select a winner
-		var selectedWinner users.User
-
-		if len(leaders) == 0 {
-			// No leaders this week
-			log.Debug("No leaders this week")
-		} else if len(leaders) == 1 {
-			// Only one leader
-			leader := leaders[0]
-			selectedWinner = leader.User
-			caption += fmt.Sprintf(
-				"%s is the ⭐ with %d workouts!",
-				leader.User.GetName(),
-				leader.Workouts,
-			)
+		// First announce all leaders
+		for _, leader := range leaders {
+			caption += leader.User.GetName() + " "
 			if err := leader.User.RegisterWeeklyLeaderEvent(group.ChatID); err != nil {
 				log.Errorf("Error while registering weekly leader event: %s", err)
 				sentry.CaptureException(err)
 			}
-		} else {
-			// Multiple leaders
-			caption += fmt.Sprintf("⭐ Leaders of the week with %d workouts:\n",
-				leaders[0].Workouts)
-
-			// First announce all leaders
-			for _, leader := range leaders {
-				caption += leader.User.GetName() + " "
-				if err := leader.User.RegisterWeeklyLeaderEvent(group.ChatID); err != nil {
-					log.Errorf("Error while registering weekly leader event: %s", err)
-					sentry.CaptureException(err)
-				}
-			}
-
-			// Now determine the winner based on who reached the max workout count first
-			selectedWinner = findEarliestLeader(leaders, group.ChatID)
-		}
-
-		// Add monthly standings info
-		if len(monthlyLeaders) > 0 {
-			caption += "\n\nMonthly standings:"
-			for i, leader := range monthlyLeaders {
-				if i == 0 {
-					caption += fmt.Sprintf("\n🥇 %s is leading with %d workouts",
-						leader.User.GetName(), leader.Workouts)
-				} else if i == 1 {
-					caption += fmt.Sprintf("\n🥈 %s is in second place with %d workouts",
-						leader.User.GetName(), leader.Workouts)
-					break // Only show first and second place
-				}
-			}
 		}
 
-		// Add group ranking info
-		if rankInfo, ok := rankMap[group.ChatID]; ok && totalActiveGroups > 0 {
-			caption += fmt.Sprintf("\n\nYour group averaged %.1f workouts per member this week. You're ranked %d/%d among active groups!",
-				rankInfo.average, rankInfo.rank, totalActiveGroups)
+		// Now determine the winner based on who reached the max workout count first
+		selectedWinner = findEarliestLeader(leaders, group.ChatID)
+	}
 
-			// Add historical comparison message
-			if rankInfo.isFirstWeek {
-				caption += "\nThis is your first recorded week! Your record has been set - try to break it next week!"
-			} else if rankInfo.isNewBest {
-				caption += "\nThis is your best week in recorded history!"
-			} else {
-				diff := rankInfo.previousBest - rankInfo.average
-				caption += fmt.Sprintf("\nYou were %.1f points away from your best week ever (%.1f).", diff, rankInfo.previousBest)
+	// Add monthly standings info
+	if len(monthlyLeaders) > 0 {
+		caption += "\n\n" + i18n.T(lang, "report.monthly_standings")
+		for i, leader := range monthlyLeaders {
+			if i == 0 {
+				caption += "\n" + i18n.N(lang, "report.monthly_standings.first", leader.Workouts, "name", leader.User.GetName())
+			} else if i == 1 {
+				caption += "\n" + i18n.N(lang, "report.monthly_standings.second", leader.Workouts, "name", leader.User.GetName())
+				break // Only show first and second place
 			}
 		}
+	}
 
-		msg.Caption = caption
-		_, err = bot.Send(msg)
-		if err != nil {
-			log.Error(err)
-			sentry.CaptureException(err)
+	if goals, err := group.GetWeeklyGoals(); err != nil {
+		log.Error("Error getting weekly goals", "group_id", group.ChatID, "error", err)
+	} else {
+		// collectUsersData loaded each member's workouts of the report cycle
+		workouts := map[uint]int{}
+		for _, user := range group.Users {
+			workouts[user.ID] = len(user.Workouts)
 		}
+		if reached, set := goalsReached(goals, workouts); set > 0 {
+			caption += "\n\n" + i18n.T(lang, "report.goals", "hit", reached, "count", set)
+		}
+	}
+
+	// Add group ranking info
+	if rank > 0 && totalActiveGroups > 0 {
+		caption += "\n\n" + i18n.T(lang, "report.group_rank",
+			"average", fmt.Sprintf("%.1f", score.AverageWorkouts), "rank", rank, "groups", totalActiveGroups)
+
+		// Add historical comparison message
+		if score.IsFirstWeek {
+			caption += "\n" + i18n.T(lang, "report.first_week")
+		} else if score.IsNewBest {
+			caption += "\n" + i18n.T(lang, "report.best_week")
+		} else {
+			diff := score.PreviousBest - score.AverageWorkouts
+			caption += "\n" + i18n.T(lang, "report.below_best",
+				"diff", fmt.Sprintf("%.1f", diff), "best", fmt.Sprintf("%.1f", score.PreviousBest))
+		}
+	}
+
+	msg.Caption = caption
+	_, err = bot.Send(msg)
+	if err != nil {
+		log.Error(err)
+		sentry.CaptureException(err)
+	}
 
-		// If we have a winner, ask them for a weekly message directly in the group chat
-		if selectedWinner.ID != 0 {
-			// Create a mention that works even if user has no username
-			userMention := fmt.Sprintf("[%s](tg://user?id=%d)", selectedWinner.GetName(), selectedWinner.TelegramUserID)
+	// If we have a winner, ask them for a weekly message directly in the group chat
+	if selectedWinner.ID != 0 {
+		// Create a mention that works even if user has no username
+		userMention := fmt.Sprintf("[%s](tg://user?id=%d)", selectedWinner.GetName(), selectedWinner.TelegramUserID)
 
-			groupMsg := tgbotapi.NewMessage(
-				group.ChatID,
-				fmt.Sprintf("🎤 %s, as this week's first leader, please share your weekly message as a reply to this message", userMention),
-			)
+		groupMsg := tgbotapi.NewMessage(
+			group.ChatID,
+			i18n.T(lang, "report.leader_message", "mention", userMention),
+		)
 
-			// Enable markdown for the mention to work
-			groupMsg.ParseMode = "MarkdownV2"
+		// Enable markdown for the mention to work
+		groupMsg.ParseMode = "MarkdownV2"
 
-			_, err := bot.Send(groupMsg)
-			if err != nil {
-				log.Error("Failed to send group message about weekly winner message", "error", err)
-				sentry.CaptureException(err)
-			}
+		_, err := bot.Send(groupMsg)
+		if err != nil {
+			log.Error("Failed to send group message about weekly winner message", "error", err)
+			sentry.CaptureException(err)
 		}
 	}
 }
@@ -245,23 +257,24 @@ func collectUsersData(group users.Group) (usersWorkouts, previousWeekWorkouts []
 	return
 }
 
-func createChartConfig(usersStringSlice, previousWorkoutsSlice, workoutsStringSlice string) string {
+func createChartConfig(lang i18n.Lang, usersStringSlice, previousWorkoutsSlice, workoutsStringSlice string) string {
 	chartConfig := fmt.Sprintf(`{
 		type: 'bar',
 		data: {
 			labels: [%s],
 			datasets: [
 				{
-					label: 'Last Week',
+					label: %q,
 					data: [%s]
 				},
 				{
-					label: 'Workouts',
+					label: %q,
 					data: [%s]
 				},
 			]
 		}
-	}`, usersStringSlice, previousWorkoutsSlice, workoutsStringSlice)
+	}`, usersStringSlice, i18n.T(lang, "report.chart.last_week"), previousWorkoutsSlice,
+		i18n.T(lang, "report.chart.workouts"), workoutsStringSlice)
 	return chartConfig
 }
 
@@ -275,9 +288,10 @@ func createQuickChart(chartConfig string) *quickchartgo.Chart {
 	return qc
 }
 
-// calculateGroupScores calculates the average workouts per user for all active groups,
-// compares with historical best, updates if new record, and returns sorted by average
-// (descending), with ties broken by user count (descending)
+// calculateGroupScores calculates the average workouts per user for all active groups
+// over each group's last report cycle, and returns them sorted by average (descending),
+// with ties broken by user count (descending). The historical best is compared and
+// updated by sendWeeklyReport, once per group, when that group's report goes out.
 func calculateGroupScores() []GroupScore {
 	groups := users.GetGroupsWithUsers()
 	var scores []GroupScore
@@ -301,23 +315,11 @@ func calculateGroupScores() []GroupScore {
 		userCount := len(group.Users)
 		average := float64(totalWorkouts) / float64(userCount)
 
-		// Compare with historical best and update if needed
-		isNewBest, previousBest, err := group.UpdateBestAverageIfHigher(average)
-		if err != nil {
-			log.Error("Error updating best average for group", "group_id", group.ChatID, "error", err)
-		}
-
-		// Check if this is the first week (no previous record)
-		isFirstWeek := previousBest == 0
-
 		scores = append(scores, GroupScore{
 			Group:           group,
 			TotalWorkouts:   totalWorkouts,
 			AverageWorkouts: average,
 			UserCount:       userCount,
-			IsNewBest:       isNewBest,
-			PreviousBest:    previousBest,
-			IsFirstWeek:     isFirstWeek,
 		})
 	}
 
@@ -335,26 +337,28 @@ func calculateGroupScores() []GroupScore {
 func ReportStandings(bot *tgbotapi.BotAPI) {
 	groups := users.GetGroups()
 	for _, group := range groups {
-		groupWithUsers := users.GetGroupWithUsers(group.ChatID)
-
-		if len(groupWithUsers.Users) < 4 {
-			log.Debug("Skipping standings report for small group",
-				"group_id", group.ChatID,
-				"name", group.Title,
-				"member_count", len(groupWithUsers.Users))
-			continue
-		}
+		sendStandings(bot, users.GetGroupWithUsers(group.ChatID))
+	}
+}
 
-		message := buildPowerRankingsMessage(groupWithUsers)
-		msg := tgbotapi.NewMessage(group.ChatID, message)
-		bot.Send(msg)
+func sendStandings(bot *tgbotapi.BotAPI, group users.Group) {
+	if len(group.Users) < 4 {
+		log.Debug("Skipping standings report for small group",
+			"group_id", group.ChatID,
+			"name", group.Title,
+			"member_count", len(group.Users))
+		return
 	}
+
+	message := buildPowerRankingsMessage(group)
+	msg := tgbotapi.NewMessage(group.ChatID, message)
+	bot.Send(msg)
 }
 
 func CreateStatsMessage(chatId int64) string {
-	users := users.GetUsers(chatId)
+	members := users.GetUsers(chatId)
 	message := ""
-	for _, user := range users {
+	for _, user := range members {
 		user.LoadWorkoutsThisCycle(chatId)
 		workoutsStr := ""
 		for range len(user.Workouts) {
@@ -364,14 +368,25 @@ func CreateStatsMessage(chatId int64) string {
 			"\n" +
 			fmt.Sprint(user.GetName()) +
 			": " +
-			workoutsStr
+			workoutsStr +
+			minutesSuffix(users.TotalWorkouts(user.Workouts).Minutes)
 	}
 	return message
 }
 
+// minutesSuffix shows the time spent working out, for workouts that have
+// metrics. It's empty when none do.
+func minutesSuffix(minutes float64) string {
+	if minutes < 1 {
+		return ""
+	}
+	return fmt.Sprintf(" ⏱ %.0f min", minutes)
+}
+
 type WeeklyStats struct {
 	User             users.User
 	ThisWeekWorkouts int
+	ThisWeekMinutes  float64
 	LastWeekWorkouts int
 	Improvement      int
 	DaysLeftToWin    string
@@ -379,9 +394,10 @@ type WeeklyStats struct {
 
 func buildPowerRankingsMessage(group users.Group) string {
 	stats := collectWeeklyStats(group)
+	lang := group.Lang()
 
 	if len(stats) == 0 {
-		return "📊 Mid-Week Power Rankings 📊\n\nNo workout data available yet this week."
+		return i18n.T(lang, "rankings.title") + "\n\n" + i18n.T(lang, "rankings.empty")
 	}
 
 	sort.Slice(stats, func(i, j int) bool {
@@ -391,8 +407,8 @@ func buildPowerRankingsMessage(group users.Group) string {
 		return stats[i].ThisWeekWorkouts > stats[j].ThisWeekWorkouts
 	})
 
-	message := "📊 Mid-Week Power Rankings 📊\n\n"
-	message += "🏆 Current Leaderboard:\n"
+	message := i18n.T(lang, "rankings.title") + "\n\n"
+	message += i18n.T(lang, "rankings.leaderboard") + "\n"
 
 	maxWorkouts := stats[0].ThisWeekWorkouts
 	leadersCount := 0
@@ -421,24 +437,36 @@ func buildPowerRankingsMessage(group users.Group) string {
 			improvementStr = fmt.Sprintf(" 📉%d", s.Improvement)
 		}
 
-		message += fmt.Sprintf("%s %s: %d workouts%s\n",
-			position, s.User.GetName(), s.ThisWeekWorkouts, improvementStr)
+		message += fmt.Sprintf("%s %s%s%s\n", position,
+			i18n.N(lang, "rankings.entry", s.ThisWeekWorkouts, "name", s.User.GetName()), minutesSuffix(s.ThisWeekMinutes), improvementStr)
+	}
+
+	if goals, err := group.GetWeeklyGoals(); err != nil {
+		log.Error("Error getting weekly goals", "group_id", group.ChatID, "error", err)
+	} else {
+		workouts := map[uint]int{}
+		for _, s := range stats {
+			workouts[s.User.ID] = s.ThisWeekWorkouts
+		}
+		if reached, set := goalsReached(goals, workouts); set > 0 {
+			message += "\n" + i18n.T(lang, "rankings.goals", "hit", reached, "count", set) + "\n"
+		}
 	}
 
 	comebackPlayer := findComebackPlayer(stats)
 	if comebackPlayer != nil {
-		message += fmt.Sprintf("\n🔥 Comeback Player: %s (+%d vs last week!)\n",
-			comebackPlayer.User.GetName(), comebackPlayer.Improvement)
+		message += "\n" + i18n.T(lang, "rankings.comeback",
+			"name", comebackPlayer.User.GetName(), "improvement", comebackPlayer.Improvement) + "\n"
 	}
 
 	if leadersCount > 1 && maxWorkouts > 0 {
-		message += fmt.Sprintf("\n⚡ CLOSE RACE! %d players tied at %d workouts!\n",
-			leadersCount, maxWorkouts)
+		message += "\n" + i18n.N(lang, "rankings.close_race", maxWorkouts, "players", leadersCount) + "\n"
 	}
 
-	closeContenders := findCloseContenders(stats, maxWorkouts)
+	daysLeft := group.GetRules().DaysUntilReport(time.Now())
+	closeContenders := findCloseContenders(lang, stats, maxWorkouts, daysLeft)
 	if len(closeContenders) > 0 {
-		message += "\n💪 Win Probability:\n"
+		message += "\n" + i18n.T(lang, "rankings.win_probability") + "\n"
 		for _, contender := range closeContenders {
 			message += contender
 		}
@@ -467,6 +495,7 @@ func collectWeeklyStats(group users.Group) []WeeklyStats {
 		stats = append(stats, WeeklyStats{
 			User:             *user, // Dereference the pointer for the struct field
 			ThisWeekWorkouts: len(thisWeekWorkouts),
+			ThisWeekMinutes:  users.TotalWorkouts(thisWeekWorkouts).Minutes,
 			LastWeekWorkouts: len(lastWeekWorkouts),
 			Improvement:      improvement,
 		})
@@ -489,13 +518,12 @@ func findComebackPlayer(stats []WeeklyStats) *WeeklyStats {
 	return bestComeback
 }
 
-func findCloseContenders(stats []WeeklyStats, maxWorkouts int) []string {
+func findCloseContenders(lang i18n.Lang, stats []WeeklyStats, maxWorkouts int, daysLeft int) []string {
 	if maxWorkouts == 0 {
 		return nil
 	}
 
 	var contenders []string
-	daysLeft := 7 - int(time.Now().Weekday())
 	if daysLeft <= 0 || daysLeft >= 5 {
 		return nil
 	}
@@ -505,13 +533,13 @@ func findCloseContenders(stats []WeeklyStats, maxWorkouts int) []string {
 
 		if gap == 0 {
 			contenders = append(contenders,
-				fmt.Sprintf("• %s: Leading! One more workout seals it 🏆\n", s.User.GetName()))
+				"• "+i18n.T(lang, "rankings.contender.leading", "name", s.User.GetName())+"\n")
 		} else if gap == 1 {
 			contenders = append(contenders,
-				fmt.Sprintf("• %s: 1 workout behind - still in the game! 🎯\n", s.User.GetName()))
+				"• "+i18n.T(lang, "rankings.contender.one_behind", "name", s.User.GetName())+"\n")
 		} else if gap == 2 && daysLeft >= 2 {
 			contenders = append(contenders,
-				fmt.Sprintf("• %s: 2 workouts needed - you've got time! ⏰\n", s.User.GetName()))
+				"• "+i18n.T(lang, "rankings.contender.two_behind", "name", s.User.GetName())+"\n")
 		}
 	}
 
@@ -543,30 +571,34 @@ func MonthlyReport(bot *tgbotapi.BotAPI) {
 	log.Debug("starting monthly report")
 	groups := users.GetGroupsWithUsers()
 	for _, group := range groups {
-		if len(group.Users) == 0 {
-			continue
-		}
+		sendMonthlyReport(bot, group)
+	}
+}
 
-		// Skip groups with fewer than 4 members
-		if len(group.Users) < 4 {
-			log.Debug("Skipping monthly report for small group",
-				"group_id", group.ChatID,
-				"name", group.Title,
-				"member_count", len(group.Users))
-			continue
-		}
+func sendMonthlyReport(bot *tgbotapi.BotAPI, group users.Group) {
+	if len(group.Users) == 0 {
+		return
+	}
 
-		leader := monthlyLeader(group)
-		if leader.ID == 0 {
-			continue
-		}
-		leader.SetImmunity(true)
-		msg := tgbotapi.NewMessage(group.ChatID, "")
-		msg.Text = fmt.Sprintf("Monthly summary:\n🥇 %s has won this month with %d workouts!\nHe gets immunity 🛡️", leader.GetName(), len(leader.Workouts))
-		_, err := bot.Send(msg)
-		if err != nil {
-			log.Error(err)
-		}
+	// Skip groups with fewer than 4 members
+	if len(group.Users) < 4 {
+		log.Debug("Skipping monthly report for small group",
+			"group_id", group.ChatID,
+			"name", group.Title,
+			"member_count", len(group.Users))
+		return
+	}
+
+	leader := monthlyLeader(group)
+	if leader.ID == 0 {
+		return
+	}
+	leader.SetImmunity(true)
+	msg := tgbotapi.NewMessage(group.ChatID, "")
+	msg.Text = i18n.N(group.Lang(), "report.monthly", len(leader.Workouts), "name", leader.GetName())
+	_, err := bot.Send(msg)
+	if err != nil {
+		log.Error(err)
 	}
 }
 
@@ -0,0 +1,162 @@
+package schedule
+
+import (
+	"context"
+	"fatbot/metrics"
+	"fatbot/users"
+	"fmt"
+	"runtime/debug"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/charmbracelet/log"
+	"github.com/getsentry/sentry-go"
+	"github.com/go-co-op/gocron"
+	tgbotapi "github.com/go-telegram-bot-api/telegram-bot-api/v5"
+)
+
+// Job is a scheduled job that admins can also trigger by name.
+type Job struct {
+	Name string
+	// Window is how long a scheduled run claims the job across instances
+	Window time.Duration
+	Run    func(run *Run) error
+}
+
+// Run is a single run of a job. Jobs report every change through Act, which
+// in a dry run only records it so nothing is sent or saved.
+type Run struct {
+	Bot    *tgbotapi.BotAPI
+	DryRun bool
+	// Force runs the job even when nothing is due, as when an admin triggers
+	// a report outside its hour
+	Force   bool
+	actions []string
+}
+
+// Act records an action of the job and reports whether to go ahead with it.
+func (run *Run) Act(format string, args ...interface{}) bool {
+	run.actions = append(run.actions, fmt.Sprintf(format, args...))
+	return !run.DryRun
+}
+
+// JobStatus describes a scheduled job for health reporting. LastSuccess is
+// local to this instance, LastClaim is the last run on any instance.
+type JobStatus struct {
+	Name        string    `json:"name"`
+	LastSuccess time.Time `json:"last_success"`
+	LastClaim   *JobClaim `json:"last_claim,omitempty"`
+	NextRun     time.Time `json:"next_run"`
+	Running     bool      `json:"running"`
+}
+
+var (
+	scheduler *gocron.Scheduler
+
+	jobsMu      sync.Mutex
+	jobs        = map[string]Job{}
+	lastSuccess = map[string]time.Time{}
+)
+
+// track registers the job on an already configured schedule. Each tick runs
+// on a single instance, which claims the job for the window.
+func track(bot *tgbotapi.BotAPI, schedule *gocron.Scheduler, job Job) (*gocron.Job, error) {
+	jobsMu.Lock()
+	jobs[job.Name] = job
+	jobsMu.Unlock()
+	users.RegisterScheduledJob(job.Name)
+	return schedule.Tag(job.Name).Do(func() {
+		if claimJobRun(job.Name, job.Window) {
+			execute(job, &Run{Bot: bot}, users.SystemActor)
+		}
+	})
+}
+
+// RunJob runs a job right away for an admin, without claiming it and
+// without waiting for it to be due. A dry run only reports what the job
+// would do.
+func RunJob(bot *tgbotapi.BotAPI, name string, triggeredBy int64, dryRun bool) (*users.JobRun, error) {
+	jobsMu.Lock()
+	job, ok := jobs[name]
+	jobsMu.Unlock()
+	if !ok {
+		return nil, fmt.Errorf("no such job %s", name)
+	}
+	return execute(job, &Run{Bot: bot, DryRun: dryRun, Force: true}, triggeredBy), nil
+}
+
+// execute runs the job, recording it in the job runs table, and turns a
+// panic into a failed run.
+func execute(job Job, run *Run, triggeredBy int64) (record *users.JobRun) {
+	record = users.StartJobRun(job.Name, triggeredBy, run.DryRun, instanceID)
+	var err error
+	defer func() {
+		if r := recover(); r != nil {
+			err = fmt.Errorf("panic in job %s: %v", job.Name, r)
+			log.Error(err, "stack", string(debug.Stack()))
+			sentry.CaptureException(err)
+		}
+		record.Finish(run.actions, err)
+		if err == nil && !run.DryRun {
+			jobsMu.Lock()
+			lastSuccess[job.Name] = time.Now()
+			jobsMu.Unlock()
+		}
+	}()
+	metrics.TimeJob(job.Name, func() {
+		if err = job.Run(run); err != nil {
+			log.Error("Job failed", "job", job.Name, "err", err)
+			sentry.CaptureException(err)
+		}
+	})()
+	return record
+}
+
+// Stop keeps jobs from starting and waits for the running ones to finish,
+// or for the context to be done.
+func Stop(ctx context.Context) error {
+	if scheduler == nil {
+		return nil
+	}
+	stopped := make(chan struct{})
+	go func() {
+		scheduler.Stop()
+		close(stopped)
+	}()
+	select {
+	case <-stopped:
+		return nil
+	case <-ctx.Done():
+		return fmt.Errorf("stopped waiting for running jobs: %w", ctx.Err())
+	}
+}
+
+// JobStatuses returns every job registered in Init, sorted by name. The last
+// success is zero for jobs that didn't complete since the bot started.
+func JobStatuses() []JobStatus {
+	if scheduler == nil {
+		return nil
+	}
+	jobsMu.Lock()
+	defer jobsMu.Unlock()
+	var statuses []JobStatus
+	for _, job := range scheduler.Jobs() {
+		tags := job.Tags()
+		if len(tags) == 0 {
+			continue
+		}
+		status := JobStatus{
+			Name:        tags[0],
+			LastSuccess: lastSuccess[tags[0]],
+			NextRun:     job.NextRun(),
+			Running:     job.IsRunning(),
+		}
+		if claim, err := LastJobClaim(status.Name); err == nil {
+			status.LastClaim = &claim
+		}
+		statuses = append(statuses, status)
+	}
+	sort.Slice(statuses, func(i, j int) bool { return statuses[i].Name < statuses[j].Name })
+	return statuses
+}
@@ -0,0 +1,74 @@
+package schedule
+
+import (
+	"errors"
+	"fatbot/db"
+	"fatbot/users"
+	"fmt"
+	"testing"
+
+	"gorm.io/driver/sqlite"
+	"gorm.io/gorm"
+	"gorm.io/gorm/logger"
+)
+
+// setupTestDB opens an in-memory SQLite database named after the test, sets
+// db.DBCon and migrates the models the jobs use.
+func setupTestDB(t *testing.T) {
+	t.Helper()
+	database, err := gorm.Open(sqlite.Open(fmt.Sprintf("file:%s?mode=memory&cache=shared", t.Name())),
+		&gorm.Config{Logger: logger.Default.LogMode(logger.Silent)})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := database.AutoMigrate(&users.User{}, &users.Group{}, &users.Workout{}, &users.Event{}, &users.JobRun{}); err != nil {
+		t.Fatal(err)
+	}
+	db.DBCon = database
+}
+
+func TestExecuteDryRunOnlyRecords(t *testing.T) {
+	setupTestDB(t)
+	var done []string
+	job := Job{Name: "test_job", Run: func(run *Run) error {
+		for _, name := range []string{"alice", "bob"} {
+			if run.Act("ban %s", name) {
+				done = append(done, name)
+			}
+		}
+		return nil
+	}}
+
+	record := execute(job, &Run{DryRun: true}, 42)
+	if len(done) != 0 {
+		t.Errorf("dry run acted on %v", done)
+	}
+	runs, err := users.GetJobRuns("test_job", 10)
+	if err != nil || len(runs) != 1 {
+		t.Fatalf("got %d runs, %v", len(runs), err)
+	}
+	if runs[0].ID != record.ID || !runs[0].DryRun || runs[0].TriggeredBy != 42 {
+		t.Errorf("got run %+v", runs[0])
+	}
+	if runs[0].Outcome != users.JobSuccess || runs[0].Affected != 2 || runs[0].Actions != "ban alice\nban bob" {
+		t.Errorf("got outcome %s, %d affected, actions %q", runs[0].Outcome, runs[0].Affected, runs[0].Actions)
+	}
+
+	execute(job, &Run{}, users.SystemActor)
+	if len(done) != 2 {
+		t.Errorf("real run acted on %v, want alice and bob", done)
+	}
+}
+
+func TestExecuteRecordsFailures(t *testing.T) {
+	setupTestDB(t)
+	failing := Job{Name: "failing", Run: func(run *Run) error { return errors.New("boom") }}
+	panicking := Job{Name: "panicking", Run: func(run *Run) error { panic("boom") }}
+
+	for _, job := range []Job{failing, panicking} {
+		record := execute(job, &Run{}, users.SystemActor)
+		if record.Outcome != users.JobFailed || record.Error == "" || record.FinishedAt == nil {
+			t.Errorf("%s: got outcome %s, error %q", job.Name, record.Outcome, record.Error)
+		}
+	}
+}
@@ -1,9 +1,9 @@
 package schedule
 
 import (
+	"fatbot/db"
 	"fatbot/spotlight"
 	"fatbot/users"
-	"fmt"
 	"time"
 
 	"github.com/charmbracelet/log"
@@ -14,43 +14,137 @@ import (
 
 func Init(bot *tgbotapi.BotAPI) {
 	timezone := viper.GetString("timezone")
-	reportHour := viper.GetInt64("report.hour")
-	reportTime := fmt.Sprintf("%d:00", reportHour)
 	location, err := time.LoadLocation(timezone)
 	if err != nil {
 		log.Fatalf("Bad timezone: %s", err)
 	}
-	scheduler := gocron.NewScheduler(location)
-	if _, err := scheduler.Every(1).Hours().Do(func() { scanUsers(bot) }); err != nil {
+	scheduler = gocron.NewScheduler(location)
+	// Every job claims its run for a window a bit shorter than its interval,
+	// so with several instances each tick runs on exactly one of them.
+	if _, err := track(bot, scheduler.Every(1).Hours(), Job{Name: users.JobScanUsers, Window: 50 * time.Minute, Run: scanUsers}); err != nil {
 		log.Errorf("Strikes scheduler err: %s", err)
 	}
 	// Whoop workouts are primarily received via webhooks now.
 	// This polling job runs as a reconciliation safety net for any missed webhooks.
-	if _, err := scheduler.Every(10).Minutes().Do(func() { SyncWhoopWorkouts(bot) }); err != nil {
+	if _, err := track(bot, scheduler.Every(10).Minutes(), Job{Name: users.JobWhoopSync, Window: 8 * time.Minute, Run: SyncWhoopWorkouts}); err != nil {
 		log.Errorf("Whoop sync scheduler err: %s", err)
 	}
-	if _, err := scheduler.Every(1).Day().Saturday().At(reportTime).Do(func() { CreateChart(bot) }); err != nil {
-		log.Errorf("Reports scheduler err: %s", err)
+	// Groups have their own timezone and report schedule, so reports are
+	// checked at the top of every hour and sent to the groups that are due.
+	for _, job := range []Job{
+		{Name: users.JobWeeklyReport, Window: 50 * time.Minute, Run: runWeeklyReports},
+		{Name: users.JobStandings, Window: 50 * time.Minute, Run: runStandings},
+		{Name: users.JobMonthlyReport, Window: 50 * time.Minute, Run: runMonthlyReports},
+	} {
+		if _, err := track(bot, scheduler.Cron("0 * * * *"), job); err != nil {
+			log.Errorf("Reports scheduler err: %s", err)
+		}
 	}
-	if _, err := scheduler.Every(1).Wednesday().At(reportTime).Do(func() { ReportStandings(bot) }); err != nil {
-		log.Errorf("Standings scheduler err: %s", err)
-	}
-	if _, err := scheduler.Every(1).MonthLastDay().Do(func() { nudgeBannedUsers(bot) }); err != nil {
+	if _, err := track(bot, scheduler.Every(1).MonthLastDay(), Job{Name: users.JobNudgeBannedUsers, Window: 23 * time.Hour, Run: nudgeBannedUsers}); err != nil {
 		log.Errorf("Banned user nudge err: %s", err)
 	}
-	if _, err := scheduler.Every(1).MonthLastDay().At(reportTime).Do(func() { MonthlyReport(bot) }); err != nil {
-		log.Errorf("Monthly report scheduler err: %s", err)
-	}
-	if _, err := scheduler.Every(1).Day().At("08:00").Do(func() {
-		users.UpdateAllUserRanks()
-	}); err != nil {
+	if _, err := track(bot, scheduler.Every(1).Day().At("08:00"), Job{Name: users.JobUpdateRanks, Window: 23 * time.Hour, Run: updateRanks}); err != nil {
 		log.Errorf("Rank updater scheduler err: %s", err)
 	}
-	if _, err := scheduler.Every(1).Day().At("09:00").Do(func() {
-		spotlight.DailyInstagramAutomation(bot)
-	}); err != nil {
+	if _, err := track(bot, scheduler.Every(1).Day().At("09:00"), Job{Name: users.JobInstagramAutomation, Window: 23 * time.Hour, Run: instagramAutomation}); err != nil {
 		log.Errorf("Instagram automation scheduler err: %s", err)
 	}
+	if hours := viper.GetInt("backup.interval_hours"); hours > 0 && db.Dialect() == db.SQLite {
+		window := time.Duration(hours)*time.Hour - 10*time.Minute
+		if _, err := track(bot, scheduler.Every(hours).Hours(), Job{Name: users.JobBackupDatabase, Window: window, Run: backupDatabase}); err != nil {
+			log.Errorf("Backup scheduler err: %s", err)
+		}
+	}
+
+	if days := viper.GetInt("workout.metrics.backfill_days"); days > 0 {
+		if _, err := track(bot, scheduler.Every(1).Day().At("04:00"), Job{Name: users.JobBackfillMetrics, Window: 23 * time.Hour, Run: backfillWorkoutMetrics}); err != nil {
+			log.Errorf("Workout metrics backfill scheduler err: %s", err)
+		}
+	}
 
 	scheduler.StartAsync()
 }
+
+// forEachDueGroup calls send for every group whose local time is its report
+// hour on a day due says the report is due. A forced run takes every group.
+func forEachDueGroup(run *Run, due func(rules users.GroupRules, now time.Time) bool,
+	send func(group users.Group, rules users.GroupRules, now time.Time)) {
+	for _, group := range users.GetGroupsWithUsers() {
+		rules := group.GetRules()
+		now := time.Now().In(rules.Location())
+		if !run.Force && (now.Hour() != rules.ReportHour || !due(rules, now)) {
+			continue
+		}
+		send(group, rules, now)
+	}
+}
+
+// runWeeklyReports sends the weekly report to the groups on their report day.
+func runWeeklyReports(run *Run) error {
+	var groupScores []GroupScore
+	forEachDueGroup(run, func(rules users.GroupRules, now time.Time) bool {
+		return now.Weekday() == rules.ReportDay
+	}, func(group users.Group, rules users.GroupRules, now time.Time) {
+		// Groups with fewer than 4 members get no reports
+		if len(group.Users) < 4 || !run.Act("send the weekly report to %s", group.Title) {
+			return
+		}
+		if groupScores == nil {
+			groupScores = calculateGroupScores()
+		}
+		sendWeeklyReport(run.Bot, group, groupScores)
+	})
+	return nil
+}
+
+// runStandings sends the mid-week standings, and nudges the members behind
+// on their weekly goal along with them.
+func runStandings(run *Run) error {
+	forEachDueGroup(run, func(rules users.GroupRules, now time.Time) bool {
+		return now.Weekday() == rules.StandingsDay()
+	}, func(group users.Group, rules users.GroupRules, now time.Time) {
+		// Goals are personal, so members of any group get the mid-week nudge
+		nudgeBehindGoals(run, group, rules, now)
+		if len(group.Users) >= 4 && run.Act("send the standings to %s", group.Title) {
+			sendStandings(run.Bot, group)
+		}
+	})
+	return nil
+}
+
+// runMonthlyReports sends the monthly report on the last day of the month.
+func runMonthlyReports(run *Run) error {
+	forEachDueGroup(run, func(rules users.GroupRules, now time.Time) bool {
+		return isLastDayOfMonth(now)
+	}, func(group users.Group, rules users.GroupRules, now time.Time) {
+		if len(group.Users) >= 4 && run.Act("send the monthly report to %s", group.Title) {
+			sendMonthlyReport(run.Bot, group)
+		}
+	})
+	return nil
+}
+
+func updateRanks(run *Run) error {
+	for _, change := range users.PendingRankUpdates() {
+		run.Act("%s", change)
+	}
+	if !run.DryRun {
+		users.UpdateAllUserRanks()
+	}
+	return nil
+}
+
+func instagramAutomation(run *Run) error {
+	candidates, err := spotlight.InstagramCandidates()
+	if err != nil {
+		return err
+	}
+	if len(candidates) > 0 && run.Act("feature one of %d enrolled users on Instagram", len(candidates)) {
+		spotlight.DailyInstagramAutomation(run.Bot)
+	}
+	return nil
+}
+
+func isLastDayOfMonth(now time.Time) bool {
+	return now.AddDate(0, 0, 1).Month() != now.Month()
+}
@@ -3,6 +3,7 @@ package schedule
 import (
 	"encoding/json"
 	"fatbot/db"
+	"fatbot/i18n"
 	"fatbot/notify"
 	"fatbot/state"
 	"fatbot/strava"
@@ -76,6 +77,7 @@ func ProcessStravaActivity(bot *tgbotapi.BotAPI, user users.User, activityID int
 	if err == nil && existing.ID != 0 && existing.StravaID == "" {
 		log.Infof("Skipping Strava activity %s for user %s: matched existing workout %d", stravaID, user.GetName(), existing.ID)
 		existing.StravaID = stravaID
+		existing.AdoptMetrics(users.StravaWorkoutMetrics(activity))
 		db.DBCon.Save(&existing)
 		return
 	}
@@ -102,11 +104,10 @@ func ProcessStravaActivity(bot *tgbotapi.BotAPI, user users.User, activityID int
 		state.SetWithTTL("strava:data:"+stravaID, string(activityJSON), 86400) // 24h
 
 		// Send question to user
-		msg := tgbotapi.NewMessage(user.TelegramUserID, fmt.Sprintf(
-			"I detected a Strava activity: %s (%s). This is your second workout today. Should this count as a workout?",
-			activity.Name, activity.SportType))
-		yesBtn := tgbotapi.NewInlineKeyboardButtonData("Yes", fmt.Sprintf("strava:yes:%s", stravaID))
-		noBtn := tgbotapi.NewInlineKeyboardButtonData("No", fmt.Sprintf("strava:no:%s", stravaID))
+		msg := tgbotapi.NewMessage(user.TelegramUserID, i18n.T(user.Lang(), "provider.detected.strava_second",
+			"activity", activity.Name, "sport", activity.SportType))
+		yesBtn := tgbotapi.NewInlineKeyboardButtonData(i18n.T(user.Lang(), "common.yes"), fmt.Sprintf("strava:yes:%s", stravaID))
+		noBtn := tgbotapi.NewInlineKeyboardButtonData(i18n.T(user.Lang(), "common.no"), fmt.Sprintf("strava:no:%s", stravaID))
 		msg.ReplyMarkup = tgbotapi.NewInlineKeyboardMarkup(tgbotapi.NewInlineKeyboardRow(yesBtn, noBtn))
 		bot.Send(msg)
 
@@ -126,9 +127,10 @@ func createStravaWorkout(bot *tgbotapi.BotAPI, user users.User, activity *strava
 	var workouts []users.Workout
 	for _, group := range user.Groups {
 		workout := users.Workout{
-			UserID:   user.ID,
-			GroupID:  group.ID,
-			StravaID: stravaID,
+			UserID:         user.ID,
+			GroupID:        group.ID,
+			StravaID:       stravaID,
+			WorkoutMetrics: users.StravaWorkoutMetrics(activity),
 		}
 		db.DBCon.Create(&workout)
 		workouts = append(workouts, workout)
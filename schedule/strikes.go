@@ -1,6 +1,7 @@
 package schedule
 
 import (
+	"fatbot/i18n"
 	"fatbot/users"
 	"fmt"
 	"time"
@@ -10,16 +11,18 @@ import (
 	tgbotapi "github.com/go-telegram-bot-api/telegram-bot-api/v5"
 )
 
-func scanUsers(bot *tgbotapi.BotAPI) error {
+func scanUsers(run *Run) error {
 	groups := users.GetGroupsWithUsers()
-	const totalDays = 5.0
 	for _, group := range groups {
+		rules := group.GetRules()
+		location := rules.Location()
+		lang := rules.Language
 		for _, user := range group.Users {
 			if !user.Active {
 				continue
 			}
 			if user.OnProbation {
-				handleProbation(bot, user, group, totalDays)
+				handleProbation(run, user, group, rules)
 				continue
 			}
 			if isNew, err := user.IsNew(group.ChatID); err != nil {
@@ -35,61 +38,96 @@ func scanUsers(bot *tgbotapi.BotAPI) error {
 				sentry.CaptureException(err)
 			}
 
-			_, daysDiff := users.IsLastWorkoutOverdue(lastWorkout.CreatedAt)
-			if daysDiff == 4 && time.Now().Hour() == 19 {
+			lastWorkoutOverdue, daysDiff := users.IsLastWorkoutOverdue(lastWorkout.CreatedAt, rules.UploadWindowDays, location)
+			if daysDiff == rules.WarningDay() && time.Now().In(location).Hour() == rules.WarningHour {
+				if !run.Act("warn %s in %s", user.GetName(), group.Title) {
+					continue
+				}
 				msg := tgbotapi.NewMessage(
-					group.ChatID, fmt.Sprintf("[%s](tg://user?id=%d) you have one day left to workout",
-						user.GetName(),
-						user.TelegramUserID))
+					group.ChatID, i18n.T(lang, "strike.warning",
+						"mention", fmt.Sprintf("[%s](tg://user?id=%d)", user.GetName(), user.TelegramUserID),
+						"left", daysLeftText(lang, rules.WarningLeadDays)))
 				msg.ParseMode = "MarkdownV2"
-				bot.Send(msg)
+				run.Bot.Send(msg)
 				if err := user.RegisterLastDayNotificationEvent(); err != nil {
 					log.Errorf("Error while registering ban event: %s", err)
 					sentry.CaptureException(err)
 				}
-			} else if lastWorkoutOverdue, _ := users.
-				IsLastWorkoutOverdue(lastWorkout.CreatedAt); lastWorkoutOverdue {
+			} else if lastWorkoutOverdue {
 				if user.Immuned {
+					if !run.Act("save %s in %s with immunity", user.GetName(), group.Title) {
+						continue
+					}
 					user.SetImmunity(false)
 					user.CreateDummyWorkout()
-					bot.Send(
+					run.Bot.Send(
 						tgbotapi.NewMessage(
 							group.ChatID,
-							fmt.Sprintf("Saved because of immunity: %s", user.GetName()),
+							i18n.T(lang, "strike.immunity", "name", user.GetName()),
 						),
 					)
 					continue
 				}
-				if err := user.Ban(bot, group.ChatID); err != nil {
+				if !run.Act("ban %s from %s", user.GetName(), group.Title) {
+					continue
+				}
+				if err := user.Ban(run.Bot, group.ChatID); err != nil {
 					err := fmt.Errorf("Issue banning %s from %d: %s", user.GetName(), group.ChatID, err)
 					log.Error(err)
 					sentry.CaptureException(err)
 					continue
 				}
+				auditAutomaticBan(user, group.ChatID, "missed the upload window")
 			}
 		}
 	}
 	return nil
 }
 
-func handleProbation(bot *tgbotapi.BotAPI, user users.User, group users.Group, totalDays float64) {
+func daysLeftText(lang i18n.Lang, days int) string {
+	if days == 0 {
+		return i18n.T(lang, "strike.end_of_day")
+	}
+	return i18n.N(lang, "strike.days_left", days)
+}
+
+func handleProbation(run *Run, user users.User, group users.Group, rules users.GroupRules) {
 	lastWorkout, err := user.GetLastXWorkout(2, group.ChatID)
 	if err != nil {
 		log.Errorf("Err getting last 2 workout for user %s: %s", user.GetName(), err)
 		sentry.CaptureException(err)
 	}
-	diffHours := int(totalDays*24 - time.Now().Sub(lastWorkout.CreatedAt).Hours())
+	diffHours := int(float64(rules.UploadWindowDays*24) - time.Now().Sub(lastWorkout.CreatedAt).Hours())
 	rejoinedLastHour := time.Now().Sub(user.UpdatedAt).Minutes() <= 60
 	lastWorkoutOk := diffHours > 0
 	if !lastWorkoutOk && !rejoinedLastHour {
-		if errors := user.Ban(bot, group.ChatID); errors != nil {
+		if !run.Act("ban %s from %s while on probation", user.GetName(), group.Title) {
+			return
+		}
+		if errors := user.Ban(run.Bot, group.ChatID); errors != nil {
 			log.Errorf("Issue banning %s from %d: %s", user.GetName(), group.ChatID, errors)
 			sentry.CaptureException(err)
+		} else {
+			auditAutomaticBan(user, group.ChatID, "missed the upload window on probation")
 		}
 	} else if lastWorkoutOk {
+		if !run.Act("end the probation of %s in %s", user.GetName(), group.Title) {
+			return
+		}
 		if err := user.UpdateOnProbation(false); err != nil {
 			log.Errorf("Issue updating unprobation %s from %d: %s", user.GetName(), group.ChatID, err)
 			sentry.CaptureException(err)
 		}
 	}
 }
+
+func auditAutomaticBan(user users.User, chatId int64, reason string) {
+	users.RecordAudit(users.AuditEntry{
+		Action:           users.AuditBan,
+		ActorTelegramID:  users.SystemActor,
+		TargetTelegramID: user.TelegramUserID,
+		GroupChatID:      chatId,
+		Before:           "active",
+		After:            reason,
+	})
+}
@@ -2,6 +2,7 @@ package schedule
 
 import (
 	"fatbot/db"
+	"fatbot/i18n"
 	"fatbot/notify"
 	"fatbot/state"
 	"fatbot/users"
@@ -13,12 +14,20 @@ import (
 	tgbotapi "github.com/go-telegram-bot-api/telegram-bot-api/v5"
 )
 
-func SyncWhoopWorkouts(bot *tgbotapi.BotAPI) {
+// SyncWhoopWorkouts only reads from Whoop in a dry run, and skips the users
+// whose access token expired since refreshing it would be saved.
+func SyncWhoopWorkouts(run *Run) error {
+	bot := run.Bot
 	whoopUsers := users.GetWhoopUsers()
 	for _, user := range whoopUsers {
 		// Check if we have a recent workout to avoid spamming/API limits if needed
 		// But simpler to just fetch new ones.
 
+		// Whoop rotates the refresh token, so a refresh must be saved or the
+		// user is locked out
+		if time.Now().After(user.WhoopTokenExpiry) && !run.Act("refresh the Whoop token of %s", user.GetName()) {
+			continue
+		}
 		accessToken, err := user.GetValidWhoopAccessToken()
 		if err != nil {
 			log.Errorf("Failed to get token for user %s: %s", user.GetName(), err)
@@ -54,8 +63,12 @@ func SyncWhoopWorkouts(bot *tgbotapi.BotAPI) {
 			margin := 60 * time.Minute
 			existing, err := user.GetWorkoutInTimeRange(record.Start.Add(-margin), record.End.Add(margin))
 			if err == nil && existing.ID != 0 && existing.WhoopID == "" {
+				if !run.Act("link Whoop workout %s of %s to workout %d", record.ID, user.GetName(), existing.ID) {
+					continue
+				}
 				log.Infof("Skipping Whoop workout %s for user %s: matched existing workout %d", record.ID, user.GetName(), existing.ID)
 				existing.WhoopID = record.ID
+				existing.AdoptMetrics(users.WhoopWorkoutMetrics(&record))
 				db.DBCon.Save(&existing)
 				continue
 			}
@@ -78,10 +91,13 @@ func SyncWhoopWorkouts(bot *tgbotapi.BotAPI) {
 			// If we have a workout today, this is a bonus/secondary workout
 			// Or if it is a small workout (low strain)
 			if isBonus || isSmall {
+				if !run.Act("ask %s whether Whoop workout %s counts", user.GetName(), record.ID) {
+					continue
+				}
 				// Send Question
-				msg := tgbotapi.NewMessage(user.TelegramUserID, fmt.Sprintf("I detected a workout: %s. Should this count as a workout?", record.SportName))
-				yesBtn := tgbotapi.NewInlineKeyboardButtonData("Yes", fmt.Sprintf("whoop:yes:%s", record.ID))
-				noBtn := tgbotapi.NewInlineKeyboardButtonData("No", fmt.Sprintf("whoop:no:%s", record.ID))
+				msg := tgbotapi.NewMessage(user.TelegramUserID, i18n.T(user.Lang(), "provider.detected.whoop", "sport", record.SportName))
+				yesBtn := tgbotapi.NewInlineKeyboardButtonData(i18n.T(user.Lang(), "common.yes"), fmt.Sprintf("whoop:yes:%s", record.ID))
+				noBtn := tgbotapi.NewInlineKeyboardButtonData(i18n.T(user.Lang(), "common.no"), fmt.Sprintf("whoop:no:%s", record.ID))
 				msg.ReplyMarkup = tgbotapi.NewInlineKeyboardMarkup(tgbotapi.NewInlineKeyboardRow(yesBtn, noBtn))
 				bot.Send(msg)
 
@@ -91,12 +107,16 @@ func SyncWhoopWorkouts(bot *tgbotapi.BotAPI) {
 			}
 
 			// --- MAIN WORKOUT LOGIC (Not Bonus) ---
+			if !run.Act("add Whoop workout %s for %s", record.ID, user.GetName()) {
+				continue
+			}
 			var workouts []users.Workout
 			for _, group := range user.Groups {
 				workout := users.Workout{
-					UserID:  user.ID,
-					GroupID: group.ID,
-					WhoopID: record.ID,
+					UserID:         user.ID,
+					GroupID:        group.ID,
+					WhoopID:        record.ID,
+					WorkoutMetrics: users.WhoopWorkoutMetrics(&record),
 				}
 				db.DBCon.Create(&workout)
 				workouts = append(workouts, workout)
@@ -110,4 +130,5 @@ func SyncWhoopWorkouts(bot *tgbotapi.BotAPI) {
 			}
 		}
 	}
+	return nil
 }
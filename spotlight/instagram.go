@@ -3,6 +3,7 @@ package spotlight
 import (
 	"fatbot/ai"
 	"fatbot/db"
+	"fatbot/i18n"
 	"fatbot/instagram"
 	"fatbot/users"
 	"fmt"
@@ -21,15 +22,19 @@ import (
 	xdraw "golang.org/x/image/draw"
 )
 
+// InstagramCandidates returns the users enrolled for Instagram that have at
+// least one workout with a photo.
+func InstagramCandidates() (usersList []users.User, err error) {
+	err = db.DBCon.Model(&users.User{}).
+		Where("users.instagram_handle <> ?", "").
+		Where("users.id IN (?)", users.WorkoutsWithPhoto().Select("workouts.user_id")).
+		Find(&usersList).Error
+	return
+}
+
 func DailyInstagramAutomation(bot *tgbotapi.BotAPI) {
 	log.Info("Starting Daily Instagram Automation")
-	var usersList []users.User
-	// Join with workouts to ensure we only pick users who have at least one workout with a photo
-	err := db.DBCon.Model(&users.User{}).
-		Joins("JOIN workouts ON workouts.user_id = users.id").
-		Where("users.instagram_handle != ? AND workouts.photo_file_id != ? AND workouts.photo_file_id != ?", "", "", "NULL").
-		Group("users.id").
-		Find(&usersList).Error
+	usersList, err := InstagramCandidates()
 	if err != nil {
 		log.Errorf("Error fetching instagram users with photos: %s", err)
 		return
@@ -136,7 +141,7 @@ func CreateInstagramStoryFromPhoto(bot *tgbotapi.BotAPI, user users.User, photoF
 	user.LoadWorkoutsThisCycle(chatId)
 	workoutCount := len(user.Workouts)
 	praise := getPraiseMessage(workoutCount)
-	title := ai.GetAiMotivationalTitle()
+	title := ai.GetAiMotivationalTitle(chatId)
 	log.Debug("Prepared story data", "workoutCount", workoutCount, "title", title)
 
 	// 3. Generate Visuals
@@ -219,15 +224,8 @@ This is what consistency looks like. Keep pushing.
 
 	// 5. Consolidated Telegram Notification
 	if storyID != "" || postID != "" {
-		tgCaption := fmt.Sprintf(`🚀 BOOM! You've been featured on FatBot Instagram!
-
-Handle: @%s
-Status: %s
-Workouts: %d
-
-Check out the central account to see your spotlight! 💪 
-https://www.instagram.com/fatbot.fit`,
-			user.InstagramHandle, praise, workoutCount)
+		tgCaption := i18n.T(user.Lang(), "spotlight.featured",
+			"handle", user.InstagramHandle, "status", praise, "workouts", workoutCount)
 
 		log.Debug("Sending Telegram notification to user")
 		// Send only the Post image as a confirmation preview
@@ -1,10 +1,13 @@
 package state
 
 import (
+	"fatbot/i18n"
 	"fatbot/spotlight"
 	"fatbot/users"
 	"fmt"
 	"strconv"
+	"strings"
+	"time"
 
 	"github.com/charmbracelet/log"
 	tgbotapi "github.com/go-telegram-bot-api/telegram-bot-api/v5"
@@ -17,6 +20,12 @@ type ActionData struct {
 	State  *State
 }
 
+// audit records the action with the admin who performed it as the actor.
+func (params ActionData) audit(entry users.AuditEntry) {
+	entry.ActorTelegramID = params.Update.SentFrom().ID
+	users.RecordAudit(entry)
+}
+
 func (menu ManageAdminsMenu) PerformAction(params ActionData) error { return nil }
 
 func (menu ShowAdminsMenu) PerformAction(params ActionData) error {
@@ -67,10 +76,20 @@ func (menu ChangeAdminsMenu) PerformAction(params ActionData) error {
 			if err := user.AddLocalAdmin(groupChatId); err != nil {
 				return err
 			}
+			params.audit(users.AuditEntry{
+				Action:           users.AuditAddAdmin,
+				TargetTelegramID: telegramUserId,
+				GroupChatID:      groupChatId,
+			})
 		case "removeadmin":
 			if err := user.RemoveLocalAdmin(groupChatId); err != nil {
 				return err
 			}
+			params.audit(users.AuditEntry{
+				Action:           users.AuditRemoveAdmin,
+				TargetTelegramID: telegramUserId,
+				GroupChatID:      groupChatId,
+			})
 		default:
 			log.Warn("Unknown", "option", option)
 		}
@@ -119,6 +138,13 @@ func (menu BanUserMenu) PerformAction(params ActionData) error {
 		if err != nil {
 			return err[0]
 		}
+		params.audit(users.AuditEntry{
+			Action:           users.AuditBan,
+			TargetTelegramID: telegramUserId,
+			GroupChatID:      groupChatId,
+			Before:           "active",
+			After:            "banned",
+		})
 	}
 	return nil
 }
@@ -147,10 +173,25 @@ func (menu RenameMenu) PerformAction(params ActionData) error {
 	if err != nil {
 		return err
 	}
+	groupChatId, err := state.getGroupChatId()
+	if err != nil {
+		return err
+	}
 	if user, err := users.GetUserById(telegramUserId); err != nil {
 		return err
 	} else {
-		user.Rename(params.Data)
+		// Rename updates user.NickName too
+		oldName := user.NickName
+		if err := user.Rename(params.Data); err != nil {
+			return err
+		}
+		params.audit(users.AuditEntry{
+			Action:           users.AuditRename,
+			TargetTelegramID: telegramUserId,
+			GroupChatID:      groupChatId,
+			Before:           oldName,
+			After:            params.Data,
+		})
 	}
 	return nil
 }
@@ -172,9 +213,21 @@ func (menu PushWorkoutMenu) PerformAction(params ActionData) error {
 		if err != nil {
 			return err
 		}
+		workout, err := user.GetLastXWorkout(1, groupChatId)
+		if err != nil {
+			return err
+		}
 		if err := user.PushWorkout(days, groupChatId); err != nil {
 			return err
 		}
+		params.audit(users.AuditEntry{
+			Action:           users.AuditPushWorkout,
+			TargetTelegramID: telegramUserId,
+			GroupChatID:      groupChatId,
+			WorkoutID:        workout.ID,
+			Before:           workout.CreatedAt.Format(time.RFC3339Nano),
+			After:            workout.CreatedAt.Add(time.Duration(-days * 24 * int64(time.Hour))).Format(time.RFC3339Nano),
+		})
 	}
 	return nil
 }
@@ -190,6 +243,13 @@ func (menu DeleteLastWorkoutMenu) PerformAction(params ActionData) error {
 	if deletedWorkout, newLastWorkout, err := user.RollbackLastWorkout(groupChatId); err != nil {
 		return err
 	} else {
+		params.audit(users.AuditEntry{
+			Action:           users.AuditDeleteWorkout,
+			TargetTelegramID: userId,
+			GroupChatID:      groupChatId,
+			WorkoutID:        deletedWorkout.ID,
+			Before:           deletedWorkout.CreatedAt.Format(time.RFC3339Nano),
+		})
 		if deletedWorkout.WhoopID != "" {
 			SetWithTTL("whoop:ignored:"+deletedWorkout.WhoopID, "1", 604800) // 7 days
 		}
@@ -290,6 +350,12 @@ func (menu RemoveUserMenu) PerformAction(params ActionData) error {
 		if err := user.RemoveFromDatabase(); err != nil {
 			return err
 		}
+		params.audit(users.AuditEntry{
+			Action:           users.AuditRemoveUser,
+			TargetTelegramID: telegramUserId,
+			GroupChatID:      groupChatId,
+			Before:           user.GetName(),
+		})
 
 		msg := tgbotapi.NewMessage(params.Update.FromChat().ID,
 			fmt.Sprintf("User %s has been completely removed from the system.", user.GetName()))
@@ -314,12 +380,23 @@ func (menu ManageImmunityMenu) PerformAction(params ActionData) error {
 	if err != nil {
 		return err
 	}
+	groupChatId, err := params.State.getGroupChatId()
+	if err != nil {
+		return err
+	}
 	user, err := users.GetUserById(telegramUserId)
 	if err != nil {
 		return err
 	}
 	// Toggle immunity
 	user.SetImmunity(true)
+	params.audit(users.AuditEntry{
+		Action:           users.AuditImmunity,
+		TargetTelegramID: telegramUserId,
+		GroupChatID:      groupChatId,
+		Before:           strconv.FormatBool(user.Immuned),
+		After:            strconv.FormatBool(true),
+	})
 	msg := tgbotapi.NewMessage(params.Update.FromChat().ID, fmt.Sprintf("User %s immunity has been %s", user.GetName(), map[bool]string{true: "enabled", false: "disabled"}[user.Immuned]))
 	params.Bot.Send(msg)
 	return nil
@@ -356,11 +433,14 @@ func (menu DisputeWorkoutMenu) PerformAction(params ActionData) error {
 	}
 
 	// Create a poll in the group
-	poll := tgbotapi.NewPoll(groupChatId, fmt.Sprintf("Cancel workout by %s from %s?", user.GetName(), lastWorkout.CreatedAt.Format("2006-01-02 15:04:05")))
-	poll.Options = []string{"No", "Yes"}
+	lang := group.Lang()
+	poll := tgbotapi.NewPoll(groupChatId, i18n.T(lang, "dispute.question",
+		"name", user.GetName(), "time", lastWorkout.CreatedAt.Format("2006-01-02 15:04:05")))
+	// The vote count relies on "No" being option 0 and "Yes" option 1
+	poll.Options = []string{i18n.T(lang, "common.no"), i18n.T(lang, "common.yes")}
 	poll.IsAnonymous = false
 	poll.Type = "regular"
-	poll.Explanation = "Vote to decide if this workout should be cancelled"
+	poll.Explanation = i18n.T(lang, "dispute.explanation")
 	poll.OpenPeriod = 3600
 
 	// Send the poll
@@ -494,6 +574,13 @@ func (menu CloseGroupMenu) PerformAction(params ActionData) error {
 	// Mark group as not approved — stops all processing
 	users.UpdateGroupApproved(groupChatId, false)
 
+	params.audit(users.AuditEntry{
+		Action:      users.AuditCloseGroup,
+		GroupChatID: groupChatId,
+		Before:      groupTitle,
+		After:       "closed",
+	})
+
 	// Free the creator's group slot
 	users.ClearGroupCreator(groupChatId)
 
@@ -573,3 +660,259 @@ func (menu InstagramSpotlightMenu) PerformAction(params ActionData) error {
 
 	return nil
 }
+
+func (menu GroupSettingsMenu) PerformAction(params ActionData) error {
+	defer DeleteStateEntry(params.State.ChatId)
+	chatId := params.Update.FromChat().ID
+	groupChatId, err := params.State.getGroupChatId()
+	if err != nil {
+		return err
+	}
+	option, err := params.State.getOption()
+	if err != nil {
+		return err
+	}
+	if err := users.UpdateGroupSetting(groupChatId, users.GroupSettingKey(option), params.Data); err != nil {
+		msg := tgbotapi.NewMessage(chatId, fmt.Sprintf("Could not update setting: %s", err))
+		params.Bot.Send(msg)
+		return nil
+	}
+	group, err := users.GetGroup(groupChatId)
+	if err != nil {
+		return err
+	}
+	msg := tgbotapi.NewMessage(chatId, fmt.Sprintf("Rules for %s:\n%s", group.Title, group.GetRules()))
+	if _, err := params.Bot.Send(msg); err != nil {
+		return err
+	}
+	return nil
+}
+
+const auditLogPageSize = 15
+
+func (menu AuditLogMenu) PerformAction(params ActionData) error {
+	defer DeleteStateEntry(params.State.ChatId)
+	groupChatId, err := params.State.getGroupChatId()
+	if err != nil {
+		return err
+	}
+	// "Whole group" is sent as 0
+	telegramUserId, err := params.State.getTelegramUserId()
+	if err != nil {
+		return err
+	}
+	entries, err := users.GetAuditEntries(groupChatId, telegramUserId, auditLogPageSize)
+	if err != nil {
+		return err
+	}
+	msg := tgbotapi.NewMessage(params.Update.FromChat().ID, "")
+	if len(entries) == 0 {
+		msg.Text = "No audit entries"
+	} else {
+		for _, entry := range entries {
+			msg.Text += entry.String() + "\n"
+		}
+		if keyboard := createAuditUndoKeyboard(entries); len(keyboard.InlineKeyboard) > 0 {
+			msg.ReplyMarkup = keyboard
+		}
+	}
+	if _, err := params.Bot.Send(msg); err != nil {
+		return err
+	}
+	return nil
+}
+
+const (
+	jobHistoryOption = "history"
+	jobRunsPageSize  = 15
+)
+
+func (menu JobRunsMenu) PerformAction(params ActionData) error {
+	defer DeleteStateEntry(params.State.ChatId)
+	option, err := params.State.getOption()
+	if err != nil {
+		return err
+	}
+	job := option
+	if option == jobHistoryOption {
+		job = ""
+	}
+	runs, err := users.GetJobRuns(job, jobRunsPageSize)
+	if err != nil {
+		return err
+	}
+	msg := tgbotapi.NewMessage(params.Update.FromChat().ID, "")
+	if job != "" {
+		msg.Text = fmt.Sprintf("Run %s?\nA dry run only reports what it would do.\n\n", option)
+		msg.ReplyMarkup = createJobRunKeyboard(option)
+	}
+	if len(runs) == 0 {
+		msg.Text += "No job runs yet"
+	}
+	for _, run := range runs {
+		msg.Text += run.String() + "\n"
+	}
+	_, err = params.Bot.Send(msg)
+	return err
+}
+
+const workoutBrowserPageSize = 8
+
+func (menu WorkoutBrowserMenu) PerformAction(params ActionData) error {
+	defer DeleteStateEntry(params.State.ChatId)
+	groupChatId, err := params.State.getGroupChatId()
+	if err != nil {
+		return err
+	}
+	telegramUserId, err := params.State.getTelegramUserId()
+	if err != nil {
+		return err
+	}
+	text, keyboard, err := WorkoutBrowserPage(telegramUserId, groupChatId, 0)
+	if err != nil {
+		return err
+	}
+	msg := tgbotapi.NewMessage(params.Update.FromChat().ID, text)
+	msg.ReplyMarkup = keyboard
+	_, err = params.Bot.Send(msg)
+	return err
+}
+
+// WorkoutBrowserPage lists a page of the user's workouts in the group,
+// deleted ones included, with a button to open each. The buttons are handled
+// by updates.handleWorkoutBrowserCallback.
+func WorkoutBrowserPage(telegramUserId, groupChatId int64, page int) (string, tgbotapi.InlineKeyboardMarkup, error) {
+	user, err := users.GetUserById(telegramUserId)
+	if err != nil {
+		return "", tgbotapi.InlineKeyboardMarkup{}, err
+	}
+	group, err := users.GetGroup(groupChatId)
+	if err != nil {
+		return "", tgbotapi.InlineKeyboardMarkup{}, err
+	}
+	workouts, err := users.GetWorkoutsPage(user.ID, group.ID, page*workoutBrowserPageSize, workoutBrowserPageSize)
+	if err != nil {
+		return "", tgbotapi.InlineKeyboardMarkup{}, err
+	}
+	hasNext := len(workouts) > workoutBrowserPageSize
+	if hasNext {
+		workouts = workouts[:workoutBrowserPageSize]
+	}
+	text := fmt.Sprintf("Workouts of %s in %s, page %d", user.GetName(), group.Title, page+1)
+	if len(workouts) == 0 {
+		text += "\nNo workouts"
+	}
+	location := group.GetRules().Location()
+	return text, createWorkoutBrowserKeyboard(workouts, location, telegramUserId, groupChatId, page, hasNext), nil
+}
+
+// reviewQueueSize keeps the queue within a message, the rest shows up as the
+// first ones are reviewed.
+const reviewQueueSize = 10
+
+func (menu ReviewQueueMenu) PerformAction(params ActionData) error {
+	defer DeleteStateEntry(params.State.ChatId)
+	groupChatId, err := params.State.getGroupChatId()
+	if err != nil {
+		return err
+	}
+	text, keyboard, err := ReviewQueue(groupChatId)
+	if err != nil {
+		return err
+	}
+	msg := tgbotapi.NewMessage(params.Update.FromChat().ID, text)
+	msg.ReplyMarkup = keyboard
+	_, err = params.Bot.Send(msg)
+	return err
+}
+
+// ReviewQueue lists the group's workouts whose photos didn't look like a
+// workout, oldest first, with buttons to approve or reject each. The buttons
+// are handled by updates.handleReviewCallback.
+func ReviewQueue(groupChatId int64) (string, tgbotapi.InlineKeyboardMarkup, error) {
+	group, err := users.GetGroup(groupChatId)
+	if err != nil {
+		return "", tgbotapi.InlineKeyboardMarkup{}, err
+	}
+	workouts, err := users.GetPendingReviews(group.ID)
+	if err != nil {
+		return "", tgbotapi.InlineKeyboardMarkup{}, err
+	}
+	if len(workouts) == 0 {
+		return fmt.Sprintf("No workouts to review in %s", group.Title), tgbotapi.InlineKeyboardMarkup{}, nil
+	}
+	text := fmt.Sprintf("%d workouts to review in %s:", len(workouts), group.Title)
+	if len(workouts) > reviewQueueSize {
+		workouts = workouts[:reviewQueueSize]
+	}
+	location := group.GetRules().Location()
+	for _, workout := range workouts {
+		name := "unknown"
+		if user, err := users.GetUser(workout.UserID); err == nil {
+			name = user.GetName()
+		}
+		text += fmt.Sprintf("\n#%d %s, %s, score %d", workout.ID, name,
+			workout.CreatedAt.In(location).Format("2006-01-02 15:04"), workout.Plausibility)
+		if workout.PhotoMessageID != 0 {
+			text += ": " + messageLink(group.ChatID, workout.PhotoMessageID)
+		}
+	}
+	return text, createReviewQueueKeyboard(workouts), nil
+}
+
+// WorkoutDetails describes a workout for the browser, with buttons to fix it.
+// page is the browser page to go back to.
+func WorkoutDetails(workout users.Workout, page int) (string, tgbotapi.InlineKeyboardMarkup, error) {
+	user, err := users.GetUser(workout.UserID)
+	if err != nil {
+		return "", tgbotapi.InlineKeyboardMarkup{}, err
+	}
+	group, err := users.GetGroupByID(workout.GroupID)
+	if err != nil {
+		return "", tgbotapi.InlineKeyboardMarkup{}, err
+	}
+	location := group.GetRules().Location()
+	text := fmt.Sprintf("Workout #%d of %s in %s\nDate: %s (%s)\nSource: %s",
+		workout.ID, user.GetName(), group.Title,
+		workout.CreatedAt.In(location).Format("2006-01-02 15:04"), location, workout.Source())
+	for _, providerID := range []string{workout.WhoopID, workout.GarminID, workout.StravaID} {
+		if providerID != "" {
+			text += fmt.Sprintf(" (%s)", providerID)
+		}
+	}
+	if workout.HasMetrics() {
+		text += fmt.Sprintf("\nMetrics: %s, %.0f min (%s)", workout.Sport, workout.DurationMinutes, workout.MetricsSource)
+	}
+	if workout.Streak > 1 {
+		text += fmt.Sprintf("\nStreak: %d days", workout.Streak)
+	}
+	if workout.Note != "" {
+		text += "\nNote: " + workout.Note
+	}
+	if workout.PhotoMessageID != 0 {
+		text += "\nPhoto: " + messageLink(group.ChatID, workout.PhotoMessageID)
+	}
+	if workout.NotifyMessageID != 0 {
+		text += "\nAnnouncement: " + messageLink(workout.NotifyChatID, workout.NotifyMessageID)
+	}
+	if workout.Review != "" {
+		text += fmt.Sprintf("\nReview: %s (score %d)", workout.Review, workout.Plausibility)
+	}
+	if workout.DuplicateOfID != 0 {
+		text += fmt.Sprintf("\nPhoto looks like the one of workout #%d", workout.DuplicateOfID)
+	}
+	if workout.Deleted() {
+		text += fmt.Sprintf("\nDeleted on %s", workout.DeletedAt.Time.In(location).Format("2006-01-02 15:04"))
+	}
+	return text, createWorkoutDetailsKeyboard(workout, user.TelegramUserID, group.ChatID, page), nil
+}
+
+// messageLink links to a message in a supergroup, whose chat ids start with
+// -100. Other chats have no links, so it falls back to the message id.
+func messageLink(chatId int64, messageId int) string {
+	id := strconv.FormatInt(chatId, 10)
+	if !strings.HasPrefix(id, "-100") {
+		return fmt.Sprintf("message %d", messageId)
+	}
+	return fmt.Sprintf("https://t.me/c/%s/%d", strings.TrimPrefix(id, "-100"), messageId)
+}
@@ -0,0 +1,51 @@
+package state
+
+import (
+	"fatbot/db"
+	"fatbot/users"
+	"fmt"
+	"testing"
+
+	tgbotapi "github.com/go-telegram-bot-api/telegram-bot-api/v5"
+	"gorm.io/driver/sqlite"
+	"gorm.io/gorm"
+	"gorm.io/gorm/logger"
+)
+
+func TestRenameUndo(t *testing.T) {
+	database, err := gorm.Open(sqlite.Open(fmt.Sprintf("file:%s?mode=memory&cache=shared", t.Name())),
+		&gorm.Config{Logger: logger.Default.LogMode(logger.Silent)})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := database.AutoMigrate(&users.User{}, &users.Group{}, &users.Workout{}, &users.AuditEntry{}); err != nil {
+		t.Fatal(err)
+	}
+	db.DBCon = database
+	SetStore(NewMemoryStore())
+	group := users.Group{ChatID: -100, Title: "Lifters"}
+	database.Create(&group)
+	database.Create(&users.User{TelegramUserID: 42, Name: "Dana", NickName: "Dee", Groups: []*users.Group{&group}})
+
+	params := ActionData{
+		Data:   "Dan",
+		Update: tgbotapi.Update{Message: &tgbotapi.Message{From: &tgbotapi.User{ID: 7}}},
+		State:  &State{ChatId: 7, Menu: RenameMenu{}, Value: "rename" + Delimiter + "-100" + Delimiter + "42"},
+	}
+	if err := (RenameMenu{}).PerformAction(params); err != nil {
+		t.Fatal(err)
+	}
+	var entry users.AuditEntry
+	if err := database.Where("action = ?", users.AuditRename).First(&entry).Error; err != nil {
+		t.Fatal(err)
+	}
+	if entry.Before != "Dee" || entry.After != "Dan" {
+		t.Errorf("audited %q => %q, want \"Dee\" => \"Dan\"", entry.Before, entry.After)
+	}
+	if err := entry.Undo(nil, 7); err != nil {
+		t.Fatal(err)
+	}
+	if user, _ := users.GetUserById(42); user.NickName != "Dee" {
+		t.Errorf("nick name %q after the undo, want Dee", user.NickName)
+	}
+}
@@ -3,6 +3,7 @@ package state
 import (
 	"fatbot/users"
 	"fmt"
+	"time"
 
 	tgbotapi "github.com/go-telegram-bot-api/telegram-bot-api/v5"
 )
@@ -136,6 +137,72 @@ func createAllUsersKeyboard(chatId int64) tgbotapi.InlineKeyboardMarkup {
 	return keyboard
 }
 
+// createAuditUsersKeyboard lists every user of the group, including removed
+// and inactive ones, plus a button to browse the whole group log.
+func createAuditUsersKeyboard(chatId int64) tgbotapi.InlineKeyboardMarkup {
+	keyboard := createAllUsersKeyboard(chatId)
+	wholeGroupRow := tgbotapi.NewInlineKeyboardRow(
+		tgbotapi.NewInlineKeyboardButtonData("Whole group", "0"),
+	)
+	keyboard.InlineKeyboard = append([][]tgbotapi.InlineKeyboardButton{wholeGroupRow}, keyboard.InlineKeyboard...)
+	return keyboard
+}
+
+// createAuditUndoKeyboard has an undo button for every entry that can still be reverted.
+func createAuditUndoKeyboard(entries []users.AuditEntry) tgbotapi.InlineKeyboardMarkup {
+	rows := [][]tgbotapi.InlineKeyboardButton{}
+	row := []tgbotapi.InlineKeyboardButton{}
+	for _, entry := range entries {
+		if !entry.Reversible() {
+			continue
+		}
+		row = append(row, tgbotapi.NewInlineKeyboardButtonData(
+			fmt.Sprintf("Undo #%d", entry.ID),
+			fmt.Sprintf("audit:undo:%d", entry.ID),
+		))
+		if len(row) == 3 {
+			rows = append(rows, row)
+			row = []tgbotapi.InlineKeyboardButton{}
+		}
+	}
+	if len(row) > 0 {
+		rows = append(rows, row)
+	}
+	return tgbotapi.InlineKeyboardMarkup{InlineKeyboard: rows}
+}
+
+func createJobsKeyboard() tgbotapi.InlineKeyboardMarkup {
+	rows := [][]tgbotapi.InlineKeyboardButton{
+		tgbotapi.NewInlineKeyboardRow(tgbotapi.NewInlineKeyboardButtonData("Recent runs", jobHistoryOption)),
+	}
+	row := []tgbotapi.InlineKeyboardButton{}
+	for _, job := range users.ScheduledJobs() {
+		row = append(row, tgbotapi.NewInlineKeyboardButtonData(job, job))
+		if len(row) == 2 {
+			rows = append(rows, row)
+			row = []tgbotapi.InlineKeyboardButton{}
+		}
+	}
+	if len(row) > 0 {
+		rows = append(rows, row)
+	}
+	rows = append(rows, tgbotapi.NewInlineKeyboardRow(
+		tgbotapi.NewInlineKeyboardButtonData("<- Back", "adminmenuback"),
+	))
+	return tgbotapi.NewInlineKeyboardMarkup(rows...)
+}
+
+// createJobRunKeyboard offers a dry run and a real run of the job. The real
+// run asks for confirmation first, see updates.handleJobCallback.
+func createJobRunKeyboard(job string) tgbotapi.InlineKeyboardMarkup {
+	return tgbotapi.NewInlineKeyboardMarkup(
+		tgbotapi.NewInlineKeyboardRow(
+			tgbotapi.NewInlineKeyboardButtonData("Dry run", "job:dry:"+job),
+			tgbotapi.NewInlineKeyboardButtonData("Run now", "job:run:"+job),
+		),
+	)
+}
+
 func createConfirmationKeyboard() tgbotapi.InlineKeyboardMarkup {
 	keyboard := tgbotapi.NewInlineKeyboardMarkup(
 		tgbotapi.NewInlineKeyboardRow(
@@ -171,6 +238,26 @@ func createInstaSpotlightModeKeyboard() tgbotapi.InlineKeyboardMarkup {
 	)
 }
 
+func createGroupSettingsKeyboard() tgbotapi.InlineKeyboardMarkup {
+	row := []tgbotapi.InlineKeyboardButton{}
+	rows := [][]tgbotapi.InlineKeyboardButton{}
+	for _, key := range users.GroupSettingKeys {
+		row = append(row, tgbotapi.NewInlineKeyboardButtonData(key.Label(), string(key)))
+		if len(row) == 2 {
+			rows = append(rows, row)
+			row = []tgbotapi.InlineKeyboardButton{}
+		}
+	}
+	if len(row) > 0 {
+		rows = append(rows, row)
+	}
+	backRow := []tgbotapi.InlineKeyboardButton{}
+	backButton := tgbotapi.NewInlineKeyboardButtonData("<- Back", "adminmenuback")
+	backRow = append(backRow, backButton)
+	rows = append(rows, backRow)
+	return tgbotapi.NewInlineKeyboardMarkup(rows...)
+}
+
 func createGroupsWithInstaKeyboard() tgbotapi.InlineKeyboardMarkup {
 	groups := users.GetGroupsWithInsta()
 	row := []tgbotapi.InlineKeyboardButton{}
@@ -229,10 +316,17 @@ func CreateAdminKeyboard(superAdmin bool) tgbotapi.InlineKeyboardMarkup {
 	var psa PSAMenu
 	var instagramSpotlight InstagramSpotlightMenu
 	var closeGroup CloseGroupMenu
+	var groupSettings GroupSettingsMenu
+	var auditLog AuditLogMenu
+	var jobRuns JobRunsMenu
+	var workoutBrowser WorkoutBrowserMenu
+	var reviewQueue ReviewQueueMenu
 	menus := []MenuBase{
 		rename.CreateMenu(0),
 		pushWorkout.CreateMenu(0),
 		deleteLastWorkout.CreateMenu(0),
+		workoutBrowser.CreateMenu(0),
+		reviewQueue.CreateMenu(0),
 		showUsers.CreateMenu(0),
 		rejoinUser.CreateMenu(0),
 		banUser.CreateMenu(0),
@@ -245,6 +339,9 @@ func CreateAdminKeyboard(superAdmin bool) tgbotapi.InlineKeyboardMarkup {
 		psa.CreateMenu(0),
 		instagramSpotlight.CreateMenu(0),
 		closeGroup.CreateMenu(0),
+		groupSettings.CreateMenu(0),
+		auditLog.CreateMenu(0),
+		jobRuns.CreateMenu(0),
 	}
 
 	row := []tgbotapi.InlineKeyboardButton{}
@@ -266,3 +363,86 @@ func CreateAdminKeyboard(superAdmin bool) tgbotapi.InlineKeyboardMarkup {
 	keyboard := tgbotapi.NewInlineKeyboardMarkup(rows...)
 	return keyboard
 }
+
+// createWorkoutBrowserKeyboard has a button per workout, deleted ones marked,
+// and buttons to the newer and older pages.
+func createWorkoutBrowserKeyboard(workouts []users.Workout, location *time.Location, telegramUserId, groupChatId int64, page int, hasNext bool) tgbotapi.InlineKeyboardMarkup {
+	rows := [][]tgbotapi.InlineKeyboardButton{}
+	for _, workout := range workouts {
+		label := fmt.Sprintf("%s %s", workout.CreatedAt.In(location).Format("2006-01-02 15:04"), workout.Source())
+		if workout.Deleted() {
+			label = "🗑 " + label
+		}
+		rows = append(rows, tgbotapi.NewInlineKeyboardRow(tgbotapi.NewInlineKeyboardButtonData(
+			label, fmt.Sprintf("workouts:show:%d:%d", workout.ID, page),
+		)))
+	}
+	navigation := []tgbotapi.InlineKeyboardButton{}
+	if page > 0 {
+		navigation = append(navigation, tgbotapi.NewInlineKeyboardButtonData(
+			"<- Newer", fmt.Sprintf("workouts:page:%d:%d:%d", telegramUserId, groupChatId, page-1)))
+	}
+	if hasNext {
+		navigation = append(navigation, tgbotapi.NewInlineKeyboardButtonData(
+			"Older ->", fmt.Sprintf("workouts:page:%d:%d:%d", telegramUserId, groupChatId, page+1)))
+	}
+	if len(navigation) > 0 {
+		rows = append(rows, navigation)
+	}
+	return tgbotapi.InlineKeyboardMarkup{InlineKeyboard: rows}
+}
+
+// createReviewQueueKeyboard has approve and reject buttons per workout, the
+// same ones the admins get with the photo, marked as coming from the queue.
+func createReviewQueueKeyboard(workouts []users.Workout) tgbotapi.InlineKeyboardMarkup {
+	rows := [][]tgbotapi.InlineKeyboardButton{}
+	for _, workout := range workouts {
+		rows = append(rows, tgbotapi.NewInlineKeyboardRow(
+			tgbotapi.NewInlineKeyboardButtonData(fmt.Sprintf("Approve #%d", workout.ID),
+				fmt.Sprintf("review:approve:%d:queue", workout.ID)),
+			tgbotapi.NewInlineKeyboardButtonData(fmt.Sprintf("Reject #%d", workout.ID),
+				fmt.Sprintf("review:reject:%d:queue", workout.ID)),
+		))
+	}
+	return tgbotapi.InlineKeyboardMarkup{InlineKeyboard: rows}
+}
+
+func createWorkoutDetailsKeyboard(workout users.Workout, telegramUserId, groupChatId int64, page int) tgbotapi.InlineKeyboardMarkup {
+	deleteButton := tgbotapi.NewInlineKeyboardButtonData("Delete", fmt.Sprintf("workouts:delete:%d:%d", workout.ID, page))
+	if workout.Deleted() {
+		deleteButton = tgbotapi.NewInlineKeyboardButtonData("Restore", fmt.Sprintf("workouts:restore:%d:%d", workout.ID, page))
+	}
+	return tgbotapi.NewInlineKeyboardMarkup(
+		tgbotapi.NewInlineKeyboardRow(
+			deleteButton,
+			tgbotapi.NewInlineKeyboardButtonData("Move date", fmt.Sprintf("workouts:move:%d:%d", workout.ID, page)),
+			tgbotapi.NewInlineKeyboardButtonData("Change group", fmt.Sprintf("workouts:group:%d:%d", workout.ID, page)),
+		),
+		tgbotapi.NewInlineKeyboardRow(
+			tgbotapi.NewInlineKeyboardButtonData("<- Back to list",
+				fmt.Sprintf("workouts:page:%d:%d:%d", telegramUserId, groupChatId, page)),
+		),
+	)
+}
+
+// CreateWorkoutGroupsKeyboard offers the user's other groups that the admin
+// manages as new groups for the workout.
+func CreateWorkoutGroupsKeyboard(workout users.Workout, groups []*users.Group, page int) tgbotapi.InlineKeyboardMarkup {
+	row := []tgbotapi.InlineKeyboardButton{}
+	rows := [][]tgbotapi.InlineKeyboardButton{}
+	for _, group := range groups {
+		row = append(row, tgbotapi.NewInlineKeyboardButtonData(
+			group.Title, fmt.Sprintf("workouts:regroup:%d:%d", workout.ID, group.ChatID)))
+		if len(row) == 3 {
+			rows = append(rows, row)
+			row = []tgbotapi.InlineKeyboardButton{}
+		}
+	}
+	if len(row) > 0 {
+		rows = append(rows, row)
+	}
+	rows = append(rows, tgbotapi.NewInlineKeyboardRow(
+		tgbotapi.NewInlineKeyboardButtonData("<- Back", fmt.Sprintf("workouts:show:%d:%d", workout.ID, page)),
+	))
+	return tgbotapi.NewInlineKeyboardMarkup(rows...)
+}
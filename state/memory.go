@@ -0,0 +1,100 @@
+package state
+
+import (
+	"sync"
+	"time"
+)
+
+// MemoryStore keeps state in process memory, for local development and
+// tests. Nothing survives a restart and nothing is shared between instances.
+type MemoryStore struct {
+	mu        sync.Mutex
+	entries   map[string]memoryEntry
+	now       func() time.Time
+	lastSweep time.Time
+}
+
+type memoryEntry struct {
+	value     string
+	expiresAt time.Time // zero means no expiry
+}
+
+const memorySweepInterval = time.Minute
+
+func NewMemoryStore() *MemoryStore {
+	return &MemoryStore{
+		entries: make(map[string]memoryEntry),
+		now:     time.Now,
+	}
+}
+
+func (entry memoryEntry) expired(now time.Time) bool {
+	return !entry.expiresAt.IsZero() && !now.Before(entry.expiresAt)
+}
+
+func (store *MemoryStore) Get(key string) (string, error) {
+	store.mu.Lock()
+	defer store.mu.Unlock()
+	entry, ok := store.entries[key]
+	if !ok {
+		return "", ErrNotFound
+	}
+	if entry.expired(store.now()) {
+		delete(store.entries, key)
+		return "", ErrNotFound
+	}
+	return entry.value, nil
+}
+
+func (store *MemoryStore) Set(key, value string) error {
+	return store.SetWithTTL(key, value, 0)
+}
+
+func (store *MemoryStore) SetWithTTL(key, value string, ttl time.Duration) error {
+	store.mu.Lock()
+	defer store.mu.Unlock()
+	store.put(key, value, ttl)
+	return nil
+}
+
+func (store *MemoryStore) SetNX(key, value string, ttl time.Duration) (bool, error) {
+	store.mu.Lock()
+	defer store.mu.Unlock()
+	if entry, ok := store.entries[key]; ok && !entry.expired(store.now()) {
+		return false, nil
+	}
+	store.put(key, value, ttl)
+	return true, nil
+}
+
+func (store *MemoryStore) Delete(key string) error {
+	store.mu.Lock()
+	defer store.mu.Unlock()
+	delete(store.entries, key)
+	return nil
+}
+
+func (store *MemoryStore) Ping() error {
+	return nil
+}
+
+// put stores the entry and, at most once a minute, drops the expired ones
+// nobody has read since. Callers must hold the lock.
+func (store *MemoryStore) put(key, value string, ttl time.Duration) {
+	now := store.now()
+	entry := memoryEntry{value: value}
+	if ttl > 0 {
+		entry.expiresAt = now.Add(ttl)
+	}
+	store.entries[key] = entry
+
+	if now.Sub(store.lastSweep) < memorySweepInterval {
+		return
+	}
+	store.lastSweep = now
+	for key, entry := range store.entries {
+		if entry.expired(now) {
+			delete(store.entries, key)
+		}
+	}
+}
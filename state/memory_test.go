@@ -0,0 +1,66 @@
+package state
+
+import (
+	"testing"
+	"time"
+)
+
+func TestMemoryStoreTTL(t *testing.T) {
+	now := time.Date(2024, 5, 18, 12, 0, 0, 0, time.UTC)
+	store := NewMemoryStore()
+	store.now = func() time.Time { return now }
+
+	store.Set("forever", "1")
+	store.SetWithTTL("short", "2", time.Minute)
+
+	if value, err := store.Get("short"); err != nil || value != "2" {
+		t.Fatalf("got %q, %v before expiry", value, err)
+	}
+
+	now = now.Add(time.Minute)
+	if _, err := store.Get("short"); err != ErrNotFound {
+		t.Errorf("got %v after expiry, want ErrNotFound", err)
+	}
+	if value, err := store.Get("forever"); err != nil || value != "1" {
+		t.Errorf("got %q, %v for key without TTL", value, err)
+	}
+
+	store.Delete("forever")
+	if _, err := store.Get("forever"); err != ErrNotFound {
+		t.Errorf("got %v after delete, want ErrNotFound", err)
+	}
+}
+
+func TestMemoryStoreSetNX(t *testing.T) {
+	now := time.Date(2024, 5, 18, 12, 0, 0, 0, time.UTC)
+	store := NewMemoryStore()
+	store.now = func() time.Time { return now }
+
+	if ok, _ := store.SetNX("lock", "a", 30*time.Second); !ok {
+		t.Fatal("first SetNX should acquire the lock")
+	}
+	if ok, _ := store.SetNX("lock", "b", 30*time.Second); ok {
+		t.Fatal("second SetNX should not acquire a held lock")
+	}
+	now = now.Add(30 * time.Second)
+	if ok, _ := store.SetNX("lock", "c", 30*time.Second); !ok {
+		t.Fatal("SetNX should acquire an expired lock")
+	}
+	if value, _ := store.Get("lock"); value != "c" {
+		t.Errorf("got %q, want c", value)
+	}
+}
+
+func TestMemoryStoreSweepsExpiredKeys(t *testing.T) {
+	now := time.Date(2024, 5, 18, 12, 0, 0, 0, time.UTC)
+	store := NewMemoryStore()
+	store.now = func() time.Time { return now }
+
+	store.SetWithTTL("stale", "1", time.Second)
+	now = now.Add(2 * memorySweepInterval)
+	store.Set("fresh", "1")
+
+	if _, ok := store.entries["stale"]; ok {
+		t.Error("expired key was not swept")
+	}
+}
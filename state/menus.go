@@ -30,6 +30,7 @@ const (
 	PushDaysStepResult               stepResult = "pushDays"
 	PSAMessageStepResult             stepResult = "psaMessage"
 	PSAMessageFeedbackStepResult     stepResult = "psaFeedback"
+	GroupSettingValueStepResult      stepResult = "groupSettingValue"
 	OptionResult                     stepResult = "option"
 )
 
@@ -92,6 +93,21 @@ type InstagramSpotlightMenu struct {
 type CloseGroupMenu struct {
 	MenuBase
 }
+type GroupSettingsMenu struct {
+	MenuBase
+}
+type AuditLogMenu struct {
+	MenuBase
+}
+type JobRunsMenu struct {
+	MenuBase
+}
+type WorkoutBrowserMenu struct {
+	MenuBase
+}
+type ReviewQueueMenu struct {
+	MenuBase
+}
 
 type MenuActionDoneError struct{}
 
@@ -122,6 +138,11 @@ var menuMap = map[string]Menu{
 	"psa":               PSAMenu{},
 	"instaspotlight":    InstagramSpotlightMenu{},
 	"closegroup":        CloseGroupMenu{},
+	"groupsettings":     GroupSettingsMenu{},
+	"auditlog":          AuditLogMenu{},
+	"jobruns":           JobRunsMenu{},
+	"browseworkouts":    WorkoutBrowserMenu{},
+	"reviewqueue":       ReviewQueueMenu{},
 }
 
 func (menu ManageAdminsMenu) CreateMenu(userId int64) MenuBase {
@@ -373,6 +394,82 @@ func (menu CloseGroupMenu) CreateMenu(userId int64) MenuBase {
 	}
 }
 
+func (menu GroupSettingsMenu) CreateMenu(userId int64) MenuBase {
+	chooseGroup := groupStepBase
+	chooseGroup.Keyboard = createGroupsKeyboard(userId)
+	chooseSetting := Step{
+		Name:     "choosesetting",
+		Kind:     KeyboardStepKind,
+		Message:  "Choose Setting",
+		Keyboard: createGroupSettingsKeyboard(),
+		Result:   OptionResult,
+	}
+	insertValue := Step{
+		Name:    "insertsettingvalue",
+		Kind:    InputStepKind,
+		Message: "Insert new value (or \"default\" to use the global default)",
+		Result:  GroupSettingValueStepResult,
+	}
+	return MenuBase{
+		Name:  "groupsettings",
+		Label: "Group Rules",
+		Steps: []Step{chooseGroup, chooseSetting, insertValue},
+	}
+}
+
+func (menu AuditLogMenu) CreateMenu(userId int64) MenuBase {
+	chooseGroup := groupStepBase
+	chooseGroup.Keyboard = createGroupsKeyboard(userId)
+	chooseUser := userStep
+	chooseUser.Name = "chooseaudituser"
+	chooseUser.Message = "Choose User (or the whole group)"
+	return MenuBase{
+		Name:  "auditlog",
+		Label: "Audit Log",
+		Steps: []Step{chooseGroup, chooseUser},
+	}
+}
+
+func (menu JobRunsMenu) CreateMenu(userId int64) MenuBase {
+	chooseJob := Step{
+		Name:     "choosejob",
+		Kind:     KeyboardStepKind,
+		Message:  "Show the recent runs, or pick a job to run",
+		Keyboard: createJobsKeyboard(),
+		Result:   OptionResult,
+	}
+	return MenuBase{
+		Name:           "jobruns",
+		Label:          "Job Runs",
+		Steps:          []Step{chooseJob},
+		SuperAdminOnly: true,
+	}
+}
+
+func (menu WorkoutBrowserMenu) CreateMenu(userId int64) MenuBase {
+	chooseGroup := groupStepBase
+	chooseGroup.Keyboard = createGroupsKeyboard(userId)
+	// Removed members' workouts can need fixing too
+	chooseUser := userStep
+	chooseUser.Name = "chooseanyuser"
+	chooseUser.Message = "Choose User (Active or Inactive)"
+	return MenuBase{
+		Name:  "browseworkouts",
+		Label: "Browse Workouts",
+		Steps: []Step{chooseGroup, chooseUser},
+	}
+}
+
+func (menu ReviewQueueMenu) CreateMenu(userId int64) MenuBase {
+	chooseGroup := groupStepBase
+	chooseGroup.Keyboard = createGroupsKeyboard(userId)
+	return MenuBase{
+		Name:  "reviewqueue",
+		Label: "Review Queue",
+		Steps: []Step{chooseGroup},
+	}
+}
+
 func (step *Step) PopulateKeyboard(data int64) {
 	switch step.Result {
 	case TelegramUserIdStepResult:
@@ -381,6 +478,8 @@ func (step *Step) PopulateKeyboard(data int64) {
 			step.Keyboard = createAllUsersKeyboard(data)
 		} else if step.Name == "chooseuserinsta" {
 			step.Keyboard = createUsersWithInstaKeyboard(data)
+		} else if step.Name == "chooseaudituser" {
+			step.Keyboard = createAuditUsersKeyboard(data)
 		} else {
 			step.Keyboard = createUsersKeyboard(data, true)
 		}
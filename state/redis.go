@@ -1,163 +1,114 @@
 package state
 
 import (
-	"fmt"
+	"fatbot/metrics"
 	"os"
+	"time"
 
 	"github.com/charmbracelet/log"
 	"github.com/gomodule/redigo/redis"
+	"github.com/spf13/viper"
 )
 
-func dial() (redis.Conn, error) {
-	var connection redis.Conn
-	var err error
-	if os.Getenv("REDIS_ADDR") == "" {
-		connection, err = redis.Dial("tcp", ":6379")
-	} else {
-		connection, err = redis.DialURL(
-			os.Getenv("REDIS_ADDR"),
-		)
-	}
-	if err != nil {
-		log.Fatal("cannot find redis!")
-	}
-	return connection, nil
+// RedisStore keeps state in Redis through a shared connection pool.
+// Connection errors are returned to the caller instead of stopping the bot.
+type RedisStore struct {
+	pool *redis.Pool
 }
 
-func set(key, value string) error {
-	c, err := dial()
-	if err != nil {
-		log.Errorf("set dial err: %s", err)
-		return err
+func NewRedisStore() *RedisStore {
+	maxIdle := viper.GetInt("state.redis.max_idle")
+	if maxIdle <= 0 {
+		maxIdle = 10
 	}
-	defer c.Close()
+	return &RedisStore{pool: &redis.Pool{
+		Dial:        dial,
+		MaxIdle:     maxIdle,
+		MaxActive:   viper.GetInt("state.redis.max_active"),
+		IdleTimeout: 5 * time.Minute,
+		// Wait for a free connection rather than failing when MaxActive is reached
+		Wait: true,
+		TestOnBorrow: func(c redis.Conn, lastUsed time.Time) error {
+			if time.Since(lastUsed) < time.Minute {
+				return nil
+			}
+			_, err := c.Do("PING")
+			return err
+		},
+	}}
+}
 
-	_, err = c.Do("SET", key, value)
-	if err != nil {
-		log.Errorf("set err: %s", err)
-		return err
+func dial() (redis.Conn, error) {
+	options := []redis.DialOption{
+		redis.DialConnectTimeout(5 * time.Second),
+		redis.DialReadTimeout(5 * time.Second),
+		redis.DialWriteTimeout(5 * time.Second),
 	}
-	return nil
+	if os.Getenv("REDIS_ADDR") == "" {
+		return redis.Dial("tcp", ":6379", options...)
+	}
+	return redis.DialURL(os.Getenv("REDIS_ADDR"), options...)
 }
 
-func SetWithTTL(key, value string, ttl int) error {
-	c, err := dial()
-	if err != nil {
-		log.Errorf("set dial err: %s", err)
-		return err
-	}
+func (store *RedisStore) do(command string, args ...interface{}) (interface{}, error) {
+	c := store.pool.Get()
 	defer c.Close()
-
-	_, err = c.Do("SET", key, value, "EX", ttl)
-	if err != nil {
-		log.Errorf("setex err: %s", err)
-		return err
+	reply, err := c.Do(command, args...)
+	if err != nil && err != redis.ErrNil {
+		log.Errorf("redis %s err: %s", command, err)
+		metrics.RedisErrors.WithLabelValues(command).Inc()
 	}
-	return nil
+	return reply, err
 }
 
-func get(key string) (string, error) {
-	c, err := dial()
-	if err != nil {
-		log.Errorf("get dial err: %s", err)
-		return "", err
-	}
-	s, err := redis.String(c.Do("GET", key))
-	if err != nil {
-		return "", err
+func (store *RedisStore) Get(key string) (string, error) {
+	value, err := redis.String(store.do("GET", key))
+	if err == redis.ErrNil {
+		return "", ErrNotFound
 	}
-	return s, nil
+	return value, err
 }
 
-func Get(key string) (string, error) {
-	return get(key)
+func (store *RedisStore) Set(key, value string) error {
+	_, err := store.do("SET", key, value)
+	return err
 }
 
-func clear(key int64) error {
-	c, err := dial()
-	if err != nil {
-		log.Errorf("del dial err: %s", err)
-		return err
-	}
-	_, err = redis.Int64(c.Do("DEL", key))
-	if err != nil {
-		log.Errorf("del err: %s", err)
-		return err
+func (store *RedisStore) SetWithTTL(key, value string, ttl time.Duration) error {
+	if ttl <= 0 {
+		return store.Set(key, value)
 	}
-	return nil
+	_, err := store.do("SET", key, value, "PX", ttl.Milliseconds())
+	return err
 }
 
-// SetNX atomically sets a key with TTL only if it does not already exist.
-// Returns true if the key was set (lock acquired), false if it already existed.
-func SetNX(key, value string, ttl int) (bool, error) {
-	c, err := dial()
-	if err != nil {
-		log.Errorf("setnx dial err: %s", err)
-		return false, err
+func (store *RedisStore) SetNX(key, value string, ttl time.Duration) (bool, error) {
+	args := []interface{}{key, value}
+	if ttl > 0 {
+		args = append(args, "PX", ttl.Milliseconds())
 	}
-	defer c.Close()
-
-	result, err := redis.String(c.Do("SET", key, value, "EX", ttl, "NX"))
+	result, err := redis.String(store.do("SET", append(args, "NX")...))
 	if err == redis.ErrNil {
 		// Key already exists — lock not acquired
 		return false, nil
 	}
 	if err != nil {
-		log.Errorf("setnx err: %s", err)
 		return false, err
 	}
 	return result == "OK", nil
 }
 
-// SetPendingPhotoConfirm temporarily stores a Telegram file ID while the user
-// decides whether to save it (yes/no prompt). Expires after 5 minutes.
-func SetPendingPhotoConfirm(telegramUserID int64, fileID string) error {
-	key := fmt.Sprintf("photo:confirm:%d", telegramUserID)
-	return SetWithTTL(key, fileID, 300) // 5 min
+func (store *RedisStore) Delete(key string) error {
+	_, err := store.do("DEL", key)
+	return err
 }
 
-// GetPendingPhotoConfirm retrieves the file ID stored during the yes/no prompt.
-func GetPendingPhotoConfirm(telegramUserID int64) (string, error) {
-	key := fmt.Sprintf("photo:confirm:%d", telegramUserID)
-	return get(key)
+func (store *RedisStore) Ping() error {
+	_, err := store.do("PING")
+	return err
 }
 
-// ClearPendingPhotoConfirm removes the temporary confirm key.
-func ClearPendingPhotoConfirm(telegramUserID int64) error {
-	key := fmt.Sprintf("photo:confirm:%d", telegramUserID)
-	return ClearString(key)
-}
-
-// SetPendingPhoto stores a Telegram file ID as a pending workout photo for a user.
-// The key is keyed by the user's Telegram ID and expires after 24 hours.
-func SetPendingPhoto(telegramUserID int64, fileID string) error {
-	key := fmt.Sprintf("photo:pending:%d", telegramUserID)
-	return SetWithTTL(key, fileID, 86400) // 24h
-}
-
-// GetPendingPhoto retrieves the pending workout photo file ID for a user.
-// Returns an error (redis.ErrNil) if none exists.
-func GetPendingPhoto(telegramUserID int64) (string, error) {
-	key := fmt.Sprintf("photo:pending:%d", telegramUserID)
-	return get(key)
-}
-
-// ClearPendingPhoto removes the pending workout photo for a user.
-func ClearPendingPhoto(telegramUserID int64) error {
-	key := fmt.Sprintf("photo:pending:%d", telegramUserID)
-	return ClearString(key)
-}
-
-func ClearString(key string) error {
-	c, err := dial()
-	if err != nil {
-		log.Errorf("del dial err: %s", err)
-		return err
-	}
-	_, err = c.Do("DEL", key)
-	if err != nil {
-		log.Errorf("del err: %s", err)
-		return err
-	}
-	return nil
+// Close releases the pooled connections.
+func (store *RedisStore) Close() error {
+	return store.pool.Close()
 }
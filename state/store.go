@@ -0,0 +1,93 @@
+package state
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"time"
+
+	"github.com/spf13/viper"
+)
+
+// ErrNotFound is returned by Store.Get when the key doesn't exist or expired.
+var ErrNotFound = errors.New("state: key not found")
+
+// Store is the key-value backend for menu state and short-lived flags.
+type Store interface {
+	Get(key string) (string, error)
+	Set(key, value string) error
+	SetWithTTL(key, value string, ttl time.Duration) error
+	// SetNX sets the key only if it doesn't exist yet and reports whether it did.
+	SetNX(key, value string, ttl time.Duration) (bool, error)
+	Delete(key string) error
+	// Ping checks that the backend is reachable.
+	Ping() error
+}
+
+var store Store
+
+// InitStore sets up the backend from state.backend in config.yaml,
+// either redis (default) or memory.
+func InitStore() error {
+	switch backend := viper.GetString("state.backend"); backend {
+	case "", "redis":
+		store = NewRedisStore()
+	case "memory":
+		store = NewMemoryStore()
+	default:
+		return fmt.Errorf("unknown state backend %s, expected redis or memory", backend)
+	}
+	return nil
+}
+
+// SetStore replaces the backend, e.g. with a MemoryStore in tests.
+func SetStore(newStore Store) {
+	store = newStore
+}
+
+// Ping checks that the configured backend is reachable.
+func Ping() error {
+	if store == nil {
+		return errors.New("state store is not initialized")
+	}
+	return store.Ping()
+}
+
+// Close releases the backend's connections, if it holds any.
+func Close() error {
+	if closer, ok := store.(io.Closer); ok {
+		return closer.Close()
+	}
+	return nil
+}
+
+func set(key, value string) error {
+	return store.Set(key, value)
+}
+
+func get(key string) (string, error) {
+	return store.Get(key)
+}
+
+func Get(key string) (string, error) {
+	return get(key)
+}
+
+// SetWithTTL sets a key that expires after ttl seconds.
+func SetWithTTL(key, value string, ttl int) error {
+	return store.SetWithTTL(key, value, time.Duration(ttl)*time.Second)
+}
+
+// SetNX atomically sets a key with TTL only if it does not already exist.
+// Returns true if the key was set (lock acquired), false if it already existed.
+func SetNX(key, value string, ttl int) (bool, error) {
+	return store.SetNX(key, value, time.Duration(ttl)*time.Second)
+}
+
+func ClearString(key string) error {
+	return store.Delete(key)
+}
+
+func clear(key int64) error {
+	return store.Delete(fmt.Sprint(key))
+}
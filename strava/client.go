@@ -2,6 +2,7 @@ package strava
 
 import (
 	"encoding/json"
+	"fatbot/metrics"
 	"fmt"
 	"io"
 	"net/http"
@@ -22,13 +23,13 @@ const (
 
 // httpClient is shared across all Strava API calls to avoid leaking idle
 // TCP connections that result from creating &http.Client{} per request.
-var httpClient = &http.Client{
+var httpClient = metrics.InstrumentClient("strava", &http.Client{
 	Timeout: 30 * time.Second,
 	Transport: &http.Transport{
 		MaxIdleConns:    5,
 		IdleConnTimeout: 60 * time.Second,
 	},
-}
+})
 
 // TokenResponse represents the OAuth token response from Strava
 type TokenResponse struct {
@@ -126,7 +127,7 @@ func ExchangeToken(code string) (*TokenResponse, error) {
 	data.Set("code", code)
 	data.Set("grant_type", "authorization_code")
 
-	resp, err := http.PostForm(TokenURL, data)
+	resp, err := httpClient.PostForm(TokenURL, data)
 	if err != nil {
 		return nil, err
 	}
@@ -155,7 +156,7 @@ func RefreshToken(refreshToken string) (*TokenResponse, error) {
 	data.Set("refresh_token", refreshToken)
 	data.Set("grant_type", "refresh_token")
 
-	resp, err := http.PostForm(TokenURL, data)
+	resp, err := httpClient.PostForm(TokenURL, data)
 	if err != nil {
 		return nil, err
 	}
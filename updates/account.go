@@ -0,0 +1,109 @@
+package updates
+
+import (
+	"bytes"
+	"fatbot/i18n"
+	"fatbot/users"
+	"fmt"
+	"strings"
+
+	"github.com/charmbracelet/log"
+	"github.com/getsentry/sentry-go"
+	tgbotapi "github.com/go-telegram-bot-api/telegram-bot-api/v5"
+)
+
+// handleExportCommand sends the user a ZIP of everything stored about them.
+func handleExportCommand(fatBotUpdate FatBotUpdate, lang i18n.Lang) error {
+	update := fatBotUpdate.Update
+	bot := fatBotUpdate.Bot
+	user, err := users.GetUserById(update.SentFrom().ID)
+	if err != nil {
+		bot.Send(tgbotapi.NewMessage(update.FromChat().ID, i18n.T(lang, "account.no_data")))
+		return nil
+	}
+	export, err := user.Export()
+	if err != nil {
+		return err
+	}
+	var archive bytes.Buffer
+	if err := export.WriteZip(&archive); err != nil {
+		return err
+	}
+	document := tgbotapi.NewDocument(update.FromChat().ID, tgbotapi.FileBytes{
+		Name:  fmt.Sprintf("fatbot-export-%s.zip", export.ExportedAt.Format("2006-01-02")),
+		Bytes: archive.Bytes(),
+	})
+	document.Caption = i18n.T(lang, "account.export")
+	_, err = bot.Send(document)
+	return err
+}
+
+// handleDeleteMeCommand asks the user to confirm deleting their account.
+func handleDeleteMeCommand(fatBotUpdate FatBotUpdate, lang i18n.Lang) error {
+	update := fatBotUpdate.Update
+	bot := fatBotUpdate.Bot
+	if _, err := users.GetUserById(update.SentFrom().ID); err != nil {
+		bot.Send(tgbotapi.NewMessage(update.FromChat().ID, i18n.T(lang, "account.no_data")))
+		return nil
+	}
+	key := "account.delete.confirm"
+	if users.DeletedWorkoutsPolicy() == users.DeletedWorkoutsDelete {
+		key = "account.delete.confirm_delete_workouts"
+	}
+	msg := tgbotapi.NewMessage(update.FromChat().ID, i18n.T(lang, key))
+	msg.ReplyMarkup = tgbotapi.NewInlineKeyboardMarkup(tgbotapi.NewInlineKeyboardRow(
+		tgbotapi.NewInlineKeyboardButtonData(i18n.T(lang, "account.delete.button"), "deleteme:confirm"),
+		tgbotapi.NewInlineKeyboardButtonData(i18n.T(lang, "common.cancel"), "deleteme:cancel"),
+	))
+	_, err := bot.Send(msg)
+	return err
+}
+
+// handleDeleteMeCallback handles deleteme:confirm and deleteme:cancel.
+func handleDeleteMeCallback(fatBotUpdate FatBotUpdate) error {
+	bot := fatBotUpdate.Bot
+	callbackQuery := fatBotUpdate.Update.CallbackQuery
+	chatId := callbackQuery.Message.Chat.ID
+	removeButtons := tgbotapi.NewEditMessageReplyMarkup(chatId, callbackQuery.Message.MessageID,
+		tgbotapi.InlineKeyboardMarkup{InlineKeyboard: [][]tgbotapi.InlineKeyboardButton{}})
+	bot.Request(removeButtons)
+	user, err := users.GetUserById(callbackQuery.From.ID)
+	if err != nil {
+		bot.Request(tgbotapi.NewCallback(callbackQuery.ID, ""))
+		return err
+	}
+	lang := user.Lang()
+	if strings.TrimPrefix(callbackQuery.Data, "deleteme:") != "confirm" {
+		bot.Request(tgbotapi.NewCallback(callbackQuery.ID, i18n.T(lang, "common.cancelled")))
+		return nil
+	}
+	bot.Request(tgbotapi.NewCallback(callbackQuery.ID, ""))
+	chatIds, err := user.GetChatIds()
+	if err != nil {
+		return err
+	}
+	if err := user.DeleteAccount(users.DeletedWorkoutsPolicy()); err != nil {
+		bot.Send(tgbotapi.NewMessage(chatId, i18n.T(lang, "account.delete.failed")))
+		return err
+	}
+	// The account is gone either way, a group we can't kick from is only logged
+	for _, groupChatId := range chatIds {
+		if err := removeFromGroupChat(bot, user, groupChatId); err != nil {
+			log.Error("Failed to remove deleted user from group", "user", user.TelegramUserID, "chat", groupChatId, "err", err)
+			sentry.CaptureException(err)
+		}
+	}
+	_, err = bot.Send(tgbotapi.NewMessage(chatId, i18n.T(lang, "account.delete.done")))
+	return err
+}
+
+// removeFromGroupChat kicks the user without leaving them banned, so they
+// can come back with /join later.
+func removeFromGroupChat(bot *tgbotapi.BotAPI, user users.User, chatId int64) error {
+	memberConfig := user.CreateChatMemberConfig(bot.Self.UserName, chatId)
+	if _, err := bot.Request(tgbotapi.BanChatMemberConfig{ChatMemberConfig: memberConfig}); err != nil {
+		return err
+	}
+	_, err := bot.Request(tgbotapi.UnbanChatMemberConfig{ChatMemberConfig: memberConfig, OnlyIfBanned: true})
+	return err
+}
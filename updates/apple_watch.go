@@ -1,6 +1,8 @@
 package updates
 
 import (
+	"fatbot/i18n"
+	"fatbot/users"
 	"fmt"
 	"math"
 	"regexp"
@@ -8,8 +10,10 @@ import (
 	"strings"
 )
 
-func getAppleWatchData(imageBytes []byte) string {
-	lines := detectImageText(imageBytes)
+// getAppleWatchData reads the workout summary off the text lines of an Apple
+// Watch screenshot. It's only usable when both the duration and the average
+// heart rate were found.
+func getAppleWatchData(lines []string) (users.WorkoutMetrics, bool) {
 
 	var duration float64
 	var elapsedTimeDuration float64
@@ -138,9 +142,23 @@ func getAppleWatchData(imageBytes []byte) string {
 			strain = 21.0
 		}
 
-		return fmt.Sprintf("\n\nStrain: %.1f\nCalories: %.0f\nAvg HR: %d\nDuration: %.0f min",
-			strain, calories, int(avgHR), duration)
+		return users.WorkoutMetrics{
+			DurationMinutes: duration,
+			Calories:        calories,
+			AvgHeartRate:    int(avgHR),
+			Strain:          strain,
+			MetricsSource:   users.MetricsAppleWatch,
+			Device:          "Apple Watch",
+		}, true
 	}
 
-	return ""
+	return users.WorkoutMetrics{}, false
+}
+
+func appleWatchSummary(lang i18n.Lang, metrics users.WorkoutMetrics) string {
+	return "\n\n" +
+		i18n.T(lang, "announce.strain", "value", fmt.Sprintf("%.1f", metrics.Strain)) + "\n" +
+		i18n.T(lang, "announce.calories", "value", fmt.Sprintf("%.0f", metrics.Calories)) + "\n" +
+		i18n.T(lang, "announce.avg_hr", "value", metrics.AvgHeartRate) + "\n" +
+		i18n.T(lang, "announce.duration", "value", fmt.Sprintf("%.0f min", metrics.DurationMinutes))
 }
@@ -0,0 +1,42 @@
+package updates
+
+import (
+	"fatbot/users"
+	"fmt"
+	"strconv"
+	"strings"
+
+	tgbotapi "github.com/go-telegram-bot-api/telegram-bot-api/v5"
+)
+
+// handleAuditUndoCallback reverts an audit entry from the "Undo #id" buttons
+// of the Audit Log admin menu. Only admins of the entry's group can undo it.
+func handleAuditUndoCallback(fatBotUpdate FatBotUpdate) error {
+	bot := fatBotUpdate.Bot
+	callbackQuery := fatBotUpdate.Update.CallbackQuery
+	chatId := callbackQuery.Message.Chat.ID
+	entryId, err := strconv.ParseUint(strings.TrimPrefix(callbackQuery.Data, "audit:undo:"), 10, 64)
+	if err != nil {
+		return err
+	}
+	entry, err := users.GetAuditEntry(uint(entryId))
+	if err != nil {
+		return err
+	}
+	admin, err := users.GetUserById(callbackQuery.From.ID)
+	if err != nil {
+		return err
+	}
+	if !admin.CanManageGroup(entry.GroupChatID) {
+		bot.Request(tgbotapi.NewCallback(callbackQuery.ID, "Only group admins can undo this"))
+		return nil
+	}
+	if err := entry.Undo(bot, admin.TelegramUserID); err != nil {
+		bot.Request(tgbotapi.NewCallback(callbackQuery.ID, "Undo failed"))
+		bot.Send(tgbotapi.NewMessage(chatId, fmt.Sprintf("Could not undo #%d: %s", entry.ID, err)))
+		return err
+	}
+	bot.Request(tgbotapi.NewCallback(callbackQuery.ID, fmt.Sprintf("Undid #%d", entry.ID)))
+	_, err = bot.Send(tgbotapi.NewMessage(chatId, fmt.Sprintf("Undone: %s", entry)))
+	return err
+}
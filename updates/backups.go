@@ -0,0 +1,31 @@
+package updates
+
+import (
+	"fatbot/backup"
+	"fatbot/users"
+	"fmt"
+	"time"
+)
+
+// backupStatusText describes the last backup run and the newest stored backup
+// for /admin_backup_status.
+func backupStatusText() string {
+	text := "Last backup run: none recorded"
+	if runs, err := users.GetJobRuns(users.JobBackupDatabase, 1); err == nil && len(runs) > 0 {
+		text = "Last backup run: " + runs[0].String()
+	}
+	store, err := backup.NewS3Store()
+	if err != nil {
+		return text + "\nStorage: " + err.Error()
+	}
+	objects, err := backup.Newest(store)
+	if err != nil {
+		return text + "\nStorage: " + err.Error()
+	}
+	if len(objects) == 0 {
+		return text + "\nNo backups stored"
+	}
+	newest := objects[0]
+	return text + fmt.Sprintf("\nNewest backup: %s, %.1f MB, %s ago\n%d backups stored",
+		newest.Key, float64(newest.Size)/(1<<20), time.Since(newest.LastModified).Round(time.Minute), len(objects))
+}
@@ -5,6 +5,7 @@ import (
 	"fatbot/ai"
 	"fatbot/db"
 	"fatbot/garmin"
+	"fatbot/i18n"
 	"fatbot/notify"
 	"fatbot/schedule"
 	"fatbot/state"
@@ -283,6 +284,38 @@ func handleCallbacks(fatBotUpdate FatBotUpdate) error {
 		if err := handlePendingPhotoCallback(fatBotUpdate); err != nil {
 			return err
 		}
+	} else if strings.HasPrefix(fatBotUpdate.Update.CallbackData(), "audit:undo:") {
+		if err := handleAuditUndoCallback(fatBotUpdate); err != nil {
+			return err
+		}
+	} else if strings.HasPrefix(fatBotUpdate.Update.CallbackData(), "job:") {
+		if err := handleJobCallback(fatBotUpdate); err != nil {
+			return err
+		}
+	} else if strings.HasPrefix(fatBotUpdate.Update.CallbackData(), "workouts:") {
+		if err := handleWorkoutBrowserCallback(fatBotUpdate); err != nil {
+			return err
+		}
+	} else if strings.HasPrefix(fatBotUpdate.Update.CallbackData(), "log:") {
+		if err := handleManualLogCallback(fatBotUpdate); err != nil {
+			return err
+		}
+	} else if strings.HasPrefix(fatBotUpdate.Update.CallbackData(), "manual:") {
+		if err := handleManualApprovalCallback(fatBotUpdate); err != nil {
+			return err
+		}
+	} else if strings.HasPrefix(fatBotUpdate.Update.CallbackData(), "review:") {
+		if err := handleReviewCallback(fatBotUpdate); err != nil {
+			return err
+		}
+	} else if strings.HasPrefix(fatBotUpdate.Update.CallbackData(), "goal:") {
+		if err := handleGoalCallback(fatBotUpdate); err != nil {
+			return err
+		}
+	} else if strings.HasPrefix(fatBotUpdate.Update.CallbackData(), "deleteme:") {
+		if err := handleDeleteMeCallback(fatBotUpdate); err != nil {
+			return err
+		}
 	} else {
 		err := handleStatefulCallback(fatBotUpdate)
 		if err != nil {
@@ -354,7 +387,7 @@ func handleWhoopBonusCallback(fatBotUpdate FatBotUpdate) error {
 		isBonus := err == nil && users.IsSameDay(lastWorkout.CreatedAt, workout.Start)
 
 		if !isBonus {
-			bot.Send(tgbotapi.NewMessage(user.TelegramUserID, "Understood. I won't report this workout."))
+			bot.Send(tgbotapi.NewMessage(user.TelegramUserID, i18n.T(user.Lang(), "provider.detected.ignored")))
 			return nil
 		}
 
@@ -372,15 +405,13 @@ func handleWhoopBonusCallback(fatBotUpdate FatBotUpdate) error {
 			return err
 		}
 		for _, group := range user.Groups {
-			msg := tgbotapi.NewMessage(group.ChatID, fmt.Sprintf(
-				"🏃 %s added a bonus activity: %s\n\nStrain: %.1f\nCalories: %.0f\nAvg HR: %d\nDuration: %.0f min\n\n(This activity is not counted as another workout.)",
-				user.GetName(),
-				workout.SportName,
-				workout.Score.Strain,
-				workout.Score.Kilojoule/4.184,
-				workout.Score.AverageHeartRate,
-				duration.Minutes(),
-			))
+			lang := group.Lang()
+			msg := tgbotapi.NewMessage(group.ChatID, i18n.T(lang, "announce.bonus", "name", user.GetName(), "sport", workout.SportName)+"\n\n"+
+				i18n.T(lang, "announce.strain", "value", fmt.Sprintf("%.1f", workout.Score.Strain))+"\n"+
+				i18n.T(lang, "announce.calories", "value", fmt.Sprintf("%.0f", workout.Score.Kilojoule/4.184))+"\n"+
+				i18n.T(lang, "announce.avg_hr", "value", workout.Score.AverageHeartRate)+"\n"+
+				i18n.T(lang, "announce.duration", "value", fmt.Sprintf("%.0f min", duration.Minutes()))+"\n\n"+
+				i18n.T(lang, "announce.bonus_not_counted"))
 			bot.Send(msg)
 		}
 		return nil
@@ -405,9 +436,10 @@ func handleWhoopBonusCallback(fatBotUpdate FatBotUpdate) error {
 		var workouts []users.Workout
 		for _, group := range user.Groups {
 			workout := users.Workout{
-				UserID:  user.ID,
-				GroupID: group.ID,
-				WhoopID: record.ID,
+				UserID:         user.ID,
+				GroupID:        group.ID,
+				WhoopID:        record.ID,
+				WorkoutMetrics: users.WhoopWorkoutMetrics(record),
 			}
 			db.DBCon.Create(&workout)
 			workouts = append(workouts, workout)
@@ -446,14 +478,14 @@ func handleGarminBonusCallback(fatBotUpdate FatBotUpdate) error {
 	if action == "no" {
 		// Mark as ignored
 		state.SetWithTTL("garmin:ignored:"+summaryID, "1", 604800) // 7 days
-		bot.Send(tgbotapi.NewMessage(user.TelegramUserID, "Understood. I won't report this Garmin activity."))
+		bot.Send(tgbotapi.NewMessage(user.TelegramUserID, i18n.T(user.Lang(), "provider.detected.ignored")))
 		return nil
 	}
 
 	if action == "yes" {
 		// Process as normal workout
 		if users.GarminWorkoutExists(summaryID) {
-			bot.Send(tgbotapi.NewMessage(user.TelegramUserID, "This workout was already registered automatically."))
+			bot.Send(tgbotapi.NewMessage(user.TelegramUserID, i18n.T(user.Lang(), "provider.detected.already_registered")))
 			return nil
 		}
 		activityDataJSON, err := state.Get("garmin:data:" + summaryID)
@@ -475,9 +507,10 @@ func handleGarminBonusCallback(fatBotUpdate FatBotUpdate) error {
 		var workouts []users.Workout
 		for _, group := range user.Groups {
 			workout := users.Workout{
-				UserID:   user.ID,
-				GroupID:  group.ID,
-				GarminID: record.SummaryID,
+				UserID:         user.ID,
+				GroupID:        group.ID,
+				GarminID:       record.SummaryID,
+				WorkoutMetrics: users.GarminWorkoutMetrics(record),
 			}
 			db.DBCon.Create(&workout)
 			workouts = append(workouts, workout)
@@ -559,21 +592,21 @@ func handleStravaBonusCallback(fatBotUpdate FatBotUpdate) error {
 		// Mark as ignored
 		state.SetWithTTL("strava:ignored:"+stravaID, "1", 604800) // 7 days
 		state.ClearString("strava:data:" + stravaID)
-		bot.Send(tgbotapi.NewMessage(user.TelegramUserID, "Understood. I won't report this Strava activity."))
+		bot.Send(tgbotapi.NewMessage(user.TelegramUserID, i18n.T(user.Lang(), "provider.detected.ignored")))
 		return nil
 	}
 
 	if action == "yes" {
 		// Process as normal workout
 		if users.StravaWorkoutExists(stravaID) {
-			bot.Send(tgbotapi.NewMessage(user.TelegramUserID, "This workout was already registered automatically."))
+			bot.Send(tgbotapi.NewMessage(user.TelegramUserID, i18n.T(user.Lang(), "provider.detected.already_registered")))
 			return nil
 		}
 
 		// Process the workout
 		if err := schedule.ProcessStravaActivityFromCallback(bot, user, stravaID); err != nil {
 			log.Errorf("Failed to process Strava activity from callback: %s", err)
-			bot.Send(tgbotapi.NewMessage(user.TelegramUserID, "Sorry, there was an error processing your workout. Please try again."))
+			bot.Send(tgbotapi.NewMessage(user.TelegramUserID, i18n.T(user.Lang(), "provider.detected.failed")))
 			return err
 		}
 	}
@@ -587,6 +620,7 @@ func handlePendingPhotoCallback(fatBotUpdate FatBotUpdate) error {
 	action := parts[1]
 	bot := fatBotUpdate.Bot
 	userID := fatBotUpdate.Update.CallbackQuery.From.ID
+	lang := senderLang(fatBotUpdate.Update)
 
 	// Remove the inline keyboard from the prompt message
 	edit := tgbotapi.NewEditMessageReplyMarkup(
@@ -598,7 +632,7 @@ func handlePendingPhotoCallback(fatBotUpdate FatBotUpdate) error {
 
 	if action == "no" {
 		state.ClearPendingPhotoConfirm(userID)
-		bot.Send(tgbotapi.NewMessage(userID, "No problem! The photo won't be saved."))
+		bot.Send(tgbotapi.NewMessage(userID, i18n.T(lang, "photo.discarded")))
 		return nil
 	}
 
@@ -606,7 +640,7 @@ func handlePendingPhotoCallback(fatBotUpdate FatBotUpdate) error {
 	fileID, err := state.GetPendingPhotoConfirm(userID)
 	if err != nil {
 		log.Errorf("Failed to retrieve pending photo confirm for user %d: %s", userID, err)
-		bot.Send(tgbotapi.NewMessage(userID, "Sorry, the photo timed out. Please send it again."))
+		bot.Send(tgbotapi.NewMessage(userID, i18n.T(lang, "photo.expired")))
 		return err
 	}
 	state.ClearPendingPhotoConfirm(userID)
@@ -616,7 +650,7 @@ func handlePendingPhotoCallback(fatBotUpdate FatBotUpdate) error {
 		user, err := users.GetUserById(userID)
 		if err != nil {
 			log.Errorf("handlePendingPhotoCallback(now): failed to get user %d: %s", userID, err)
-			bot.Send(tgbotapi.NewMessage(userID, "Sorry, I couldn't find your account. Please try again."))
+			bot.Send(tgbotapi.NewMessage(userID, i18n.T(lang, "photo.no_account")))
 			return err
 		}
 		if err := user.LoadGroups(); err != nil {
@@ -637,18 +671,17 @@ func handlePendingPhotoCallback(fatBotUpdate FatBotUpdate) error {
 				count++
 			}
 		}
-		bot.Send(tgbotapi.NewMessage(userID, fmt.Sprintf("Sent to %d group(s)!", count)))
+		bot.Send(tgbotapi.NewMessage(userID, i18n.N(lang, "photo.sent", count)))
 		return nil
 	}
 
 	if action == "yes" {
 		if err := state.SetPendingPhoto(userID, fileID); err != nil {
 			log.Errorf("Failed to store pending photo for user %d: %s", userID, err)
-			bot.Send(tgbotapi.NewMessage(userID, "Sorry, I couldn't save the photo. Please try again."))
+			bot.Send(tgbotapi.NewMessage(userID, i18n.T(lang, "photo.save_failed")))
 			return err
 		}
-		bot.Send(tgbotapi.NewMessage(userID,
-			"Got it! I'll attach this photo to your next workout upload automatically."))
+		bot.Send(tgbotapi.NewMessage(userID, i18n.T(lang, "photo.saved")))
 	}
 	return nil
 }
@@ -1,6 +1,7 @@
 package updates
 
 import (
+	"fatbot/i18n"
 	"fatbot/users"
 	"fmt"
 
@@ -61,29 +62,16 @@ func handleBotAddedAsMember(bot *tgbotapi.BotAPI, chatMember *tgbotapi.ChatMembe
 
 	chatId := chatMember.Chat.ID
 	chatType := chatMember.Chat.Type
+	lang := i18n.Or(chatMember.From.LanguageCode, i18n.Default())
 
 	if chatType == "group" {
-		msg := tgbotapi.NewMessage(chatId, fmt.Sprintf(`Thanks for adding me!
-
-I need to be an admin to work. Here's how:
-
-1. Tap the group name > Edit > Administrators
-2. Add @%s as admin
-3. Turn ON "Remain Anonymous" for @%s
-4. Turn it back OFF
-
-This converts the group so I can manage it. I'll finish setup automatically!`, bot.Self.UserName, bot.Self.UserName))
+		msg := tgbotapi.NewMessage(chatId, i18n.T(lang, "setup.added_group", "bot", bot.Self.UserName))
 		bot.Send(msg)
 		return nil
 	}
 
 	if chatType == "supergroup" {
-		msg := tgbotapi.NewMessage(chatId, fmt.Sprintf(`Thanks for adding me!
-
-One step left - make me an admin:
-Tap the group name > Edit > Administrators > Add @%s
-
-I'll finish setup automatically!`, bot.Self.UserName))
+		msg := tgbotapi.NewMessage(chatId, i18n.T(lang, "setup.added_supergroup", "bot", bot.Self.UserName))
 		bot.Send(msg)
 		return nil
 	}
@@ -104,13 +92,7 @@ func handleBotPromotedToAdmin(bot *tgbotapi.BotAPI, chatMember *tgbotapi.ChatMem
 
 	// Regular group with bot as admin — needs supergroup conversion.
 	if chatType == "group" {
-		msg := tgbotapi.NewMessage(chatId, fmt.Sprintf(`Almost there!
-
-1. Tap the group name > Edit > Administrators
-2. Tap @%s > turn ON "Remain Anonymous"
-3. Turn it back OFF
-
-This converts the group so I can manage it. I'll finish setup automatically!`, bot.Self.UserName))
+		msg := tgbotapi.NewMessage(chatId, i18n.T(i18n.Or(from.LanguageCode, i18n.Default()), "setup.convert", "bot", bot.Self.UserName))
 		bot.Send(msg)
 		return nil
 	}
@@ -151,7 +133,7 @@ func setupAutonomousGroup(bot *tgbotapi.BotAPI, chatId int64, chatTitle string,
 		maxGroups = 1
 	}
 	if users.CountAutonomousGroupsByCreator(from.ID) >= maxGroups {
-		msg := tgbotapi.NewMessage(chatId, "You already have a group. Each user can create one group.")
+		msg := tgbotapi.NewMessage(chatId, i18n.T(i18n.Or(from.LanguageCode, i18n.Default()), "creategroup.limit"))
 		bot.Send(msg)
 		return nil
 	}
@@ -191,34 +173,18 @@ func setupAutonomousGroup(bot *tgbotapi.BotAPI, chatId int64, chatTitle string,
 	creatorName := user.GetName()
 
 	// Send group activation message
-	groupMsg := tgbotapi.NewMessage(chatId, fmt.Sprintf(`Group activated!
-
-How it works:
-- Post a workout photo every 5 days
-- Miss the deadline = banned (you can rejoin after 24h)
-- Everyone starts with a 5-day grace period
-
-%s is the group admin.
-IMPORTANT❗: Do not add other users yourself, share the link below with them to register them to the group. 
-%s`, creatorName, inviteLink))
+	rules := group.GetRules()
+	groupMsg := tgbotapi.NewMessage(chatId, i18n.T(rules.Language, "setup.activated",
+		"window", i18n.N(rules.Language, "days", rules.UploadWindowDays),
+		"rejoin", i18n.N(rules.Language, "hours", rules.RejoinWaitHours),
+		"grace", i18n.N(rules.Language, "days", rules.NewUserGraceDays),
+		"admin", creatorName,
+		"link", inviteLink,
+	))
 	bot.Send(groupMsg)
 
 	// Send private onboarding message to creator
-	privateMsg := tgbotapi.NewMessage(from.ID, fmt.Sprintf(`You're the admin of "%s"!
-
-Send this link to friends you want to invite:
-%s
-
-When they click it, you'll get a message to approve them.
-
-Your admin tools (type /admin in our private chat):
-- Group Link: get a fresh invite link anytime
-- Show Users: see all members
-- Ban User / Rejoin User: manage members
-- Push Workout: credit a workout for someone
-- Close Group: shut down the group
-
-Need help? Type /admin anytime to see all options.`, chatTitle, inviteLink))
+	privateMsg := tgbotapi.NewMessage(from.ID, i18n.T(user.Lang(), "setup.creator", "title", chatTitle, "link", inviteLink))
 	bot.Send(privateMsg)
 
 	// Notify super admins
@@ -259,6 +225,7 @@ func getOrCreateUser(from *tgbotapi.User) (users.User, error) {
 			return user, err
 		}
 	}
+	user.DetectLanguage(from.LanguageCode)
 	return user, nil
 }
 
@@ -295,10 +262,11 @@ func handleBotRemovedFromGroup(bot *tgbotapi.BotAPI, chatMember *tgbotapi.ChatMe
 
 	// Notify the creator
 	if creatorID != 0 {
-		creatorMsg := tgbotapi.NewMessage(creatorID, fmt.Sprintf(
-			"I was removed from \"%s\". The group has been deactivated.\n\nYou can create a new group anytime with /creategroup.",
-			chatTitle,
-		))
+		lang := i18n.Default()
+		if creator, err := users.GetUserById(creatorID); err == nil {
+			lang = creator.Lang()
+		}
+		creatorMsg := tgbotapi.NewMessage(creatorID, i18n.T(lang, "setup.removed", "title", chatTitle))
 		bot.Send(creatorMsg)
 	}
 
@@ -1,6 +1,7 @@
 package updates
 
 import (
+	"fatbot/i18n"
 	"fatbot/users"
 	"strings"
 )
@@ -110,7 +111,7 @@ func (fatBotUpdate FatBotUpdate) isGroupReplyUpdate() bool {
 	}
 
 	// Check if it's a reply to the weekly message request
-	if !strings.Contains(update.Message.ReplyToMessage.Text, "please share your weekly message as a reply to this message") {
+	if !i18n.Sent(update.Message.ReplyToMessage.Text, "report.leader_message") {
 		return false
 	}
 
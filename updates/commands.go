@@ -2,6 +2,8 @@ package updates
 
 import (
 	"fatbot/db"
+	"fatbot/i18n"
+	"fatbot/notify"
 	"fatbot/schedule"
 	"fatbot/state"
 	"fatbot/users"
@@ -43,7 +45,8 @@ func handleCommandUpdate(fatBotUpdate FatBotUpdate) error {
 	}
 	var err error
 	var msg tgbotapi.MessageConfig
-	msg.Text = "Unknown command"
+	lang := senderLang(update)
+	msg.Text = i18n.T(lang, "command.unknown")
 	switch update.Message.Command() {
 	case "join", "start":
 		msg, err = handleJoinCommand(fatBotUpdate)
@@ -56,9 +59,9 @@ func handleCommandUpdate(fatBotUpdate FatBotUpdate) error {
 			return err
 		}
 	case "status":
-		msg = handleStatusCommand(update)
+		msg = handleStatusCommand(update, lang)
 	case "stats":
-		msg = handleStatsCommand(update)
+		msg = handleStatsCommand(update, lang)
 	case "whoop":
 		msg, err = HandleWhoopCommand(fatBotUpdate)
 		if err != nil {
@@ -75,30 +78,46 @@ func handleCommandUpdate(fatBotUpdate FatBotUpdate) error {
 			return err
 		}
 	case "instagram":
-		err = handleInstagramCommand(fatBotUpdate)
+		err = handleInstagramCommand(fatBotUpdate, lang)
 		if err != nil {
 			return err
 		}
 		return nil
 	case "instagram_off":
-		err = handleInstagramOffCommand(fatBotUpdate)
+		err = handleInstagramOffCommand(fatBotUpdate, lang)
 		if err != nil {
 			return err
 		}
 		return nil
+	case "log":
+		return handleLogCommand(fatBotUpdate, lang)
+	case "goal":
+		msg, err = handleGoalCommand(fatBotUpdate, lang)
+		if err != nil {
+			return err
+		}
+	case "export":
+		return handleExportCommand(fatBotUpdate, lang)
+	case "delete_me":
+		return handleDeleteMeCommand(fatBotUpdate, lang)
+	case "language":
+		msg, err = handleLanguageCommand(fatBotUpdate)
+		if err != nil {
+			return err
+		}
 	case "support":
 		msg, err = handleSupportCommand(fatBotUpdate)
 		if err != nil {
 			return err
 		}
 	case "cancel":
-		msg, err = handleCancelCommand(fatBotUpdate)
+		msg, err = handleCancelCommand(fatBotUpdate, lang)
 		if err != nil {
 			return err
 		}
 	case "help":
 		msg.ChatID = update.FromChat().ID
-		msg.Text = "Join a group: /join\nCreate your own group: /creategroup\nCheck your status: /status\nView stats: /stats\nCancel your last workout (within a few minutes): /cancel"
+		msg.Text = i18n.T(lang, "help")
 	default:
 		msg.ChatID = update.FromChat().ID
 	}
@@ -111,7 +130,7 @@ func handleCommandUpdate(fatBotUpdate FatBotUpdate) error {
 	return nil
 }
 
-func handleStatsCommand(update tgbotapi.Update) tgbotapi.MessageConfig {
+func handleStatsCommand(update tgbotapi.Update, lang i18n.Lang) tgbotapi.MessageConfig {
 	var user users.User
 	var err error
 	msg := tgbotapi.NewMessage(update.Message.Chat.ID, "")
@@ -119,7 +138,7 @@ func handleStatsCommand(update tgbotapi.Update) tgbotapi.MessageConfig {
 		log.Error(err)
 		sentry.CaptureException(err)
 	} else if user.ID == 0 {
-		msg.Text = "Unregistered user"
+		msg.Text = i18n.T(lang, "user.unregistered")
 		return msg
 	}
 
@@ -140,32 +159,30 @@ func handleStatsCommand(update tgbotapi.Update) tgbotapi.MessageConfig {
 	return msg
 }
 
-func createRankStatusMessage(user *users.User) (string, error) {
+func createRankStatusMessage(lang i18n.Lang, user *users.User) (string, error) {
 	if user.RankUpdatedAt == nil {
-		return "No workout history yet.", nil
+		return i18n.T(lang, "rank.no_history"), nil
 	}
 	ranks := users.GetRanks()
 	currentRank := ranks[user.Rank]
 
 	nextRank, ok := ranks[user.Rank+1]
 	if !ok {
-		return fmt.Sprintf("Current Rank: %s (highest rank!)",
-			currentRank.Name), nil
+		return i18n.T(lang, "rank.highest", "rank", currentRank.Name), nil
 	}
 
 	daysSinceUpdate := int(time.Since(*user.RankUpdatedAt).Hours() / 24)
 	daysNeededForNextRank := nextRank.MinDays - currentRank.MinDays
 	remainingDays := daysNeededForNextRank - daysSinceUpdate
 
-	return fmt.Sprintf(
-		"Current Rank: %s\nDays until next rank (%s): %d",
-		currentRank.Name,
-		nextRank.Name,
-		remainingDays,
+	return i18n.T(lang, "rank.next",
+		"rank", currentRank.Name,
+		"next", nextRank.Name,
+		"days", remainingDays,
 	), nil
 }
 
-func handleStatusCommand(update tgbotapi.Update) tgbotapi.MessageConfig {
+func handleStatusCommand(update tgbotapi.Update, lang i18n.Lang) tgbotapi.MessageConfig {
 	var user users.User
 	var err error
 	msg := tgbotapi.NewMessage(update.Message.Chat.ID, "")
@@ -173,10 +190,10 @@ func handleStatusCommand(update tgbotapi.Update) tgbotapi.MessageConfig {
 	if user, err = users.GetUserFromMessage(update.Message); err != nil {
 		log.Error(err)
 		sentry.CaptureException(err)
-		msg.Text = "Failed to load user."
+		msg.Text = i18n.T(lang, "user.load_failed")
 		return msg
 	} else if user.ID == 0 {
-		msg.Text = "Unregistered user."
+		msg.Text = i18n.T(lang, "user.unregistered")
 		return msg
 	}
 
@@ -189,12 +206,12 @@ func handleStatusCommand(update tgbotapi.Update) tgbotapi.MessageConfig {
 			group, _ := users.GetGroup(chatId)
 
 			// Get rank status
-			rankInfo, err := createRankStatusMessage(&user)
+			rankInfo, err := createRankStatusMessage(lang, &user)
 			if err != nil {
 				rankInfo = ""
 			}
 
-			groupStatus := createStatusMessage(user, chatId, msg).Text
+			groupStatus := createStatusMessage(lang, user, chatId, msg).Text
 
 			msg.Text += "\n\n" +
 				fmt.Sprintf("%s: %s\n%s", group.Title, rankInfo, groupStatus)
@@ -204,7 +221,7 @@ func handleStatusCommand(update tgbotapi.Update) tgbotapi.MessageConfig {
 	return msg
 }
 
-func createStatusMessage(user users.User, chatId int64, msg tgbotapi.MessageConfig) tgbotapi.MessageConfig {
+func createStatusMessage(lang i18n.Lang, user users.User, chatId int64, msg tgbotapi.MessageConfig) tgbotapi.MessageConfig {
 	lastWorkout, err := user.GetLastXWorkout(1, chatId)
 	if err != nil {
 		log.Errorf("Err getting last workout: %s", err)
@@ -213,21 +230,26 @@ func createStatusMessage(user users.User, chatId int64, msg tgbotapi.MessageConf
 	}
 	if lastWorkout.CreatedAt.IsZero() {
 		log.Warn("no last workout")
-		msg.Text = "I don't have your last workout yet."
+		msg.Text = i18n.T(lang, "status.no_workout")
 	} else {
 		// Get the start of the day for both times to compare just the days
-		isLastWorkoutOverdue, daysDiff := users.IsLastWorkoutOverdue(lastWorkout.CreatedAt)
+		rules := users.GetGroupRules(chatId)
+		isLastWorkoutOverdue, daysDiff := users.IsLastWorkoutOverdue(lastWorkout.CreatedAt, rules.UploadWindowDays, rules.Location())
 
 		if isLastWorkoutOverdue {
-			msg.Text = fmt.Sprintf("%s, your last workout was on %s\nYou are overdue for your workout!",
-				user.GetName(),
-				lastWorkout.CreatedAt.Weekday())
+			msg.Text = i18n.T(lang, "status.overdue",
+				"name", user.GetName(),
+				"weekday", notify.Weekday(lang, lastWorkout.CreatedAt.Weekday()))
 		} else {
-			daysLeft := 5 - daysDiff
-			msg.Text = fmt.Sprintf("%s, your last workout was on %s\nYou have %d days left to workout.",
-				user.GetName(),
-				lastWorkout.CreatedAt.Weekday(),
-				daysLeft)
+			daysLeft := rules.UploadWindowDays - daysDiff
+			msg.Text = i18n.N(lang, "status.days_left", daysLeft,
+				"name", user.GetName(),
+				"weekday", notify.Weekday(lang, lastWorkout.CreatedAt.Weekday()))
+		}
+	}
+	if group, err := users.GetGroup(chatId); err == nil {
+		if goal, err := user.GetWeeklyGoal(group); err == nil && goal > 0 {
+			msg.Text += "\n" + i18n.T(lang, "status.goal", "done", workoutsThisCycle(user, chatId), "goal", goal)
 		}
 	}
 	return msg
@@ -249,16 +271,17 @@ func hasValidGroupCommandArgument(commandArguments string) (bool, users.Group) {
 func handleCreateGroupCommand(fatBotUpdate FatBotUpdate) (msg tgbotapi.MessageConfig, err error) {
 	msg.ChatID = fatBotUpdate.Update.FromChat().ID
 	userId := fatBotUpdate.Update.SentFrom().ID
+	lang := senderLang(fatBotUpdate.Update)
 
 	// Feature flag check
 	if !viper.GetBool("groups.creation.enabled") {
-		msg.Text = "Group creation is currently disabled."
+		msg.Text = i18n.T(lang, "creategroup.disabled")
 		return msg, nil
 	}
 
 	// Blacklist check
 	if users.BlackListed(userId) {
-		msg.Text = "You are blocked from using this bot."
+		msg.Text = i18n.T(lang, "user.blocked")
 		return msg, nil
 	}
 
@@ -268,26 +291,12 @@ func handleCreateGroupCommand(fatBotUpdate FatBotUpdate) (msg tgbotapi.MessageCo
 		maxGroups = 1
 	}
 	if users.CountAutonomousGroupsByCreator(userId) >= maxGroups {
-		msg.Text = "You already have a group. Each user can create one group."
+		msg.Text = i18n.T(lang, "creategroup.limit")
 		return msg, nil
 	}
 
 	botName := fatBotUpdate.Bot.Self.UserName
-	msg.Text = fmt.Sprintf(`Let's create your group!
-
-Follow these steps:
-
-1. Open Telegram and create a new group
-   Give it a short name (e.g. "Warriors")
-
-2. Add @%s to the group
-
-3. Make @%s an admin:
-   Tap the group name > Edit > Administrators > Add @%s
-
-4. Tap @%s > turn ON "Remain Anonymous" > then turn it back OFF
-
-That's it! I'll set everything up automatically and send you an invite link to share with friends.`, botName, botName, botName, botName)
+	msg.Text = i18n.T(lang, "creategroup.steps", "bot", botName)
 
 	// Notify super admins
 	adminMsg := tgbotapi.NewMessage(0, fmt.Sprintf(
@@ -322,14 +331,17 @@ func sendLinkJoinForAdminApproval(fatBotUpdate FatBotUpdate, group users.Group)
 	)
 	adminMessage.ReplyMarkup = approvalKeyboard
 	users.SendMessageToGroupAdmins(fatBotUpdate.Bot, group.ChatID, adminMessage)
-	text := `Welcome!
-You'll get a link to join the group soon.
-Once you join, you have 5 days to post your first workout photo in the group chat.
-After that, post at least once every 5 days to stay in!`
-	msg.Text = text
+	msg.Text = joinWelcomeText(senderLang(fatBotUpdate.Update), group.GetRules())
 	return msg, nil
 }
 
+func joinWelcomeText(lang i18n.Lang, rules users.GroupRules) string {
+	return i18n.T(lang, "join.welcome",
+		"grace", i18n.N(lang, "days", rules.NewUserGraceDays),
+		"window", i18n.N(lang, "days", rules.UploadWindowDays),
+	)
+}
+
 func handleJoinCommand(fatBotUpdate FatBotUpdate) (msg tgbotapi.MessageConfig, err error) {
 	if user, err := users.GetUserById(fatBotUpdate.Update.SentFrom().ID); err != nil {
 		if _, classificationErr := err.(*users.NoSuchUserError); classificationErr {
@@ -356,18 +368,14 @@ func handleJoinCommandNewUser(fatBotUpdate FatBotUpdate) (msg tgbotapi.MessageCo
 	)
 	adminMessage.ReplyMarkup = createNewUserGroupsKeyboard(from.ID, from.FirstName, from.UserName)
 	users.SendMessageToSuperAdmins(fatBotUpdate.Bot, adminMessage)
-	text := `Welcome!
-You'll get a link to join the group soon.
-Once you join, you have 5 days to post your first workout photo in the group chat.
-After that, post at least once every 5 days to stay in!`
-	msg.Text = text
+	msg.Text = joinWelcomeText(senderLang(fatBotUpdate.Update), users.DefaultGroupRules())
 	return msg, nil
 }
 
 func handleJoinCommandExistingUser(fatBotUpdate FatBotUpdate, user users.User) (msg tgbotapi.MessageConfig, err error) {
 	msg.ChatID = fatBotUpdate.Update.FromChat().ID
 	if user.Active {
-		msg.Text = "You are already active"
+		msg.Text = i18n.T(user.Lang(), "join.already_active")
 		return msg, nil
 	}
 	lastBanDate, err := user.GetLastBanDate()
@@ -375,9 +383,10 @@ func handleJoinCommandExistingUser(fatBotUpdate FatBotUpdate, user users.User) (
 		return msg, err
 	}
 	timeSinceBan := int(time.Now().Sub(lastBanDate).Hours())
-	waitHours := viper.GetInt("ban.wait.hours")
+	waitHours := user.RejoinWaitHours()
 	if timeSinceBan < waitHours {
-		msg.Text = fmt.Sprintf("%s, it's only been %d hours, you have to wait %d", user.GetName(), timeSinceBan, waitHours)
+		msg.Text = i18n.T(user.Lang(), "join.wait",
+			"name", user.GetName(), "hours", i18n.N(user.Lang(), "hours", timeSinceBan), "wait", i18n.N(user.Lang(), "hours", waitHours))
 	} else {
 		if err := user.Rejoin(fatBotUpdate.Update, fatBotUpdate.Bot); err != nil {
 			return msg, err
@@ -386,7 +395,7 @@ func handleJoinCommandExistingUser(fatBotUpdate FatBotUpdate, user users.User) (
 	return
 }
 
-func handleInstagramCommand(fatBotUpdate FatBotUpdate) error {
+func handleInstagramCommand(fatBotUpdate FatBotUpdate, lang i18n.Lang) error {
 	update := fatBotUpdate.Update
 	bot := fatBotUpdate.Bot
 	user, err := users.GetUserById(update.SentFrom().ID)
@@ -395,7 +404,7 @@ func handleInstagramCommand(fatBotUpdate FatBotUpdate) error {
 	}
 	handle := strings.TrimSpace(update.Message.CommandArguments())
 	if handle == "" {
-		msg := tgbotapi.NewMessage(update.FromChat().ID, "Please provide your Instagram handle: `/instagram your_handle`")
+		msg := tgbotapi.NewMessage(update.FromChat().ID, i18n.T(lang, "instagram.missing_handle"))
 		msg.ParseMode = "Markdown"
 		_, err := bot.Send(msg)
 		return err
@@ -406,12 +415,12 @@ func handleInstagramCommand(fatBotUpdate FatBotUpdate) error {
 	if err := db.DBCon.Save(&user).Error; err != nil {
 		return err
 	}
-	msg := tgbotapi.NewMessage(update.FromChat().ID, fmt.Sprintf("Awesome! I've registered your Instagram handle @%s and enabled daily automated stories. 🔥", handle))
+	msg := tgbotapi.NewMessage(update.FromChat().ID, i18n.T(lang, "instagram.registered", "handle", handle))
 	_, err = bot.Send(msg)
 	return err
 }
 
-func handleInstagramOffCommand(fatBotUpdate FatBotUpdate) error {
+func handleInstagramOffCommand(fatBotUpdate FatBotUpdate, lang i18n.Lang) error {
 	update := fatBotUpdate.Update
 	bot := fatBotUpdate.Bot
 	user, err := users.GetUserById(update.SentFrom().ID)
@@ -422,12 +431,12 @@ func handleInstagramOffCommand(fatBotUpdate FatBotUpdate) error {
 	if err := db.DBCon.Save(&user).Error; err != nil {
 		return err
 	}
-	msg := tgbotapi.NewMessage(update.FromChat().ID, "Daily automated Instagram stories disabled. 🫡")
+	msg := tgbotapi.NewMessage(update.FromChat().ID, i18n.T(lang, "instagram.disabled"))
 	_, err = bot.Send(msg)
 	return err
 }
 
-func handleCancelCommand(fatBotUpdate FatBotUpdate) (tgbotapi.MessageConfig, error) {
+func handleCancelCommand(fatBotUpdate FatBotUpdate, lang i18n.Lang) (tgbotapi.MessageConfig, error) {
 	update := fatBotUpdate.Update
 	bot := fatBotUpdate.Bot
 	msg := tgbotapi.NewMessage(update.FromChat().ID, "")
@@ -435,19 +444,19 @@ func handleCancelCommand(fatBotUpdate FatBotUpdate) (tgbotapi.MessageConfig, err
 	user, err := users.GetUserFromMessage(update.Message)
 	if err != nil {
 		if _, ok := err.(*users.NoSuchUserError); ok {
-			msg.Text = "You are not registered."
+			msg.Text = i18n.T(lang, "user.unregistered")
 			return msg, nil
 		}
 		return msg, err
 	}
 	if user.ID == 0 {
-		msg.Text = "You are not registered."
+		msg.Text = i18n.T(lang, "user.unregistered")
 		return msg, nil
 	}
 
 	chatIds, err := user.GetChatIds()
 	if err != nil {
-		msg.Text = "You are not in any group."
+		msg.Text = i18n.T(lang, "cancel.no_group")
 		return msg, nil
 	}
 
@@ -474,7 +483,7 @@ func handleCancelCommand(fatBotUpdate FatBotUpdate) (tgbotapi.MessageConfig, err
 	}
 
 	if lastWorkout.ID == 0 || lastWorkout.CreatedAt.IsZero() {
-		msg.Text = "You don't have any workouts to cancel."
+		msg.Text = i18n.T(lang, "cancel.nothing")
 		return msg, nil
 	}
 
@@ -484,10 +493,9 @@ func handleCancelCommand(fatBotUpdate FatBotUpdate) (tgbotapi.MessageConfig, err
 	}
 	elapsed := time.Since(lastWorkout.CreatedAt)
 	if elapsed > time.Duration(windowMinutes)*time.Minute {
-		msg.Text = fmt.Sprintf(
-			"Your last workout was %d minutes ago — you can only cancel within %d minutes.",
-			int(elapsed.Minutes()),
-			windowMinutes,
+		msg.Text = i18n.T(lang, "cancel.too_late",
+			"ago", int(elapsed.Minutes()),
+			"window", windowMinutes,
 		)
 		return msg, nil
 	}
@@ -526,17 +534,16 @@ func handleCancelCommand(fatBotUpdate FatBotUpdate) (tgbotapi.MessageConfig, err
 	}
 
 	createdAtStr := deletedWorkout.CreatedAt.Format("2006-01-02 15:04:05")
-	groupMsg := tgbotapi.NewMessage(selectedChat, fmt.Sprintf(
-		"%s cancelled their last workout from %s.",
-		user.GetName(),
-		createdAtStr,
+	groupMsg := tgbotapi.NewMessage(selectedChat, i18n.T(users.GroupLang(selectedChat), "cancel.group",
+		"name", user.GetName(),
+		"time", createdAtStr,
 	))
 	if _, err := bot.Send(groupMsg); err != nil {
 		log.Errorf("cancel: failed to send group confirmation: %s", err)
 		sentry.CaptureException(err)
 	}
 
-	msg.Text = fmt.Sprintf("Cancelled your workout from %s.", createdAtStr)
+	msg.Text = i18n.T(lang, "cancel.done", "time", createdAtStr)
 	return msg, nil
 }
 
@@ -563,13 +570,13 @@ func handleAdminCommandUpdate(fatBotUpdate FatBotUpdate) error {
 
 		if attempts == 1 {
 			// First attempt - send a warning
-			msg.Text = "You are not authorized to use admin commands. Only group administrators can use this feature."
+			msg.Text = i18n.T(user.Lang(), "admin.unauthorized")
 		} else if attempts < 5 {
 			// Repeated attempts - send a stronger warning
-			msg.Text = fmt.Sprintf("Warning: This is your %d attempt to access admin commands. Continued unauthorized attempts may result in being blocked.", attempts)
+			msg.Text = i18n.T(user.Lang(), "admin.unauthorized_again", "attempts", attempts)
 		} else {
 			// Many attempts - send a final warning
-			msg.Text = "FINAL WARNING: Your repeated attempts to access admin features have been logged. Further attempts will result in being blocked from using this bot."
+			msg.Text = i18n.T(user.Lang(), "admin.unauthorized_final")
 
 			// Notify actual admins about the repeated attempts
 			adminMsg := tgbotapi.NewMessage(0, fmt.Sprintf(
@@ -592,6 +599,12 @@ func handleAdminCommandUpdate(fatBotUpdate FatBotUpdate) error {
 		msg = state.HandleAdminCommand(update)
 	case "admin_send_report":
 		schedule.CreateChart(bot)
+	case "admin_backup_status":
+		if !user.IsAdmin {
+			msg.Text = "Only superadmins can see the backup status"
+			break
+		}
+		msg.Text = backupStatusText()
 	default:
 		msg.Text = "Unknown command"
 	}
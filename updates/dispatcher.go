@@ -0,0 +1,237 @@
+package updates
+
+import (
+	"context"
+	"fatbot/metrics"
+	"fmt"
+	"runtime/debug"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/charmbracelet/log"
+	"github.com/getsentry/sentry-go"
+	tgbotapi "github.com/go-telegram-bot-api/telegram-bot-api/v5"
+)
+
+// Dispatcher runs updates on a bounded pool of workers. Updates that share a
+// chat or a user run one at a time in the order they were submitted, while
+// unrelated chats are processed in parallel.
+type Dispatcher struct {
+	bot     *tgbotapi.BotAPI
+	workers int
+	timeout time.Duration
+	handle  func(FatBotUpdate) error
+
+	mu     sync.Mutex
+	queues map[string][]*dispatchTask
+	ready  chan *dispatchTask
+	slots  chan struct{}
+
+	queued   atomic.Int64
+	running  atomic.Int64
+	inflight sync.WaitGroup
+}
+
+type dispatchTask struct {
+	update  tgbotapi.Update
+	keys    []string
+	waiting int
+}
+
+// lastUpdateAt is when the last update finished processing, in Unix nanoseconds.
+var lastUpdateAt atomic.Int64
+
+// LastUpdateAt returns when the last update finished processing, zero if none did yet.
+func LastUpdateAt() time.Time {
+	if nanos := lastUpdateAt.Load(); nanos != 0 {
+		return time.Unix(0, nanos)
+	}
+	return time.Time{}
+}
+
+// DispatcherStats is a snapshot of the dispatcher load.
+type DispatcherStats struct {
+	Queued  int64
+	Running int64
+}
+
+func NewDispatcher(bot *tgbotapi.BotAPI, workers, queueSize int, timeout time.Duration) *Dispatcher {
+	if workers < 1 {
+		workers = 1
+	}
+	if queueSize < workers {
+		queueSize = workers
+	}
+	return &Dispatcher{
+		bot:     bot,
+		workers: workers,
+		timeout: timeout,
+		handle:  HandleUpdates,
+		queues:  make(map[string][]*dispatchTask),
+		ready:   make(chan *dispatchTask, queueSize),
+		slots:   make(chan struct{}, queueSize),
+	}
+}
+
+func (dispatcher *Dispatcher) Start() {
+	for i := 0; i < dispatcher.workers; i++ {
+		go dispatcher.work()
+	}
+}
+
+// Submit queues an update, blocking while the queue is full.
+func (dispatcher *Dispatcher) Submit(update tgbotapi.Update) {
+	dispatcher.slots <- struct{}{}
+	dispatcher.inflight.Add(1)
+	dispatcher.queued.Add(1)
+	metrics.UpdatesQueued.Inc()
+	task := &dispatchTask{update: update, keys: orderingKeys(update)}
+
+	dispatcher.mu.Lock()
+	for _, key := range task.keys {
+		if len(dispatcher.queues[key]) > 0 {
+			task.waiting++
+		}
+		dispatcher.queues[key] = append(dispatcher.queues[key], task)
+	}
+	runnable := task.waiting == 0
+	dispatcher.mu.Unlock()
+
+	if runnable {
+		dispatcher.ready <- task
+	}
+}
+
+func (dispatcher *Dispatcher) Stats() DispatcherStats {
+	return DispatcherStats{
+		Queued:  dispatcher.queued.Load(),
+		Running: dispatcher.running.Load(),
+	}
+}
+
+func (dispatcher *Dispatcher) work() {
+	for task := range dispatcher.ready {
+		dispatcher.queued.Add(-1)
+		dispatcher.running.Add(1)
+		metrics.UpdatesQueued.Dec()
+		metrics.UpdatesRunning.Inc()
+		dispatcher.run(task)
+		dispatcher.running.Add(-1)
+		metrics.UpdatesRunning.Dec()
+		dispatcher.release(task)
+		<-dispatcher.slots
+		dispatcher.inflight.Done()
+	}
+}
+
+// Drain waits until every submitted update was handled or the context is
+// done. Nothing may be submitted once draining started.
+func (dispatcher *Dispatcher) Drain(ctx context.Context) error {
+	done := make(chan struct{})
+	go func() {
+		dispatcher.inflight.Wait()
+		close(done)
+	}()
+	select {
+	case <-done:
+		return nil
+	case <-ctx.Done():
+		stats := dispatcher.Stats()
+		return fmt.Errorf("stopped waiting for %d queued and %d running updates: %w",
+			stats.Queued, stats.Running, ctx.Err())
+	}
+}
+
+// run handles a single update. A handler that runs past the timeout is
+// reported but still waited for: it can't be cancelled, and letting the
+// chat's next update start or Drain return under it would break the ordering
+// and shutdown guarantees.
+func (dispatcher *Dispatcher) run(task *dispatchTask) {
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		defer func() {
+			if r := recover(); r != nil {
+				err := fmt.Errorf("panic handling update %d: %v", task.update.UpdateID, r)
+				log.Error(err, "stack", string(debug.Stack()))
+				metrics.HandlerErrors.WithLabelValues("panic").Inc()
+				sentry.CaptureException(err)
+			}
+		}()
+		if err := dispatcher.handle(FatBotUpdate{Bot: dispatcher.bot, Update: task.update}); err != nil {
+			log.Error(err)
+			sentry.CaptureException(err)
+		}
+		lastUpdateAt.Store(time.Now().UnixNano())
+	}()
+	if dispatcher.timeout <= 0 {
+		<-done
+		return
+	}
+	timer := time.NewTimer(dispatcher.timeout)
+	defer timer.Stop()
+	select {
+	case <-done:
+	case <-timer.C:
+		err := fmt.Errorf("update %d still running after %s", task.update.UpdateID, dispatcher.timeout)
+		log.Error(err, "keys", task.keys)
+		sentry.CaptureException(err)
+		<-done
+	}
+}
+
+// release removes the finished task from its key queues and hands the
+// next task of each queue to the workers once it no longer waits on any key.
+func (dispatcher *Dispatcher) release(task *dispatchTask) {
+	var unblocked []*dispatchTask
+	dispatcher.mu.Lock()
+	for _, key := range task.keys {
+		queue := dispatcher.queues[key][1:]
+		if len(queue) == 0 {
+			delete(dispatcher.queues, key)
+			continue
+		}
+		dispatcher.queues[key] = queue
+		next := queue[0]
+		next.waiting--
+		if next.waiting == 0 {
+			unblocked = append(unblocked, next)
+		}
+	}
+	dispatcher.mu.Unlock()
+	for _, next := range unblocked {
+		dispatcher.ready <- next
+	}
+}
+
+// orderingKeys returns the chat and user an update has to be ordered by.
+func orderingKeys(update tgbotapi.Update) []string {
+	var keys []string
+	if chat := update.FromChat(); chat != nil {
+		keys = append(keys, fmt.Sprintf("chat:%d", chat.ID))
+	}
+	if user := update.SentFrom(); user != nil {
+		keys = append(keys, fmt.Sprintf("user:%d", user.ID))
+	} else if update.PollAnswer != nil {
+		keys = append(keys, fmt.Sprintf("user:%d", update.PollAnswer.User.ID))
+	} else if update.MyChatMember != nil {
+		keys = append(keys, fmt.Sprintf("user:%d", update.MyChatMember.From.ID))
+	}
+	return keys
+}
+
+// LogQueueDepth periodically logs the dispatcher load, warning when the
+// number of waiting updates goes above the threshold.
+func (dispatcher *Dispatcher) LogQueueDepth(interval time.Duration, warnThreshold int64) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for range ticker.C {
+		stats := dispatcher.Stats()
+		if warnThreshold > 0 && stats.Queued >= warnThreshold {
+			log.Warn("Update queue is backing up", "queued", stats.Queued, "running", stats.Running)
+		} else if stats.Queued > 0 || stats.Running > 0 {
+			log.Debug("Update queue", "queued", stats.Queued, "running", stats.Running)
+		}
+	}
+}
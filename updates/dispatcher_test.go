@@ -0,0 +1,142 @@
+package updates
+
+import (
+	"context"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	tgbotapi "github.com/go-telegram-bot-api/telegram-bot-api/v5"
+)
+
+func newChatUpdate(updateId int, chatId, userId int64) tgbotapi.Update {
+	return tgbotapi.Update{
+		UpdateID: updateId,
+		Message: &tgbotapi.Message{
+			Chat: &tgbotapi.Chat{ID: chatId},
+			From: &tgbotapi.User{ID: userId},
+		},
+	}
+}
+
+func TestDispatcherOrdering(t *testing.T) {
+	dispatcher := NewDispatcher(nil, 4, 100, 0)
+	var mu sync.Mutex
+	var wg sync.WaitGroup
+	seen := map[int64][]int{}
+	dispatcher.handle = func(update FatBotUpdate) error {
+		defer wg.Done()
+		// Give later updates of the same chat a chance to overtake
+		time.Sleep(time.Millisecond)
+		mu.Lock()
+		defer mu.Unlock()
+		chatId := update.Update.Message.Chat.ID
+		seen[chatId] = append(seen[chatId], update.Update.UpdateID)
+		return nil
+	}
+	dispatcher.Start()
+
+	for i := 0; i < 40; i++ {
+		wg.Add(1)
+		dispatcher.Submit(newChatUpdate(i, int64(i%3), int64(100+i%5)))
+	}
+	wg.Wait()
+
+	for chatId, ids := range seen {
+		for i := 1; i < len(ids); i++ {
+			if ids[i] < ids[i-1] {
+				t.Errorf("chat %d handled out of order: %v", chatId, ids)
+				break
+			}
+		}
+	}
+}
+
+func TestDispatcherRecoversFromPanic(t *testing.T) {
+	dispatcher := NewDispatcher(nil, 1, 10, 0)
+	handled := make(chan int, 2)
+	dispatcher.handle = func(update FatBotUpdate) error {
+		if update.Update.UpdateID == 1 {
+			panic("boom")
+		}
+		handled <- update.Update.UpdateID
+		return nil
+	}
+	dispatcher.Start()
+
+	dispatcher.Submit(newChatUpdate(1, 1, 1))
+	dispatcher.Submit(newChatUpdate(2, 1, 1))
+
+	select {
+	case id := <-handled:
+		if id != 2 {
+			t.Errorf("got update %d, want 2", id)
+		}
+	case <-time.After(time.Second):
+		t.Fatal("update after panic was never handled")
+	}
+}
+
+func TestDispatcherTimeoutKeepsChatOrder(t *testing.T) {
+	dispatcher := NewDispatcher(nil, 2, 10, 10*time.Millisecond)
+	block := make(chan struct{})
+	handled := make(chan int, 2)
+	dispatcher.handle = func(update FatBotUpdate) error {
+		if update.Update.UpdateID == 1 {
+			<-block
+		}
+		handled <- update.Update.UpdateID
+		return nil
+	}
+	dispatcher.Start()
+
+	dispatcher.Submit(newChatUpdate(1, 1, 1))
+	dispatcher.Submit(newChatUpdate(2, 1, 1))
+	dispatcher.Submit(newChatUpdate(3, 2, 2))
+
+	// Other chats go on while the slow one is past its timeout
+	if id := <-handled; id != 3 {
+		t.Fatalf("got update %d first, want the other chat's 3", id)
+	}
+	select {
+	case id := <-handled:
+		t.Fatalf("update %d started while update 1 of its chat was still running", id)
+	case <-time.After(50 * time.Millisecond):
+	}
+	close(block)
+	for _, want := range []int{1, 2} {
+		if id := <-handled; id != want {
+			t.Errorf("got update %d, want %d", id, want)
+		}
+	}
+}
+
+func TestDispatcherDrain(t *testing.T) {
+	dispatcher := NewDispatcher(nil, 1, 10, 0)
+	block := make(chan struct{})
+	var handled atomic.Int64
+	dispatcher.handle = func(update FatBotUpdate) error {
+		<-block
+		handled.Add(1)
+		return nil
+	}
+	dispatcher.Start()
+	for i := 0; i < 3; i++ {
+		dispatcher.Submit(newChatUpdate(i, int64(i), int64(i)))
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+	if err := dispatcher.Drain(ctx); err == nil {
+		t.Fatal("Drain returned before the blocked updates finished")
+	}
+
+	close(block)
+	if err := dispatcher.Drain(context.Background()); err != nil {
+		t.Fatal(err)
+	}
+	if got := handled.Load(); got != 3 {
+		t.Errorf("got %d handled updates after drain, want 3", got)
+	}
+}
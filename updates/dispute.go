@@ -1,6 +1,7 @@
 package updates
 
 import (
+	"fatbot/i18n"
 	"fatbot/state"
 	"fatbot/users"
 	"fmt"
@@ -124,32 +125,29 @@ func handlePollUpdate(update tgbotapi.Update, bot *tgbotapi.BotAPI) error {
 			}
 
 			// Announce result
-			msg := tgbotapi.NewMessage(group.ChatID,
-				fmt.Sprintf("The group has decided to cancel %s's workout from %s.\nVotes: Yes: %d, No: %d (Required: %d)",
-					targetUser.GetName(),
-					workout.CreatedAt.Format("2006-01-02 15:04:05"),
-					finalYesVotes,
-					finalNoVotes,
-					requiredVotes))
+			msg := tgbotapi.NewMessage(group.ChatID, i18n.T(group.Lang(), "dispute.cancelled",
+				"name", targetUser.GetName(),
+				"time", workout.CreatedAt.Format("2006-01-02 15:04:05"),
+				"yes", finalYesVotes,
+				"no", finalNoVotes,
+				"required", requiredVotes))
 			if _, err := bot.Send(msg); err != nil {
 				return fmt.Errorf("failed to send group message: %v", err)
 			}
 
 			// Notify user
-			userMsg := tgbotapi.NewMessage(targetUser.TelegramUserID,
-				fmt.Sprintf("Your workout from %s was disputed and cancelled by group vote.",
-					workout.CreatedAt.Format("2006-01-02 15:04:05")))
+			userMsg := tgbotapi.NewMessage(targetUser.TelegramUserID, i18n.T(targetUser.Lang(), "dispute.cancelled_dm",
+				"time", workout.CreatedAt.Format("2006-01-02 15:04:05")))
 			if _, err := bot.Send(userMsg); err != nil {
 				return fmt.Errorf("failed to send user message: %v", err)
 			}
 		} else {
 			// Keep the workout
-			msg := tgbotapi.NewMessage(group.ChatID,
-				fmt.Sprintf("The group has decided to keep %s's workout.\nVotes: Yes: %d, No: %d (Required: %d)",
-					targetUser.GetName(),
-					finalYesVotes,
-					finalNoVotes,
-					requiredVotes))
+			msg := tgbotapi.NewMessage(group.ChatID, i18n.T(group.Lang(), "dispute.kept",
+				"name", targetUser.GetName(),
+				"yes", finalYesVotes,
+				"no", finalNoVotes,
+				"required", requiredVotes))
 			if _, err := bot.Send(msg); err != nil {
 				return fmt.Errorf("failed to send group message: %v", err)
 			}
@@ -0,0 +1,121 @@
+package updates
+
+import (
+	"fatbot/i18n"
+	"fatbot/imagehash"
+	"fatbot/users"
+	"fmt"
+
+	"github.com/charmbracelet/log"
+	"github.com/getsentry/sentry-go"
+	tgbotapi "github.com/go-telegram-bot-api/telegram-bot-api/v5"
+)
+
+// photoCheck is what an uploaded workout photo was found to duplicate.
+type photoCheck struct {
+	hash      imagehash.Hash
+	hashed    bool
+	duplicate users.Workout
+	found     bool
+	rules     users.GroupRules
+}
+
+// checkDuplicatePhoto hashes the photo and looks for an earlier one like it.
+// A photo that can't be hashed is never a duplicate.
+func checkDuplicatePhoto(user users.User, group users.Group, imageBytes []byte) (check photoCheck) {
+	check.rules = group.GetRules()
+	if len(imageBytes) == 0 {
+		return
+	}
+	hash, err := imagehash.Compute(imageBytes)
+	if err != nil {
+		log.Warnf("Failed to hash the photo of %s: %s", user.GetName(), err)
+		return
+	}
+	check.hash, check.hashed = hash, true
+	check.duplicate, check.found, err = users.FindDuplicatePhoto(user, group, hash)
+	if err != nil {
+		log.Errorf("Failed to look for duplicates of the photo of %s: %s", user.GetName(), err)
+		sentry.CaptureException(err)
+		check.found = false
+	}
+	return
+}
+
+// refused reports whether the workout shouldn't count at all.
+func (check photoCheck) refused() bool {
+	return check.found && check.rules.DuplicatePhotos == users.DuplicatesRefuse
+}
+
+// duplicateText is what the member is told about a recycled photo, empty if
+// the group doesn't tell.
+func (check photoCheck) duplicateText(lang i18n.Lang, user users.User) string {
+	if !check.found {
+		return ""
+	}
+	date := check.duplicate.CreatedAt.In(check.rules.Location()).Format("2006-01-02")
+	switch check.rules.DuplicatePhotos {
+	case users.DuplicatesRefuse:
+		return i18n.T(lang, "duplicate.refused", "name", user.GetName(), "date", date)
+	case users.DuplicatesWarn:
+		return i18n.T(lang, "duplicate.warning", "name", user.GetName(), "date", date)
+	}
+	return ""
+}
+
+// record stores the hash on the new workout, flags it when it recycles a
+// photo and lets the admins know if the group wants them to.
+func (check photoCheck) record(bot *tgbotapi.BotAPI, user users.User, group users.Group, workout users.Workout, imageBytes []byte) {
+	if !check.hashed || workout.ID == 0 {
+		return
+	}
+	var duplicateOf uint
+	if check.found {
+		duplicateOf = check.duplicate.ID
+	}
+	if err := workout.SavePhotoHash(check.hash, duplicateOf); err != nil {
+		log.Errorf("Failed to save the photo hash of workout %d: %s", workout.ID, err)
+		sentry.CaptureException(err)
+	}
+	if check.found && check.rules.DuplicatePhotos == users.DuplicatesAdmins {
+		notifyAdminsOfDuplicate(bot, user, group, workout, check.duplicate, imageBytes)
+	}
+}
+
+// notifyAdminsOfDuplicate shows the group admins the new photo next to the
+// one it looks like, with a button to open the workout in the workout
+// browser. Without the earlier photo they get the text only.
+func notifyAdminsOfDuplicate(bot *tgbotapi.BotAPI, user users.User, group users.Group, workout, duplicate users.Workout, imageBytes []byte) {
+	earlierGroup := group
+	if duplicate.GroupID != group.ID {
+		if other, err := users.GetGroupByID(duplicate.GroupID); err == nil {
+			earlierGroup = *other
+		}
+	}
+	text := fmt.Sprintf("%s's workout photo in %s looks like the one from %s in %s (left: new, right: earlier)",
+		user.GetName(), group.Title, duplicate.CreatedAt.Format("2006-01-02 15:04"), earlierGroup.Title)
+	keyboard := tgbotapi.NewInlineKeyboardMarkup(tgbotapi.NewInlineKeyboardRow(
+		tgbotapi.NewInlineKeyboardButtonData("Open workout", fmt.Sprintf("workouts:show:%d:0", workout.ID)),
+	))
+
+	var combined []byte
+	if duplicate.PhotoFileID != "" {
+		earlier, err := downloadFile(bot, tgbotapi.FileConfig{FileID: duplicate.PhotoFileID})
+		if err == nil {
+			combined, err = imagehash.SideBySide(imageBytes, earlier)
+		}
+		if err != nil {
+			log.Warnf("Failed to put duplicate photos of workouts %d and %d side by side: %s", workout.ID, duplicate.ID, err)
+		}
+	}
+	if combined == nil {
+		msg := tgbotapi.NewMessage(0, text)
+		msg.ReplyMarkup = keyboard
+		users.SendMessageToGroupAdmins(bot, group.ChatID, msg)
+		return
+	}
+	photo := tgbotapi.NewPhoto(0, tgbotapi.FileBytes{Name: "duplicate.jpg", Bytes: combined})
+	photo.Caption = text
+	photo.ReplyMarkup = keyboard
+	users.SendPhotoToGroupAdmins(bot, group.ChatID, photo)
+}
@@ -4,6 +4,7 @@ import (
 	"encoding/json"
 	"fatbot/db"
 	"fatbot/garmin"
+	"fatbot/i18n"
 	"fatbot/schedule"
 	"fatbot/state"
 	"fatbot/users"
@@ -35,10 +36,10 @@ func HandleGarminCommand(fatBotUpdate FatBotUpdate) (tgbotapi.MessageConfig, err
 
 	authURL := garmin.GetAuthURL(stateStr, challenge)
 	log.Infof("Garmin Auth URL: %s", authURL)
-	msg.Text = "Connect your Garmin account to automatically sync workouts."
+	msg.Text = i18n.T(user.Lang(), "provider.connect.garmin")
 	msg.ReplyMarkup = tgbotapi.NewInlineKeyboardMarkup(
 		tgbotapi.NewInlineKeyboardRow(
-			tgbotapi.NewInlineKeyboardButtonURL("Connect Garmin", authURL),
+			tgbotapi.NewInlineKeyboardButtonURL(i18n.T(user.Lang(), "provider.connect.garmin.button"), authURL),
 		),
 	)
 	return msg, nil
@@ -93,10 +94,7 @@ func HandleGarminCallback(w http.ResponseWriter, r *http.Request) {
 
 	// Notify user via Telegram
 	if GlobalBot != nil {
-		msg := tgbotapi.NewMessage(user.TelegramUserID,
-			"Your Garmin account has been connected successfully!\n\n"+
-				"Your workouts will now be automatically synced. "+
-				"Note: Any previous Strava or Whoop integration has been disconnected.")
+		msg := tgbotapi.NewMessage(user.TelegramUserID, i18n.T(user.Lang(), "provider.connected.garmin"))
 		GlobalBot.Send(msg)
 	}
 
@@ -316,6 +314,7 @@ func HandleGarminWebhook(w http.ResponseWriter, r *http.Request) {
 	}
 
 	for _, batch := range batches {
+		batch.activities = fillKnownGarminActivities(batch.user, batch.activities)
 		if len(batch.activities) == 0 {
 			continue
 		}
@@ -330,7 +329,7 @@ func HandleGarminWebhook(w http.ResponseWriter, r *http.Request) {
 			if GlobalBot != nil {
 				msg := tgbotapi.NewMessage(
 					batch.user.TelegramUserID,
-					"I detected an unusually large Garmin sync and only kept the newest activity to prevent accidental bulk workout uploads.",
+					i18n.T(batch.user.Lang(), "provider.garmin.bulk_sync"),
 				)
 				GlobalBot.Send(msg)
 			}
@@ -387,7 +386,7 @@ func HandleGarminPermissionsChange(w http.ResponseWriter, r *http.Request) {
 				} else {
 					log.Infof("Successfully deregistered Garmin for user %s due to full permission revocation", user.GetName())
 					if GlobalBot != nil {
-						msg := tgbotapi.NewMessage(user.TelegramUserID, "Your Garmin account has been disconnected because all permissions were revoked.")
+						msg := tgbotapi.NewMessage(user.TelegramUserID, i18n.T(user.Lang(), "provider.garmin.permissions_revoked"))
 						GlobalBot.Send(msg)
 					}
 				}
@@ -443,7 +442,7 @@ func HandleGarminDeregistration(w http.ResponseWriter, r *http.Request) {
 			} else {
 				log.Infof("Successfully deregistered Garmin for user %s", user.GetName())
 				if GlobalBot != nil {
-					msg := tgbotapi.NewMessage(user.TelegramUserID, "Your Garmin account has been disconnected.")
+					msg := tgbotapi.NewMessage(user.TelegramUserID, i18n.T(user.Lang(), "provider.garmin.disconnected"))
 					GlobalBot.Send(msg)
 				}
 			}
@@ -454,3 +453,24 @@ func HandleGarminDeregistration(w http.ResponseWriter, r *http.Request) {
 
 	w.WriteHeader(http.StatusOK)
 }
+
+// fillKnownGarminActivities saves the metrics of activities that are already
+// workouts and drops old ones resent by a backfill. Garmin sends both after
+// a metrics backfill, and neither is a new workout.
+func fillKnownGarminActivities(user users.User, activities []garmin.ActivityData) []garmin.ActivityData {
+	cutoff := schedule.GarminBackfillCutoff(user.ID)
+	var fresh []garmin.ActivityData
+	for _, activity := range activities {
+		if users.GarminWorkoutExists(activity.SummaryID) {
+			if err := users.SaveProviderMetrics(users.MetricsGarmin, activity.SummaryID, users.GarminWorkoutMetrics(activity)); err != nil {
+				log.Errorf("Failed to save Garmin metrics for %s: %s", activity.SummaryID, err)
+			}
+			continue
+		}
+		if activity.StartTimeInSeconds < cutoff.Unix() {
+			continue
+		}
+		fresh = append(fresh, activity)
+	}
+	return fresh
+}
@@ -0,0 +1,120 @@
+package updates
+
+import (
+	"fatbot/i18n"
+	"fatbot/users"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/charmbracelet/log"
+	tgbotapi "github.com/go-telegram-bot-api/telegram-bot-api/v5"
+)
+
+// handleGoalCommand shows the user's weekly goals, or sets one with
+// /goal <workouts>. Members of several groups pick the group with a button.
+func handleGoalCommand(fatBotUpdate FatBotUpdate, lang i18n.Lang) (msg tgbotapi.MessageConfig, err error) {
+	update := fatBotUpdate.Update
+	msg.ChatID = update.FromChat().ID
+	user, err := users.GetUserById(update.SentFrom().ID)
+	if err != nil {
+		msg.Text = i18n.T(lang, "user.unregistered")
+		return msg, nil
+	}
+	if len(user.Groups) == 0 {
+		msg.Text = i18n.T(lang, "goal.no_group")
+		return msg, nil
+	}
+	argument := strings.TrimSpace(update.Message.CommandArguments())
+	if argument == "" {
+		msg.Text = goalSummary(lang, user)
+		return msg, nil
+	}
+	goal, err := strconv.Atoi(argument)
+	if err != nil || goal < 0 || goal > users.MaxWeeklyGoal {
+		msg.Text = i18n.T(lang, "goal.invalid", "max", users.MaxWeeklyGoal)
+		return msg, nil
+	}
+	if len(user.Groups) == 1 {
+		msg.Text, err = setGoal(lang, user, *user.Groups[0], goal)
+		return msg, err
+	}
+	var rows [][]tgbotapi.InlineKeyboardButton
+	for _, group := range user.Groups {
+		rows = append(rows, tgbotapi.NewInlineKeyboardRow(
+			tgbotapi.NewInlineKeyboardButtonData(group.Title, fmt.Sprintf("goal:%d:%d", goal, group.ChatID))))
+	}
+	msg.Text = i18n.T(lang, "goal.pick_group")
+	msg.ReplyMarkup = tgbotapi.NewInlineKeyboardMarkup(rows...)
+	return msg, nil
+}
+
+// handleGoalCallback sets the goal in the group picked after /goal, from
+// goal:<workouts>:<chat id>.
+func handleGoalCallback(fatBotUpdate FatBotUpdate) error {
+	bot := fatBotUpdate.Bot
+	callbackQuery := fatBotUpdate.Update.CallbackQuery
+	bot.Request(tgbotapi.NewCallback(callbackQuery.ID, ""))
+	lang := senderLang(fatBotUpdate.Update)
+	var goal int
+	var chatId int64
+	if _, err := fmt.Sscanf(callbackQuery.Data, "goal:%d:%d", &goal, &chatId); err != nil {
+		return fmt.Errorf("bad goal callback %q: %w", callbackQuery.Data, err)
+	}
+	user, err := users.GetUserById(callbackQuery.From.ID)
+	if err != nil {
+		return err
+	}
+	if !user.IsInGroup(chatId) {
+		return fmt.Errorf("%s is not in group %d", user.GetName(), chatId)
+	}
+	group, err := users.GetGroup(chatId)
+	if err != nil {
+		return err
+	}
+	text, err := setGoal(lang, user, group, goal)
+	if err != nil {
+		return err
+	}
+	_, err = bot.Request(tgbotapi.NewEditMessageText(callbackQuery.Message.Chat.ID, callbackQuery.Message.MessageID, text))
+	return err
+}
+
+func setGoal(lang i18n.Lang, user users.User, group users.Group, goal int) (string, error) {
+	if err := user.SetWeeklyGoal(group, goal); err != nil {
+		return "", err
+	}
+	if goal == 0 {
+		return i18n.T(lang, "goal.cleared", "group", group.Title), nil
+	}
+	return i18n.T(lang, "goal.set", "group", group.Title, "done", workoutsThisCycle(user, group.ChatID), "goal", goal), nil
+}
+
+// goalSummary lists the user's goals with this week's progress.
+func goalSummary(lang i18n.Lang, user users.User) string {
+	var lines []string
+	for _, group := range user.Groups {
+		goal, err := user.GetWeeklyGoal(*group)
+		if err != nil {
+			log.Errorf("Failed to get the weekly goal of %s: %s", user.GetName(), err)
+			continue
+		}
+		if goal > 0 {
+			lines = append(lines, i18n.T(lang, "goal.progress",
+				"group", group.Title, "done", workoutsThisCycle(user, group.ChatID), "goal", goal))
+		}
+	}
+	if len(lines) == 0 {
+		return i18n.T(lang, "goal.none")
+	}
+	return i18n.T(lang, "goal.current", "goals", strings.Join(lines, "\n"))
+}
+
+// workoutsThisCycle counts the user's workouts in the group this week. It
+// works on a copy, so the caller's workouts stay as they are.
+func workoutsThisCycle(user users.User, chatId int64) int {
+	if err := user.LoadWorkoutsThisCycle(chatId); err != nil {
+		log.Errorf("Failed to load workouts for user %s: %s", user.GetName(), err)
+	}
+	return len(user.Workouts)
+}
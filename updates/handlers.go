@@ -2,9 +2,11 @@ package updates
 
 import (
 	"fatbot/db"
+	"fatbot/i18n"
 	"fatbot/spotlight"
 	"fatbot/state"
 	"fatbot/users"
+	"fatbot/vision"
 	"fmt"
 	"strings"
 	"time"
@@ -60,7 +62,7 @@ func (update UnknownGroupUpdate) handle() error {
 
 	// Bot is NOT in this group — this is a genuinely unknown group.
 	bot.Send(tgbotapi.NewMessage(update.Update.Message.Chat.ID,
-		fmt.Sprintf("Group %s not activated, send this to the admin: `%d`", update.Update.Message.Chat.Title, chatId),
+		i18n.T(i18n.Default(), "group.not_activated", "title", update.Update.Message.Chat.Title, "chat_id", chatId),
 	))
 	sentry.CaptureMessage(fmt.Sprintf("non activated group: %d, title: %s", chatId, update.Update.FromChat().Title))
 	return nil
@@ -84,7 +86,7 @@ func (update MediaUpdate) handle() error {
 		msg := update.Update.Message
 		chatId := update.Update.FromChat().ID
 
-		if msg.ReplyToMessage != nil && strings.Contains(msg.ReplyToMessage.Text, "Reply to this message with a photo") {
+		if msg.ReplyToMessage != nil && i18n.Sent(msg.ReplyToMessage.Text, "workout.photo_prompt") {
 			user, err := users.GetUserById(chatId)
 			if err != nil {
 				return err
@@ -109,7 +111,7 @@ func (update MediaUpdate) handle() error {
 					count++
 				}
 			}
-			reply := tgbotapi.NewMessage(chatId, fmt.Sprintf("Sent photo to %d groups and saved for your daily progress! 📸", count))
+			reply := tgbotapi.NewMessage(chatId, i18n.N(user.Lang(), "photo.sent_saved", count))
 			update.Bot.Send(reply)
 			return nil
 		}
@@ -125,11 +127,11 @@ func (update MediaUpdate) handle() error {
 				log.Errorf("Failed to store pending photo confirm for user %d: %s", chatId, err)
 				return err
 			}
-			promptMsg := tgbotapi.NewMessage(chatId,
-				"Nice photo! What would you like to do with it?")
-			nowBtn := tgbotapi.NewInlineKeyboardButtonData("Send to groups now", "photo:now")
-			yesBtn := tgbotapi.NewInlineKeyboardButtonData("Save for next workout", "photo:yes")
-			noBtn := tgbotapi.NewInlineKeyboardButtonData("Nothing", "photo:no")
+			lang := senderLang(update.Update)
+			promptMsg := tgbotapi.NewMessage(chatId, i18n.T(lang, "photo.prompt"))
+			nowBtn := tgbotapi.NewInlineKeyboardButtonData(i18n.T(lang, "photo.prompt.now"), "photo:now")
+			yesBtn := tgbotapi.NewInlineKeyboardButtonData(i18n.T(lang, "photo.prompt.save"), "photo:yes")
+			noBtn := tgbotapi.NewInlineKeyboardButtonData(i18n.T(lang, "photo.prompt.nothing"), "photo:no")
 			promptMsg.ReplyMarkup = tgbotapi.NewInlineKeyboardMarkup(
 				tgbotapi.NewInlineKeyboardRow(nowBtn),
 				tgbotapi.NewInlineKeyboardRow(yesBtn, noBtn),
@@ -153,8 +155,8 @@ func (update MediaUpdate) handle() error {
 	if err != nil {
 		return err
 	}
-	labels := detectImageLabels(imageBytes)
-	msg, workout, err := handleWorkoutUpload(update, labels, imageBytes)
+	analysis := vision.Analyze(fileUniqueID(update), imageBytes)
+	msg, workout, err := handleWorkoutUpload(update, analysis, imageBytes)
 	if err != nil {
 		return fmt.Errorf("Error handling last workout: %s", err)
 	}
@@ -167,9 +169,11 @@ func (update MediaUpdate) handle() error {
 	}
 	// Track the bot's reply so /cancel can remove it along with the workout photo.
 	if workout.ID != 0 {
-		workout.NotifyMessageID = sentMsg.MessageID
-		workout.NotifyChatID = update.Update.FromChat().ID
-		if err := db.DBCon.Save(&workout).Error; err != nil {
+		// Only these columns, the upload filled in others since the workout was read
+		if err := db.DBCon.Model(&workout).Updates(map[string]any{
+			"notify_message_id": sentMsg.MessageID,
+			"notify_chat_id":    update.Update.FromChat().ID,
+		}).Error; err != nil {
 			log.Errorf("Failed to store notify message ID for workout %d: %s", workout.ID, err)
 			sentry.CaptureException(err)
 		}
@@ -180,7 +184,7 @@ func (update MediaUpdate) handle() error {
 		MessageID: update.Update.Message.MessageID,
 		Reactions: []tgbotapi.ReactionType{{
 			Type:  "emoji",
-			Emoji: findReaction(labels),
+			Emoji: findReaction(analysis.Labels),
 		}},
 	}
 	if _, err := update.Bot.Request(config); err != nil {
@@ -205,6 +209,16 @@ func (update PrivateUpdate) handle() error {
 		return handleSupportFollowUp(update.FatBotUpdate)
 	}
 
+	// Check if an admin is typing a workout's new date
+	if isWorkoutMoveState(chatId) {
+		return handleWorkoutMoveMessage(update.FatBotUpdate)
+	}
+
+	// Check if user is typing the note of a /log
+	if isManualLogNote(chatId) {
+		return handleManualLogNote(update.FatBotUpdate)
+	}
+
 	// Handle stateful callbacks first
 	if err := handleStatefulCallback(update.FatBotUpdate); err == nil {
 		return err
@@ -212,7 +226,7 @@ func (update PrivateUpdate) handle() error {
 
 	// Default response for private messages
 	msg := tgbotapi.NewMessage(chatId, "")
-	msg.Text = "Try /help"
+	msg.Text = i18n.T(senderLang(update.Update), "private.try_help")
 	if _, err := update.Bot.Send(msg); err != nil {
 		return err
 	}
@@ -224,7 +238,7 @@ func (update GroupReplyUpdate) handle() error {
 	originalText := update.Update.Message.ReplyToMessage.Text
 
 	// Check if this is a reply to the weekly leader message
-	if strings.Contains(originalText, "as this week's first leader") {
+	if i18n.Sent(originalText, "report.leader_message") {
 		// Get the chat and user IDs
 		chatId := update.Update.Message.Chat.ID
 		userId := update.Update.Message.From.ID
@@ -287,7 +301,7 @@ func (update GroupReplyUpdate) handle() error {
 				userName := user.GetName()
 				replyMsg := tgbotapi.NewMessage(
 					chatId,
-					fmt.Sprintf("Thanks for your weekly message, %s! It has been pinned until next week's winner is announced.", userName),
+					i18n.T(users.GroupLang(chatId), "report.leader_thanks", "name", userName),
 				)
 				replyMsg.ReplyToMessageID = update.Update.Message.MessageID
 
@@ -340,7 +354,7 @@ func (update VideoNoteUpdate) handle() error {
 		}
 
 		// Reply to user
-		reply := tgbotapi.NewMessage(chatId, fmt.Sprintf("Sent to %d groups!", count))
+		reply := tgbotapi.NewMessage(chatId, i18n.N(user.Lang(), "photo.sent", count))
 		if _, err := update.Bot.Send(reply); err != nil {
 			return err
 		}
@@ -395,7 +409,7 @@ func instaProcessingKey(telegramUserID int64) string {
 
 // instaRemainingCooldown returns a human-readable string of how long until
 // the user's rate limit expires, e.g. "1 day, 14 hours".
-func instaRemainingCooldown(telegramUserID int64) string {
+func instaRemainingCooldown(lang i18n.Lang, telegramUserID int64) string {
 	key := instaRateLimitKey(telegramUserID)
 	val, err := state.Get(key)
 	if err != nil || val == "" {
@@ -414,9 +428,9 @@ func instaRemainingCooldown(telegramUserID int64) string {
 	days := int(remaining.Hours()) / 24
 	hours := int(remaining.Hours()) % 24
 	if days > 0 {
-		return fmt.Sprintf("%d day(s), %d hour(s)", days, hours)
+		return i18n.T(lang, "duration.days_hours", "days", i18n.N(lang, "days", days), "hours", i18n.N(lang, "hours", hours))
 	}
-	return fmt.Sprintf("%d hour(s)", hours)
+	return i18n.N(lang, "hours", hours)
 }
 
 func (update InstaRequestUpdate) handle() error {
@@ -425,8 +439,9 @@ func (update InstaRequestUpdate) handle() error {
 	chatId := msg.Chat.ID
 	senderID := msg.From.ID
 
-	reply := func(text string) {
-		r := tgbotapi.NewMessage(chatId, text)
+	lang := users.GroupLang(chatId)
+	reply := func(key string, args ...interface{}) {
+		r := tgbotapi.NewMessage(chatId, i18n.T(lang, key, args...))
 		r.ReplyToMessageID = msg.MessageID
 		bot.Send(r)
 	}
@@ -440,13 +455,13 @@ func (update InstaRequestUpdate) handle() error {
 
 	// 2. Must have an Instagram handle registered
 	if user.InstagramHandle == "" {
-		reply("Set your Instagram handle first with /instagram your_handle 📸")
+		reply("spotlight.no_handle")
 		return nil
 	}
 
 	// 3. The replied-to message must be from the same user (own photo only)
 	if msg.ReplyToMessage.From == nil || msg.ReplyToMessage.From.ID != senderID {
-		reply("You can only request a spotlight for your own photos 🙅")
+		reply("spotlight.not_own")
 		return nil
 	}
 
@@ -459,18 +474,18 @@ func (update InstaRequestUpdate) handle() error {
 
 	// 5. Check 2-day rate limit
 	if val, err := state.Get(instaRateLimitKey(senderID)); err == nil && val != "" {
-		remaining := instaRemainingCooldown(senderID)
+		remaining := instaRemainingCooldown(lang, senderID)
 		if remaining != "" {
-			reply(fmt.Sprintf("You've already been featured recently! You can request again in %s ⏳", remaining))
+			reply("spotlight.cooldown", "remaining", remaining)
 		} else {
-			reply("You've already been featured recently! Try again in a bit ⏳")
+			reply("spotlight.cooldown_soon")
 		}
 		return nil
 	}
 
 	// 6. Concurrency lock — prevent double-trigger during async processing
 	if val, err := state.Get(instaProcessingKey(senderID)); err == nil && val != "" {
-		reply("Your spotlight is already being processed, hang tight! ⚙️")
+		reply("spotlight.processing")
 		return nil
 	}
 	if err := state.SetWithTTL(instaProcessingKey(senderID), "1", instaProcessingTTL); err != nil {
@@ -485,7 +500,7 @@ func (update InstaRequestUpdate) handle() error {
 	}
 
 	// 8. Acknowledge in the group
-	reply("📸 Got it! Generating your Instagram spotlight... this may take a minute.")
+	reply("spotlight.started")
 
 	// 9. Set rate limit key BEFORE async work (timestamp value for TTL display)
 	if err := state.SetWithTTL(
@@ -507,19 +522,20 @@ func (update InstaRequestUpdate) handle() error {
 
 func handleCommand(update FatBotUpdate) (tgbotapi.MessageConfig, error) {
 	msg := tgbotapi.NewMessage(update.Update.Message.Chat.ID, "")
+	lang := senderLang(update.Update)
 	switch update.Update.Message.Command() {
 	case "start":
-		msg.Text = "Welcome to FatBot! Use /join to join a group."
+		msg.Text = i18n.T(lang, "start")
 	case "join":
 		return handleJoinCommand(update)
 	case "status":
-		msg = handleStatusCommand(update.Update)
+		msg = handleStatusCommand(update.Update, lang)
 	case "stats":
-		msg = handleStatsCommand(update.Update)
+		msg = handleStatsCommand(update.Update, lang)
 	case "help":
-		msg.Text = "Available commands:\n/start - Start using the bot\n/join - Join a group\n/status - Check your workout status\n/stats - View workout statistics\n/help - Show this help message"
+		msg.Text = i18n.T(lang, "help")
 	default:
-		msg.Text = "Unknown command. Use /help to see available commands."
+		msg.Text = i18n.T(lang, "command.unknown")
 	}
 	return msg, nil
 }
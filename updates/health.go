@@ -0,0 +1,203 @@
+package updates
+
+import (
+	"context"
+	"encoding/json"
+	"fatbot/db"
+	"fatbot/schedule"
+	"fatbot/state"
+	"fmt"
+	"net/http"
+	"sync"
+	"time"
+
+	"github.com/spf13/viper"
+)
+
+const (
+	healthOK       = "ok"
+	healthDegraded = "degraded"
+	healthDown     = "down"
+
+	healthCheckTimeout = 3 * time.Second
+	// A job whose next run is this far in the past means the scheduler is stuck
+	jobLateAfter = 5 * time.Minute
+)
+
+// healthCheck is a single dependency check. Failing a critical check makes
+// the endpoint return 503, failing any other one only marks it degraded.
+type healthCheck struct {
+	name     string
+	critical bool
+	run      func(ctx context.Context) (detail interface{}, err error)
+}
+
+type healthCheckResult struct {
+	Status   string      `json:"status"`
+	Critical bool        `json:"critical"`
+	Error    string      `json:"error,omitempty"`
+	Detail   interface{} `json:"detail,omitempty"`
+}
+
+type healthReport struct {
+	Status string                       `json:"status"`
+	Checks map[string]healthCheckResult `json:"checks"`
+}
+
+// HandleHealthz reports whether the process and its local dependencies work.
+// It doesn't call Telegram so it's cheap enough for frequent liveness probes.
+// Nothing is critical here: restarting the bot doesn't bring back the
+// database or Redis, so an outage only marks it degraded.
+func HandleHealthz(w http.ResponseWriter, r *http.Request) {
+	writeHealthReport(w, r, []healthCheck{nonCritical(databaseCheck), nonCritical(stateCheck), lastUpdateCheck, jobsCheck})
+}
+
+// HandleReadyz reports whether the bot can serve updates, including Telegram.
+func HandleReadyz(w http.ResponseWriter, r *http.Request) {
+	writeHealthReport(w, r, []healthCheck{databaseCheck, stateCheck, telegramCheck, lastUpdateCheck, jobsCheck})
+}
+
+func nonCritical(check healthCheck) healthCheck {
+	check.critical = false
+	return check
+}
+
+func writeHealthReport(w http.ResponseWriter, r *http.Request, checks []healthCheck) {
+	report := runHealthChecks(r.Context(), checks)
+	w.Header().Set("Content-Type", "application/json")
+	if report.Status == healthDown {
+		w.WriteHeader(http.StatusServiceUnavailable)
+	}
+	json.NewEncoder(w).Encode(report)
+}
+
+// runHealthChecks runs the checks concurrently, each with its own timeout.
+func runHealthChecks(ctx context.Context, checks []healthCheck) healthReport {
+	report := healthReport{Status: healthOK, Checks: make(map[string]healthCheckResult, len(checks))}
+	var mu sync.Mutex
+	var wg sync.WaitGroup
+	for _, check := range checks {
+		wg.Add(1)
+		go func(check healthCheck) {
+			defer wg.Done()
+			result := runHealthCheck(ctx, check)
+			mu.Lock()
+			defer mu.Unlock()
+			report.Checks[check.name] = result
+			switch {
+			case result.Status == healthDown:
+				report.Status = healthDown
+			case result.Status == healthDegraded && report.Status == healthOK:
+				report.Status = healthDegraded
+			}
+		}(check)
+	}
+	wg.Wait()
+	return report
+}
+
+func runHealthCheck(ctx context.Context, check healthCheck) healthCheckResult {
+	ctx, cancel := context.WithTimeout(ctx, healthCheckTimeout)
+	defer cancel()
+	type outcome struct {
+		detail interface{}
+		err    error
+	}
+	// Buffered so a check that outlives the timeout doesn't leak the goroutine
+	done := make(chan outcome, 1)
+	go func() {
+		detail, err := check.run(ctx)
+		done <- outcome{detail, err}
+	}()
+	result := healthCheckResult{Status: healthOK, Critical: check.critical}
+	var err error
+	select {
+	case out := <-done:
+		result.Detail, err = out.detail, out.err
+	case <-ctx.Done():
+		err = fmt.Errorf("timed out after %s", healthCheckTimeout)
+	}
+	if err != nil {
+		result.Error = err.Error()
+		result.Status = healthDegraded
+		if check.critical {
+			result.Status = healthDown
+		}
+	}
+	return result
+}
+
+var databaseCheck = healthCheck{
+	name:     "database",
+	critical: true,
+	run: func(ctx context.Context) (interface{}, error) {
+		if db.DBCon == nil {
+			return nil, fmt.Errorf("database is not connected")
+		}
+		return db.Dialect(), db.Ping(ctx)
+	},
+}
+
+var stateCheck = healthCheck{
+	name:     "state",
+	critical: true,
+	run: func(ctx context.Context) (interface{}, error) {
+		return viper.GetString("state.backend"), state.Ping()
+	},
+}
+
+var telegramCheck = healthCheck{
+	name:     "telegram",
+	critical: true,
+	run: func(ctx context.Context) (interface{}, error) {
+		if GlobalBot == nil {
+			return nil, fmt.Errorf("bot is not initialized")
+		}
+		me, err := GlobalBot.GetMe()
+		if err != nil {
+			return nil, err
+		}
+		return me.UserName, nil
+	},
+}
+
+// lastUpdateCheck degrades when no update was processed for
+// health.max_update_age_minutes. Quiet groups are normal, so it's not critical.
+var lastUpdateCheck = healthCheck{
+	name: "last_update",
+	run: func(ctx context.Context) (interface{}, error) {
+		last := LastUpdateAt()
+		if last.IsZero() {
+			return "no updates processed since start", nil
+		}
+		age := time.Since(last).Round(time.Second)
+		detail := map[string]interface{}{"at": last, "age": age.String()}
+		maxAge := time.Duration(viper.GetInt("health.max_update_age_minutes")) * time.Minute
+		if maxAge > 0 && age > maxAge {
+			return detail, fmt.Errorf("last update was %s ago", age)
+		}
+		return detail, nil
+	},
+}
+
+var jobsCheck = healthCheck{
+	name: "jobs",
+	run: func(ctx context.Context) (interface{}, error) {
+		statuses := schedule.JobStatuses()
+		return statuses, lateJobs(statuses, time.Now())
+	},
+}
+
+// lateJobs returns an error naming the jobs that should have started by now.
+func lateJobs(statuses []schedule.JobStatus, now time.Time) error {
+	var late []string
+	for _, status := range statuses {
+		if !status.Running && !status.NextRun.IsZero() && now.Sub(status.NextRun) > jobLateAfter {
+			late = append(late, status.Name)
+		}
+	}
+	if len(late) > 0 {
+		return fmt.Errorf("jobs didn't start on schedule: %v", late)
+	}
+	return nil
+}
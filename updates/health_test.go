@@ -0,0 +1,55 @@
+package updates
+
+import (
+	"context"
+	"errors"
+	"fatbot/schedule"
+	"testing"
+	"time"
+)
+
+func staticCheck(name string, critical bool, err error) healthCheck {
+	return healthCheck{name: name, critical: critical, run: func(ctx context.Context) (interface{}, error) {
+		return nil, err
+	}}
+}
+
+func TestRunHealthChecksStatus(t *testing.T) {
+	failure := errors.New("unreachable")
+	tests := []struct {
+		name   string
+		checks []healthCheck
+		want   string
+	}{
+		{"all ok", []healthCheck{staticCheck("a", true, nil), staticCheck("b", false, nil)}, healthOK},
+		{"optional failed", []healthCheck{staticCheck("a", true, nil), staticCheck("b", false, failure)}, healthDegraded},
+		{"critical failed", []healthCheck{staticCheck("a", true, failure), staticCheck("b", false, failure)}, healthDown},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			report := runHealthChecks(context.Background(), test.checks)
+			if report.Status != test.want {
+				t.Errorf("got %s, want %s", report.Status, test.want)
+			}
+			if len(report.Checks) != len(test.checks) {
+				t.Errorf("got %d results, want %d", len(report.Checks), len(test.checks))
+			}
+		})
+	}
+}
+
+func TestLateJobs(t *testing.T) {
+	now := time.Date(2024, 5, 18, 12, 0, 0, 0, time.UTC)
+	statuses := []schedule.JobStatus{
+		{Name: "scan_users", NextRun: now.Add(time.Hour)},
+		{Name: "whoop_sync", NextRun: now.Add(-time.Minute)},
+		{Name: "update_ranks", NextRun: now.Add(-time.Hour), Running: true},
+	}
+	if err := lateJobs(statuses, now); err != nil {
+		t.Errorf("got %v for jobs within the grace period", err)
+	}
+	statuses = append(statuses, schedule.JobStatus{Name: "weekly_report", NextRun: now.Add(-time.Hour)})
+	if err := lateJobs(statuses, now); err == nil {
+		t.Error("expected an error for a job an hour late")
+	}
+}
@@ -0,0 +1,77 @@
+package updates
+
+import (
+	"fatbot/schedule"
+	"fatbot/users"
+	"fmt"
+	"strings"
+
+	tgbotapi "github.com/go-telegram-bot-api/telegram-bot-api/v5"
+)
+
+// Telegram messages are limited to 4096 characters
+const jobReportMaxLength = 3500
+
+// handleJobCallback runs a job from the Job Runs admin menu. Callback data is
+// job:dry:<name>, job:run:<name> which asks for confirmation, or
+// job:confirm:<name>. Only superadmins can run jobs.
+func handleJobCallback(fatBotUpdate FatBotUpdate) error {
+	bot := fatBotUpdate.Bot
+	callbackQuery := fatBotUpdate.Update.CallbackQuery
+	chatId := callbackQuery.Message.Chat.ID
+	parts := strings.SplitN(callbackQuery.Data, ":", 3)
+	if len(parts) != 3 {
+		return fmt.Errorf("bad job callback %s", callbackQuery.Data)
+	}
+	mode, job := parts[1], parts[2]
+	admin, err := users.GetUserById(callbackQuery.From.ID)
+	if err != nil {
+		return err
+	}
+	if !admin.IsAdmin {
+		bot.Request(tgbotapi.NewCallback(callbackQuery.ID, "Only superadmins can run jobs"))
+		return nil
+	}
+	switch mode {
+	case "run":
+		bot.Request(tgbotapi.NewCallback(callbackQuery.ID, ""))
+		msg := tgbotapi.NewMessage(chatId, fmt.Sprintf("This really runs %s, are you sure?", job))
+		msg.ReplyMarkup = tgbotapi.NewInlineKeyboardMarkup(tgbotapi.NewInlineKeyboardRow(
+			tgbotapi.NewInlineKeyboardButtonData("Yes, run it", "job:confirm:"+job),
+		))
+		_, err = bot.Send(msg)
+		return err
+	case "dry", "confirm":
+	default:
+		return fmt.Errorf("bad job callback %s", callbackQuery.Data)
+	}
+	dryRun := mode == "dry"
+	bot.Request(tgbotapi.NewCallback(callbackQuery.ID, fmt.Sprintf("Running %s", job)))
+	run, err := schedule.RunJob(bot, job, admin.TelegramUserID, dryRun)
+	if err != nil {
+		bot.Send(tgbotapi.NewMessage(chatId, err.Error()))
+		return err
+	}
+	_, err = bot.Send(tgbotapi.NewMessage(chatId, jobRunReport(*run)))
+	return err
+}
+
+func jobRunReport(run users.JobRun) string {
+	report := run.String()
+	if run.Actions == "" {
+		return report + "\nNothing to do"
+	}
+	actions := run.Actions
+	if len(actions) > jobReportMaxLength {
+		// Cut at a line break so a name isn't split mid-character
+		cut := strings.LastIndex(actions[:jobReportMaxLength], "\n")
+		if cut < 0 {
+			cut = 0
+		}
+		actions = actions[:cut] + "\n..."
+	}
+	if run.DryRun {
+		return report + "\nWould:\n" + actions
+	}
+	return report + "\nDid:\n" + actions
+}
@@ -0,0 +1,61 @@
+package updates
+
+import (
+	"fatbot/i18n"
+	"fatbot/users"
+	"fmt"
+	"strings"
+
+	tgbotapi "github.com/go-telegram-bot-api/telegram-bot-api/v5"
+)
+
+// senderLang is the language for replies to whoever sent the update. Users
+// who haven't picked one get the language of their Telegram app.
+func senderLang(update tgbotapi.Update) i18n.Lang {
+	from := update.SentFrom()
+	if from == nil {
+		return i18n.Default()
+	}
+	user, err := users.GetUserById(from.ID)
+	if err != nil {
+		return i18n.Or(from.LanguageCode, i18n.Default())
+	}
+	user.DetectLanguage(from.LanguageCode)
+	return user.Lang()
+}
+
+// handleLanguageCommand shows the user's language, or changes it with
+// /language <code>.
+func handleLanguageCommand(fatBotUpdate FatBotUpdate) (msg tgbotapi.MessageConfig, err error) {
+	update := fatBotUpdate.Update
+	msg.ChatID = update.FromChat().ID
+	user, err := users.GetUserById(update.SentFrom().ID)
+	if err != nil {
+		msg.Text = i18n.T(senderLang(update), "user.unregistered")
+		return msg, nil
+	}
+	code := strings.TrimSpace(update.Message.CommandArguments())
+	if code == "" {
+		msg.Text = i18n.T(user.Lang(), "language.current",
+			"language", user.Lang().Name(), "languages", languageList())
+		return msg, nil
+	}
+	lang, ok := i18n.Parse(code)
+	if !ok {
+		msg.Text = i18n.T(user.Lang(), "language.unsupported", "languages", languageList())
+		return msg, nil
+	}
+	if err := user.SetLanguage(lang); err != nil {
+		return msg, err
+	}
+	msg.Text = i18n.T(lang, "language.changed")
+	return msg, nil
+}
+
+func languageList() string {
+	lines := make([]string, 0, len(i18n.Supported))
+	for _, lang := range i18n.Supported {
+		lines = append(lines, fmt.Sprintf("/language %s - %s", lang, lang.Name()))
+	}
+	return strings.Join(lines, "\n")
+}
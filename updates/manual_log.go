@@ -0,0 +1,461 @@
+package updates
+
+import (
+	"encoding/json"
+	"fatbot/i18n"
+	"fatbot/notify"
+	"fatbot/state"
+	"fatbot/users"
+	"fmt"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/charmbracelet/log"
+	"github.com/getsentry/sentry-go"
+	tgbotapi "github.com/go-telegram-bot-api/telegram-bot-api/v5"
+)
+
+const (
+	manualLogStateKey   = "log:draft:"
+	manualLogPendingKey = "log:pending:"
+	manualLogStateTTL   = 900    // 15 minutes to finish a /log
+	manualLogPendingTTL = 259200 // 3 days for the admins to answer
+	manualLogNoteLength = 200
+)
+
+// manualLog is a /log in progress. It's kept in the state store between the
+// steps: activity, duration, note and groups.
+type manualLog struct {
+	Category     string
+	Minutes      int
+	Note         string
+	AwaitingNote bool
+	GroupChatIDs []int64
+	MessageID    int // The message with the buttons of the current step
+}
+
+// pendingManualLog is a manual log waiting for a group admin.
+type pendingManualLog struct {
+	TelegramUserID int64
+	GroupChatID    int64
+	Metrics        users.WorkoutMetrics
+	Note           string
+}
+
+func getManualLog(chatId int64) (draft manualLog, ok bool) {
+	value, err := state.Get(fmt.Sprint(manualLogStateKey, chatId))
+	if err != nil || value == "" {
+		return draft, false
+	}
+	if err := json.Unmarshal([]byte(value), &draft); err != nil {
+		log.Errorf("Failed to read manual log of %d: %s", chatId, err)
+		return draft, false
+	}
+	return draft, true
+}
+
+func saveManualLog(chatId int64, draft manualLog) error {
+	value, err := json.Marshal(draft)
+	if err != nil {
+		return err
+	}
+	return state.SetWithTTL(fmt.Sprint(manualLogStateKey, chatId), string(value), manualLogStateTTL)
+}
+
+func clearManualLog(chatId int64) {
+	if err := state.ClearString(fmt.Sprint(manualLogStateKey, chatId)); err != nil {
+		log.Error("Failed to clear manual log", "error", err)
+	}
+}
+
+// isManualLogNote checks if the user was asked for the note of a /log.
+func isManualLogNote(chatId int64) bool {
+	draft, ok := getManualLog(chatId)
+	return ok && draft.AwaitingNote
+}
+
+// handleLogCommand starts a /log by asking for the activity.
+func handleLogCommand(fatBotUpdate FatBotUpdate, lang i18n.Lang) error {
+	bot := fatBotUpdate.Bot
+	chatId := fatBotUpdate.Update.FromChat().ID
+	user, err := users.GetUserById(fatBotUpdate.Update.SentFrom().ID)
+	if err != nil {
+		bot.Send(tgbotapi.NewMessage(chatId, i18n.T(lang, "user.unregistered")))
+		return nil
+	}
+	if len(user.Groups) == 0 {
+		bot.Send(tgbotapi.NewMessage(chatId, i18n.T(lang, "log.no_group")))
+		return nil
+	}
+	var draft manualLog
+	// Nothing to pick with a single group
+	if len(user.Groups) == 1 {
+		draft.GroupChatIDs = []int64{user.Groups[0].ChatID}
+	}
+	msg := tgbotapi.NewMessage(chatId, i18n.T(lang, "log.activity"))
+	msg.ReplyMarkup = manualActivityKeyboard(lang)
+	sent, err := bot.Send(msg)
+	if err != nil {
+		return err
+	}
+	draft.MessageID = sent.MessageID
+	return saveManualLog(chatId, draft)
+}
+
+// handleManualLogCallback moves a /log along as the user presses buttons:
+// log:activity:<category>, log:minutes:<n>, log:note:skip,
+// log:group:<chat id>, log:submit and log:cancel.
+func handleManualLogCallback(fatBotUpdate FatBotUpdate) error {
+	bot := fatBotUpdate.Bot
+	callbackQuery := fatBotUpdate.Update.CallbackQuery
+	chatId := callbackQuery.Message.Chat.ID
+	messageId := callbackQuery.Message.MessageID
+	lang := senderLang(fatBotUpdate.Update)
+	parts := strings.SplitN(callbackQuery.Data, ":", 3)
+	action, value := parts[1], ""
+	if len(parts) == 3 {
+		value = parts[2]
+	}
+
+	draft, ok := getManualLog(chatId)
+	if !ok || draft.MessageID != messageId {
+		bot.Request(tgbotapi.NewEditMessageText(chatId, messageId, i18n.T(lang, "log.expired")))
+		bot.Request(tgbotapi.NewCallback(callbackQuery.ID, ""))
+		return nil
+	}
+	user, err := users.GetUserById(callbackQuery.From.ID)
+	if err != nil {
+		bot.Request(tgbotapi.NewCallback(callbackQuery.ID, ""))
+		return err
+	}
+
+	var edit tgbotapi.EditMessageTextConfig
+	switch action {
+	case "activity":
+		if !slices.Contains(users.ManualCategories, value) {
+			return fmt.Errorf("no manual log activity %q", value)
+		}
+		draft.Category = value
+		edit = tgbotapi.NewEditMessageTextAndMarkup(chatId, messageId,
+			i18n.T(lang, "log.duration", "activity", i18n.T(lang, "log.activity."+value)), manualMinutesKeyboard(lang))
+	case "minutes":
+		minutes, err := strconv.Atoi(value)
+		if err != nil || !slices.Contains(users.ManualMinutes, minutes) {
+			return fmt.Errorf("no manual log duration %q", value)
+		}
+		draft.Minutes = minutes
+		draft.AwaitingNote = true
+		edit = tgbotapi.NewEditMessageTextAndMarkup(chatId, messageId, i18n.T(lang, "log.note"),
+			tgbotapi.NewInlineKeyboardMarkup(tgbotapi.NewInlineKeyboardRow(
+				tgbotapi.NewInlineKeyboardButtonData(i18n.T(lang, "log.note_skip"), "log:note:skip"),
+				tgbotapi.NewInlineKeyboardButtonData(i18n.T(lang, "common.cancel"), "log:cancel"),
+			)))
+	case "note":
+		draft.AwaitingNote = false
+		if len(draft.GroupChatIDs) == 1 && len(user.Groups) <= 1 {
+			bot.Request(tgbotapi.NewCallback(callbackQuery.ID, ""))
+			return submitManualLog(fatBotUpdate, user, draft, messageId)
+		}
+		edit = tgbotapi.NewEditMessageTextAndMarkup(chatId, messageId, i18n.T(lang, "log.groups"), manualGroupsKeyboard(lang, user, draft))
+	case "group":
+		groupChatId, err := strconv.ParseInt(value, 10, 64)
+		if err != nil || !user.IsInGroup(groupChatId) {
+			return fmt.Errorf("%s isn't in group %q", user.GetName(), value)
+		}
+		if index := slices.Index(draft.GroupChatIDs, groupChatId); index >= 0 {
+			draft.GroupChatIDs = slices.Delete(draft.GroupChatIDs, index, index+1)
+		} else {
+			draft.GroupChatIDs = append(draft.GroupChatIDs, groupChatId)
+		}
+		edit = tgbotapi.NewEditMessageTextAndMarkup(chatId, messageId, i18n.T(lang, "log.groups"), manualGroupsKeyboard(lang, user, draft))
+	case "submit":
+		if len(draft.GroupChatIDs) == 0 {
+			bot.Request(tgbotapi.NewCallback(callbackQuery.ID, i18n.T(lang, "log.no_groups_picked")))
+			return nil
+		}
+		bot.Request(tgbotapi.NewCallback(callbackQuery.ID, ""))
+		return submitManualLog(fatBotUpdate, user, draft, messageId)
+	default:
+		clearManualLog(chatId)
+		bot.Request(tgbotapi.NewEditMessageText(chatId, messageId, i18n.T(lang, "common.cancelled")))
+		bot.Request(tgbotapi.NewCallback(callbackQuery.ID, ""))
+		return nil
+	}
+	bot.Request(tgbotapi.NewCallback(callbackQuery.ID, ""))
+	if err := saveManualLog(chatId, draft); err != nil {
+		return err
+	}
+	_, err = bot.Request(edit)
+	return err
+}
+
+// handleManualLogNote takes the note the user typed and moves on to the
+// groups.
+func handleManualLogNote(fatBotUpdate FatBotUpdate) error {
+	bot := fatBotUpdate.Bot
+	update := fatBotUpdate.Update
+	chatId := update.FromChat().ID
+	lang := senderLang(update)
+	draft, ok := getManualLog(chatId)
+	if !ok {
+		return nil
+	}
+	note := strings.TrimSpace(update.Message.Text)
+	if note == "" {
+		bot.Send(tgbotapi.NewMessage(chatId, i18n.T(lang, "log.note_text_only")))
+		return nil
+	}
+	if runes := []rune(note); len(runes) > manualLogNoteLength {
+		note = string(runes[:manualLogNoteLength])
+	}
+	draft.Note = note
+	draft.AwaitingNote = false
+	user, err := users.GetUserById(update.SentFrom().ID)
+	if err != nil {
+		return err
+	}
+	// The buttons move to a new message below the note
+	bot.Request(tgbotapi.NewEditMessageReplyMarkup(chatId, draft.MessageID,
+		tgbotapi.InlineKeyboardMarkup{InlineKeyboard: [][]tgbotapi.InlineKeyboardButton{}}))
+	if len(draft.GroupChatIDs) == 1 && len(user.Groups) <= 1 {
+		sent, err := bot.Send(tgbotapi.NewMessage(chatId, i18n.T(lang, "log.logging")))
+		if err != nil {
+			return err
+		}
+		return submitManualLog(fatBotUpdate, user, draft, sent.MessageID)
+	}
+	msg := tgbotapi.NewMessage(chatId, i18n.T(lang, "log.groups"))
+	msg.ReplyMarkup = manualGroupsKeyboard(lang, user, draft)
+	sent, err := bot.Send(msg)
+	if err != nil {
+		return err
+	}
+	draft.MessageID = sent.MessageID
+	return saveManualLog(chatId, draft)
+}
+
+// submitManualLog logs the workout in every picked group the way the group's
+// policy says, and replaces the buttons with how it went in each.
+func submitManualLog(fatBotUpdate FatBotUpdate, user users.User, draft manualLog, messageId int) error {
+	bot := fatBotUpdate.Bot
+	clearManualLog(user.TelegramUserID)
+	lang := user.Lang()
+	metrics := users.ManualWorkoutMetrics(draft.Category, draft.Minutes)
+	var results []string
+	for _, groupChatId := range draft.GroupChatIDs {
+		group, err := users.GetGroup(groupChatId)
+		if err != nil {
+			log.Errorf("Failed to get group %d for manual log: %s", groupChatId, err)
+			continue
+		}
+		result, err := logManualWorkoutInGroup(bot, user, group, metrics, draft.Note)
+		if err != nil {
+			log.Errorf("Failed to log manual workout of %s in %s: %s", user.GetName(), group.Title, err)
+			sentry.CaptureException(err)
+			result = i18n.T(lang, "log.result.failed", "group", group.Title)
+		}
+		results = append(results, result)
+	}
+	_, err := bot.Request(tgbotapi.NewEditMessageText(user.TelegramUserID, messageId, strings.Join(results, "\n")))
+	return err
+}
+
+func logManualWorkoutInGroup(bot *tgbotapi.BotAPI, user users.User, group users.Group, metrics users.WorkoutMetrics, note string) (string, error) {
+	lang := user.Lang()
+	rules := group.GetRules()
+	refusal, err := user.CheckManualLog(group)
+	if err != nil {
+		return "", err
+	}
+	if refusal != users.ManualLogAccepted {
+		return manualLogRefusalText(lang, refusal, group), nil
+	}
+	if rules.ManualLogs == users.ManualLogsApproval {
+		if err := requestManualLogApproval(bot, user, group, metrics, note); err != nil {
+			return "", err
+		}
+		return i18n.T(lang, "log.result.pending", "group", group.Title), nil
+	}
+	workout, err := user.LogManualWorkout(group, metrics, note)
+	if err != nil {
+		return "", err
+	}
+	notify.NotifyManualWorkout(bot, user, workout)
+	return i18n.T(lang, "log.result.logged", "group", group.Title), nil
+}
+
+func manualLogRefusalText(lang i18n.Lang, refusal users.ManualLogRefusal, group users.Group) string {
+	switch refusal {
+	case users.ManualLogDisabled:
+		return i18n.T(lang, "log.result.off", "group", group.Title)
+	case users.ManualLogCapped:
+		return i18n.N(lang, "log.result.capped", group.GetRules().ManualWeeklyCap, "group", group.Title)
+	case users.ManualLogTooSoon:
+		return i18n.T(lang, "log.result.too_soon", "group", group.Title)
+	}
+	return ""
+}
+
+// requestManualLogApproval asks the group admins whether the manual log
+// counts. The first one to answer decides.
+func requestManualLogApproval(bot *tgbotapi.BotAPI, user users.User, group users.Group, metrics users.WorkoutMetrics, note string) error {
+	id := fmt.Sprintf("%d-%d-%d", user.TelegramUserID, group.ID, time.Now().Unix())
+	value, err := json.Marshal(pendingManualLog{
+		TelegramUserID: user.TelegramUserID,
+		GroupChatID:    group.ChatID,
+		Metrics:        metrics,
+		Note:           note,
+	})
+	if err != nil {
+		return err
+	}
+	if err := state.SetWithTTL(manualLogPendingKey+id, string(value), manualLogPendingTTL); err != nil {
+		return err
+	}
+	text := fmt.Sprintf("Manual log from %s in %s: %s, %.0f min", user.GetName(), group.Title, metrics.Category, metrics.DurationMinutes)
+	if note != "" {
+		text += "\nNote: " + note
+	}
+	msg := tgbotapi.NewMessage(0, text+"\n\nDoes it count?")
+	msg.ReplyMarkup = tgbotapi.NewInlineKeyboardMarkup(tgbotapi.NewInlineKeyboardRow(
+		tgbotapi.NewInlineKeyboardButtonData("Approve", "manual:approve:"+id),
+		tgbotapi.NewInlineKeyboardButtonData("Reject", "manual:reject:"+id),
+	))
+	users.SendMessageToGroupAdmins(bot, group.ChatID, msg)
+	return nil
+}
+
+// handleManualApprovalCallback handles manual:approve:<id> and
+// manual:reject:<id> from a group admin.
+func handleManualApprovalCallback(fatBotUpdate FatBotUpdate) error {
+	bot := fatBotUpdate.Bot
+	callbackQuery := fatBotUpdate.Update.CallbackQuery
+	chatId := callbackQuery.Message.Chat.ID
+	messageId := callbackQuery.Message.MessageID
+	parts := strings.SplitN(callbackQuery.Data, ":", 3)
+	action, id := parts[1], parts[2]
+
+	value, err := state.Get(manualLogPendingKey + id)
+	if err != nil || value == "" {
+		bot.Request(tgbotapi.NewEditMessageText(chatId, messageId, callbackQuery.Message.Text+"\n\nAlready answered or expired"))
+		bot.Request(tgbotapi.NewCallback(callbackQuery.ID, ""))
+		return nil
+	}
+	var pending pendingManualLog
+	if err := json.Unmarshal([]byte(value), &pending); err != nil {
+		return err
+	}
+	admin, err := users.GetUserById(callbackQuery.From.ID)
+	if err != nil || !admin.CanManageGroup(pending.GroupChatID) {
+		bot.Request(tgbotapi.NewCallback(callbackQuery.ID, "Only group admins can answer this"))
+		return nil
+	}
+	// Two admins can press at the same time, only the first claim answers
+	claimed, err := state.SetNX(manualLogPendingKey+id+":claimed", fmt.Sprint(admin.TelegramUserID), manualLogPendingTTL)
+	if err != nil {
+		return err
+	}
+	if !claimed {
+		bot.Request(tgbotapi.NewCallback(callbackQuery.ID, "Already answered"))
+		return nil
+	}
+	if err := state.ClearString(manualLogPendingKey + id); err != nil {
+		return err
+	}
+	bot.Request(tgbotapi.NewCallback(callbackQuery.ID, ""))
+
+	user, err := users.GetUserById(pending.TelegramUserID)
+	if err != nil {
+		return err
+	}
+	group, err := users.GetGroup(pending.GroupChatID)
+	if err != nil {
+		return err
+	}
+	lang := user.Lang()
+	activity := i18n.T(lang, "log.activity."+pending.Metrics.Category)
+	minutes := fmt.Sprintf("%.0f", pending.Metrics.DurationMinutes)
+	audit := users.AuditEntry{
+		Action:           users.AuditRejectManual,
+		ActorTelegramID:  admin.TelegramUserID,
+		TargetTelegramID: user.TelegramUserID,
+		GroupChatID:      group.ChatID,
+		After:            fmt.Sprintf("%s, %s min", pending.Metrics.Category, minutes),
+	}
+	outcome := "Rejected"
+	reply := i18n.T(lang, "log.rejected", "activity", activity, "minutes", minutes, "group", group.Title)
+	if action == "approve" {
+		// The cap and the last workout may have changed while the log waited
+		refusal, err := user.CheckManualLog(group)
+		if err != nil {
+			return err
+		}
+		if refusal != users.ManualLogAccepted {
+			bot.Request(tgbotapi.NewEditMessageText(chatId, messageId,
+				fmt.Sprintf("%s\n\nApproved by %s, but it can't be logged anymore: %s", callbackQuery.Message.Text, admin.GetName(), refusal)))
+			_, err = bot.Send(tgbotapi.NewMessage(user.TelegramUserID, i18n.T(lang, "log.refused",
+				"activity", activity, "minutes", minutes, "reason", manualLogRefusalText(lang, refusal, group))))
+			return err
+		}
+		workout, err := user.LogManualWorkout(group, pending.Metrics, pending.Note)
+		if err != nil {
+			return err
+		}
+		notify.NotifyManualWorkout(bot, user, workout)
+		audit.Action = users.AuditApproveManual
+		audit.WorkoutID = workout.ID
+		outcome = "Approved"
+		reply = i18n.T(lang, "log.approved", "activity", activity, "minutes", minutes, "group", group.Title)
+	}
+	users.RecordAudit(audit)
+	bot.Request(tgbotapi.NewEditMessageText(chatId, messageId,
+		fmt.Sprintf("%s\n\n%s by %s", callbackQuery.Message.Text, outcome, admin.GetName())))
+	_, err = bot.Send(tgbotapi.NewMessage(user.TelegramUserID, reply))
+	return err
+}
+
+func manualActivityKeyboard(lang i18n.Lang) tgbotapi.InlineKeyboardMarkup {
+	var rows [][]tgbotapi.InlineKeyboardButton
+	var row []tgbotapi.InlineKeyboardButton
+	for _, category := range users.ManualCategories {
+		row = append(row, tgbotapi.NewInlineKeyboardButtonData(i18n.T(lang, "log.activity."+category), "log:activity:"+category))
+		if len(row) == 3 {
+			rows = append(rows, row)
+			row = nil
+		}
+	}
+	if len(row) > 0 {
+		rows = append(rows, row)
+	}
+	rows = append(rows, tgbotapi.NewInlineKeyboardRow(tgbotapi.NewInlineKeyboardButtonData(i18n.T(lang, "common.cancel"), "log:cancel")))
+	return tgbotapi.NewInlineKeyboardMarkup(rows...)
+}
+
+func manualMinutesKeyboard(lang i18n.Lang) tgbotapi.InlineKeyboardMarkup {
+	var row []tgbotapi.InlineKeyboardButton
+	for _, minutes := range users.ManualMinutes {
+		row = append(row, tgbotapi.NewInlineKeyboardButtonData(fmt.Sprintf("%d min", minutes), fmt.Sprintf("log:minutes:%d", minutes)))
+	}
+	half := len(row) / 2
+	return tgbotapi.NewInlineKeyboardMarkup(row[:half], row[half:],
+		tgbotapi.NewInlineKeyboardRow(tgbotapi.NewInlineKeyboardButtonData(i18n.T(lang, "common.cancel"), "log:cancel")))
+}
+
+func manualGroupsKeyboard(lang i18n.Lang, user users.User, draft manualLog) tgbotapi.InlineKeyboardMarkup {
+	var rows [][]tgbotapi.InlineKeyboardButton
+	for _, group := range user.Groups {
+		mark := "⬜"
+		if slices.Contains(draft.GroupChatIDs, group.ChatID) {
+			mark = "✅"
+		}
+		rows = append(rows, tgbotapi.NewInlineKeyboardRow(
+			tgbotapi.NewInlineKeyboardButtonData(mark+" "+group.Title, fmt.Sprintf("log:group:%d", group.ChatID))))
+	}
+	rows = append(rows, tgbotapi.NewInlineKeyboardRow(
+		tgbotapi.NewInlineKeyboardButtonData(i18n.T(lang, "log.submit"), "log:submit"),
+		tgbotapi.NewInlineKeyboardButtonData(i18n.T(lang, "common.cancel"), "log:cancel"),
+	))
+	return tgbotapi.NewInlineKeyboardMarkup(rows...)
+}
@@ -0,0 +1,170 @@
+package updates
+
+import (
+	"fatbot/i18n"
+	"fatbot/state"
+	"fatbot/users"
+	"fatbot/vision"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/charmbracelet/log"
+	"github.com/getsentry/sentry-go"
+	tgbotapi "github.com/go-telegram-bot-api/telegram-bot-api/v5"
+)
+
+// reviewWorkoutPhoto scores how much the uploaded photo looks like a workout
+// and, below the group's threshold or when it's unsafe, puts the workout in
+// the review queue and shows it to the group admins. Without labels the image
+// analysis failed, so there's nothing to judge the photo by.
+func reviewWorkoutPhoto(bot *tgbotapi.BotAPI, user users.User, group users.Group, workout users.Workout, analysis vision.Analysis, caption string) (queued bool) {
+	if len(analysis.Labels) == 0 || workout.ID == 0 {
+		return false
+	}
+	threshold := group.GetRules().Plausibility
+	score := users.ScorePlausibility(analysis.Labels, analysis.Text, caption)
+	queued = threshold > 0 && (score < threshold || len(analysis.Unsafe) > 0)
+	if err := workout.SavePlausibility(score, queued); err != nil {
+		log.Errorf("Failed to save the plausibility of workout %d: %s", workout.ID, err)
+		sentry.CaptureException(err)
+		return false
+	}
+	if !queued {
+		return false
+	}
+
+	message := fmt.Sprintf("Workout #%d of %s in %s scores %d/100 as a workout, the group's threshold is %d\nLabels: %s",
+		workout.ID, user.GetName(), group.Title, score, threshold, strings.Join(analysis.Labels, ", "))
+	if len(analysis.Unsafe) > 0 {
+		message += "\nUnsafe: " + strings.Join(analysis.Unsafe, ", ")
+	}
+	if caption != "" {
+		message += "\nCaption: " + caption
+	}
+	message += "\n\nIt counts until it's rejected"
+	keyboard := tgbotapi.NewInlineKeyboardMarkup(tgbotapi.NewInlineKeyboardRow(
+		tgbotapi.NewInlineKeyboardButtonData("Approve", fmt.Sprintf("review:approve:%d", workout.ID)),
+		tgbotapi.NewInlineKeyboardButtonData("Reject", fmt.Sprintf("review:reject:%d", workout.ID)),
+	))
+	if workout.PhotoFileID == "" {
+		msg := tgbotapi.NewMessage(0, message)
+		msg.ReplyMarkup = keyboard
+		users.SendMessageToGroupAdmins(bot, group.ChatID, msg)
+		return true
+	}
+	photo := tgbotapi.NewPhoto(0, tgbotapi.FileID(workout.PhotoFileID))
+	photo.Caption = message
+	photo.ReplyMarkup = keyboard
+	users.SendPhotoToGroupAdmins(bot, group.ChatID, photo)
+	return true
+}
+
+// handleReviewCallback handles review:approve:<workout id> and
+// review:reject:<workout id> from the photo sent to the admins, with a
+// trailing :queue when they come from the Review Queue admin menu. Rejecting
+// deletes the workout and its announcement and tells the member.
+func handleReviewCallback(fatBotUpdate FatBotUpdate) error {
+	bot := fatBotUpdate.Bot
+	callbackQuery := fatBotUpdate.Update.CallbackQuery
+	parts := strings.Split(callbackQuery.Data, ":")
+	if len(parts) < 3 {
+		return fmt.Errorf("bad review callback %s", callbackQuery.Data)
+	}
+	workoutId, err := strconv.ParseUint(parts[2], 10, 64)
+	if err != nil {
+		return err
+	}
+	workout, err := users.GetAnyWorkout(uint(workoutId))
+	if err != nil {
+		return err
+	}
+	group, err := users.GetGroupByID(workout.GroupID)
+	if err != nil {
+		return err
+	}
+	admin, err := users.GetUserById(callbackQuery.From.ID)
+	if err != nil || !admin.CanManageGroup(group.ChatID) {
+		bot.Request(tgbotapi.NewCallback(callbackQuery.ID, "Only group admins can review workouts"))
+		return nil
+	}
+	fromQueue := len(parts) == 4 && parts[3] == "queue"
+	if workout.Review != users.ReviewPending {
+		bot.Request(tgbotapi.NewCallback(callbackQuery.ID, "Already reviewed"))
+		return refreshReviewMessage(bot, callbackQuery, group.ChatID, fromQueue, "")
+	}
+	user, err := users.GetUser(workout.UserID)
+	if err != nil {
+		return err
+	}
+
+	entry := users.AuditEntry{
+		ActorTelegramID:  admin.TelegramUserID,
+		TargetTelegramID: user.TelegramUserID,
+		GroupChatID:      group.ChatID,
+		WorkoutID:        workout.ID,
+		Before:           users.ReviewPending,
+	}
+	outcome := "Approved"
+	switch parts[1] {
+	case "approve":
+		entry.Action = users.AuditApproveWorkout
+		entry.After = users.ReviewApproved
+	case "reject":
+		entry.Action = users.AuditRejectWorkout
+		entry.After = users.ReviewRejected
+		outcome = "Rejected"
+	default:
+		return fmt.Errorf("bad review callback %s", callbackQuery.Data)
+	}
+	// The check above can race another admin, only the first answer counts
+	reviewed, err := users.ReviewWorkout(workout.ID, entry.After)
+	if err != nil {
+		return err
+	}
+	if !reviewed {
+		bot.Request(tgbotapi.NewCallback(callbackQuery.ID, "Already reviewed"))
+		return refreshReviewMessage(bot, callbackQuery, group.ChatID, fromQueue, "")
+	}
+	bot.Request(tgbotapi.NewCallback(callbackQuery.ID, ""))
+	if entry.Action == users.AuditRejectWorkout {
+		if workout.NotifyMessageID != 0 && workout.NotifyChatID != 0 {
+			if _, err := bot.Request(tgbotapi.NewDeleteMessage(workout.NotifyChatID, workout.NotifyMessageID)); err != nil {
+				log.Warnf("review: failed to delete the announcement of workout %d: %s", workout.ID, err)
+			}
+		}
+		date := workout.CreatedAt.In(group.GetRules().Location()).Format("2006-01-02 15:04")
+		if _, err := bot.Send(tgbotapi.NewMessage(user.TelegramUserID,
+			i18n.T(user.Lang(), "review.rejected", "date", date, "group", group.Title))); err != nil {
+			log.Errorf("review: failed to tell %s about the rejected workout: %s", user.GetName(), err)
+		}
+	}
+	users.RecordAudit(entry)
+	return refreshReviewMessage(bot, callbackQuery, group.ChatID, fromQueue,
+		fmt.Sprintf("%s by %s", outcome, admin.GetName()))
+}
+
+// refreshReviewMessage redraws the review queue, or marks the single workout
+// review with the outcome and takes its buttons away.
+func refreshReviewMessage(bot *tgbotapi.BotAPI, callbackQuery *tgbotapi.CallbackQuery, groupChatId int64, fromQueue bool, outcome string) error {
+	chatId := callbackQuery.Message.Chat.ID
+	messageId := callbackQuery.Message.MessageID
+	if fromQueue {
+		text, keyboard, err := state.ReviewQueue(groupChatId)
+		if err != nil {
+			return err
+		}
+		_, err = bot.Request(tgbotapi.NewEditMessageTextAndMarkup(chatId, messageId, text, keyboard))
+		return err
+	}
+	if outcome == "" {
+		outcome = "Already reviewed"
+	}
+	var err error
+	if callbackQuery.Message.Photo != nil {
+		_, err = bot.Request(tgbotapi.NewEditMessageCaption(chatId, messageId, callbackQuery.Message.Caption+"\n\n"+outcome))
+	} else {
+		_, err = bot.Request(tgbotapi.NewEditMessageText(chatId, messageId, callbackQuery.Message.Text+"\n\n"+outcome))
+	}
+	return err
+}
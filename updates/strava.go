@@ -2,6 +2,7 @@ package updates
 
 import (
 	"encoding/json"
+	"fatbot/i18n"
 	"fatbot/schedule"
 	"fatbot/strava"
 	"fatbot/users"
@@ -29,11 +30,10 @@ func HandleStravaCommand(fatBotUpdate FatBotUpdate) (tgbotapi.MessageConfig, err
 	authURL := strava.GetAuthURL(state)
 	log.Infof("Strava Auth URL: %s", authURL)
 
-	msg.Text = "Connect your Strava account to automatically sync workouts.\n\n" +
-		"Note: This will disconnect any existing Whoop or Garmin integration."
+	msg.Text = i18n.T(user.Lang(), "provider.connect.strava")
 	msg.ReplyMarkup = tgbotapi.NewInlineKeyboardMarkup(
 		tgbotapi.NewInlineKeyboardRow(
-			tgbotapi.NewInlineKeyboardButtonURL("Connect with Strava", authURL),
+			tgbotapi.NewInlineKeyboardButtonURL(i18n.T(user.Lang(), "provider.connect.strava.button"), authURL),
 		),
 	)
 	return msg, nil
@@ -97,10 +97,7 @@ func HandleStravaCallback(w http.ResponseWriter, r *http.Request) {
 
 	// Notify user via Telegram
 	if GlobalBot != nil {
-		msg := tgbotapi.NewMessage(user.TelegramUserID,
-			fmt.Sprintf("Your Strava account%s has been connected successfully!\n\n"+
-				"Your workouts will now be automatically synced. "+
-				"Note: Any previous Whoop or Garmin integration has been disconnected.", athleteName))
+		msg := tgbotapi.NewMessage(user.TelegramUserID, i18n.T(user.Lang(), "provider.connected.strava", "athlete", athleteName))
 		GlobalBot.Send(msg)
 	}
 
@@ -234,8 +231,7 @@ func handleStravaAthleteEvent(event strava.WebhookEvent) {
 
 			// Notify user
 			if GlobalBot != nil {
-				msg := tgbotapi.NewMessage(user.TelegramUserID,
-					"Your Strava account has been disconnected because you revoked access in Strava settings.")
+				msg := tgbotapi.NewMessage(user.TelegramUserID, i18n.T(user.Lang(), "provider.strava.revoked"))
 				GlobalBot.Send(msg)
 			}
 		}
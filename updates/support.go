@@ -1,6 +1,7 @@
 package updates
 
 import (
+	"fatbot/i18n"
 	"fatbot/state"
 	"fatbot/users"
 	"fmt"
@@ -37,9 +38,10 @@ func isSupportGroupConfigured() bool {
 func handleSupportCommand(fatBotUpdate FatBotUpdate) (tgbotapi.MessageConfig, error) {
 	chatId := fatBotUpdate.Update.FromChat().ID
 	msg := tgbotapi.NewMessage(chatId, "")
+	lang := senderLang(fatBotUpdate.Update)
 
 	if !isSupportGroupConfigured() {
-		msg.Text = "Support is currently unavailable. Please try again later."
+		msg.Text = i18n.T(lang, "support.unavailable")
 		log.Error("Support group not configured")
 		return msg, nil
 	}
@@ -47,7 +49,7 @@ func handleSupportCommand(fatBotUpdate FatBotUpdate) (tgbotapi.MessageConfig, er
 	// Check cooldown
 	cooldownKey := supportCooldownKey + fmt.Sprint(chatId)
 	if _, err := state.Get(cooldownKey); err == nil {
-		msg.Text = "Please wait before sending another support message."
+		msg.Text = i18n.T(lang, "support.cooldown")
 		return msg, nil
 	}
 
@@ -58,7 +60,7 @@ func handleSupportCommand(fatBotUpdate FatBotUpdate) (tgbotapi.MessageConfig, er
 		return msg, err
 	}
 
-	msg.Text = "Please type your support message. You can ask for help or suggest a feature:"
+	msg.Text = i18n.T(lang, "support.prompt")
 	return msg, nil
 }
 
@@ -86,10 +88,11 @@ func handleSupportMessage(fatBotUpdate FatBotUpdate) error {
 	bot := fatBotUpdate.Bot
 	chatId := update.FromChat().ID
 	messageText := update.Message.Text
+	lang := senderLang(update)
 
 	if messageText == "" {
 		clearSupportState(chatId)
-		msg := tgbotapi.NewMessage(chatId, "Support messages currently only accept text. Please use /support and describe your issue in words.")
+		msg := tgbotapi.NewMessage(chatId, i18n.T(lang, "support.text_only_retry"))
 		if _, err := bot.Send(msg); err != nil {
 			log.Error("Failed to send text-only notice", "error", err)
 		}
@@ -109,7 +112,7 @@ func handleSupportMessage(fatBotUpdate FatBotUpdate) error {
 		log.Error("Failed to send support message to group", "error", err)
 		sentry.CaptureException(err)
 		// Don't clear state so user can retry
-		msg := tgbotapi.NewMessage(chatId, "Failed to send your support message. Please try again.")
+		msg := tgbotapi.NewMessage(chatId, i18n.T(lang, "support.failed"))
 		if _, sendErr := bot.Send(msg); sendErr != nil {
 			log.Error("Failed to notify user of support send failure", "error", sendErr)
 		}
@@ -132,7 +135,7 @@ func handleSupportMessage(fatBotUpdate FatBotUpdate) error {
 	}
 
 	// Confirm to user
-	confirmMsg := tgbotapi.NewMessage(chatId, "Your message has been sent to our support team. You'll receive a reply here.\n\nTo continue the conversation, reply directly to the support message you receive.")
+	confirmMsg := tgbotapi.NewMessage(chatId, i18n.T(lang, "support.sent"))
 	if _, err := bot.Send(confirmMsg); err != nil {
 		log.Error("Failed to send support confirmation", "error", err)
 		return err
@@ -259,7 +262,11 @@ func handleSupportGroupReply(fatBotUpdate FatBotUpdate) error {
 	}
 
 	// Send reply to the user's DM
-	userMsg := tgbotapi.NewMessage(userTelegramID, fmt.Sprintf("Support reply:\n\n%s", replyText))
+	lang := i18n.Default()
+	if user, err := users.GetUserById(userTelegramID); err == nil {
+		lang = user.Lang()
+	}
+	userMsg := tgbotapi.NewMessage(userTelegramID, i18n.T(lang, "support.reply", "reply", replyText))
 	sentMsg, err := bot.Send(userMsg)
 	if err != nil {
 		log.Error("Failed to deliver support reply to user", "userID", userTelegramID, "error", err)
@@ -291,9 +298,10 @@ func handleSupportFollowUp(fatBotUpdate FatBotUpdate) error {
 	chatId := update.FromChat().ID
 	replyToMsgID := update.Message.ReplyToMessage.MessageID
 	messageText := update.Message.Text
+	lang := senderLang(update)
 
 	if messageText == "" {
-		msg := tgbotapi.NewMessage(chatId, "Support messages currently only accept text.")
+		msg := tgbotapi.NewMessage(chatId, i18n.T(lang, "support.text_only"))
 		if _, err := bot.Send(msg); err != nil {
 			log.Error("Failed to send text-only notice", "error", err)
 		}
@@ -319,7 +327,7 @@ func handleSupportFollowUp(fatBotUpdate FatBotUpdate) error {
 	if err != nil {
 		log.Error("Failed to send follow-up to support group", "error", err)
 		sentry.CaptureException(err)
-		msg := tgbotapi.NewMessage(chatId, "Failed to send your message. Please try again.")
+		msg := tgbotapi.NewMessage(chatId, i18n.T(lang, "support.failed"))
 		if _, sendErr := bot.Send(msg); sendErr != nil {
 			log.Error("Failed to notify user of follow-up send failure", "error", sendErr)
 		}
@@ -332,7 +340,7 @@ func handleSupportFollowUp(fatBotUpdate FatBotUpdate) error {
 		sentry.CaptureException(err)
 	}
 
-	confirmMsg := tgbotapi.NewMessage(chatId, "Your follow-up has been sent to support.")
+	confirmMsg := tgbotapi.NewMessage(chatId, i18n.T(lang, "support.follow_up_sent"))
 	if _, err := bot.Send(confirmMsg); err != nil {
 		log.Error("Failed to send follow-up confirmation", "error", err)
 	}
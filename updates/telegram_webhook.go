@@ -0,0 +1,123 @@
+package updates
+
+import (
+	"crypto/subtle"
+	"fmt"
+	"net/http"
+	"sync"
+
+	"github.com/charmbracelet/log"
+	tgbotapi "github.com/go-telegram-bot-api/telegram-bot-api/v5"
+	"github.com/spf13/viper"
+)
+
+const telegramSecretTokenHeader = "X-Telegram-Bot-Api-Secret-Token"
+
+// telegramWebhookUpdates buffers updates received on /telegram-webhook until the
+// main loop picks them up, the same way GetUpdatesChan does for long polling.
+var telegramWebhookUpdates = make(chan tgbotapi.Update, 100)
+
+var (
+	telegramWebhookStopped  = make(chan struct{})
+	stopTelegramWebhookOnce sync.Once
+)
+
+// AllowedUpdates are the update types the bot subscribes to, for both
+// long polling and the webhook.
+var AllowedUpdates = []string{"message", "callback_query", "poll", "poll_answer", "my_chat_member"}
+
+func TelegramWebhookEnabled() bool {
+	return viper.GetBool("telegram.webhook.enabled")
+}
+
+func telegramWebhookSecret() string {
+	return viper.GetString("telegram.webhook.secret")
+}
+
+// SetTelegramWebhook registers the webhook with Telegram and returns the channel
+// the /telegram-webhook handler feeds.
+func SetTelegramWebhook(bot *tgbotapi.BotAPI) (tgbotapi.UpdatesChannel, error) {
+	webhookURL := viper.GetString("telegram.webhook.url")
+	if webhookURL == "" {
+		return nil, fmt.Errorf("telegram.webhook.url is required in webhook mode")
+	}
+	if telegramWebhookSecret() == "" {
+		return nil, fmt.Errorf("telegram.webhook.secret is required in webhook mode")
+	}
+	// The secret_token field is newer than the library's WebhookConfig, so the
+	// request is built by hand.
+	params := tgbotapi.Params{}
+	params["url"] = webhookURL
+	params["secret_token"] = telegramWebhookSecret()
+	params.AddNonZero("max_connections", viper.GetInt("telegram.webhook.max_connections"))
+	if err := params.AddInterface("allowed_updates", AllowedUpdates); err != nil {
+		return nil, err
+	}
+	if _, err := bot.MakeRequest("setWebhook", params); err != nil {
+		return nil, fmt.Errorf("setWebhook failed: %s", err)
+	}
+	log.Infof("Telegram webhook set to %s", webhookURL)
+	return telegramWebhookUpdates, nil
+}
+
+// DeleteTelegramWebhook removes the webhook so getUpdates can be used again.
+func DeleteTelegramWebhook(bot *tgbotapi.BotAPI) error {
+	if _, err := bot.Request(tgbotapi.DeleteWebhookConfig{}); err != nil {
+		return fmt.Errorf("deleteWebhook failed: %s", err)
+	}
+	return nil
+}
+
+func HandleTelegramWebhook(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	secret := telegramWebhookSecret()
+	received := r.Header.Get(telegramSecretTokenHeader)
+	if secret == "" || subtle.ConstantTimeCompare([]byte(received), []byte(secret)) != 1 {
+		log.Warn("Telegram webhook secret token mismatch", "remote", r.RemoteAddr)
+		w.WriteHeader(http.StatusUnauthorized)
+		return
+	}
+
+	select {
+	case <-telegramWebhookStopped:
+		// Shutting down, Telegram redelivers the update once a new instance is up
+		w.WriteHeader(http.StatusServiceUnavailable)
+		return
+	default:
+	}
+
+	if GlobalBot == nil {
+		log.Error("GlobalBot not initialized, cannot process Telegram webhook")
+		w.WriteHeader(http.StatusServiceUnavailable)
+		return
+	}
+	update, err := GlobalBot.HandleUpdate(r)
+	if err != nil {
+		// A malformed body will never succeed, so don't make Telegram retry it
+		log.Errorf("Failed to decode Telegram webhook: %s", err)
+		w.WriteHeader(http.StatusOK)
+		return
+	}
+
+	select {
+	case telegramWebhookUpdates <- *update:
+		w.WriteHeader(http.StatusOK)
+	case <-r.Context().Done():
+		// Telegram gave up waiting, it will redeliver the update
+		log.Warn("Telegram webhook queue full, update not accepted", "update_id", update.UpdateID)
+	case <-telegramWebhookStopped:
+		w.WriteHeader(http.StatusServiceUnavailable)
+	}
+}
+
+// StopTelegramWebhook makes the webhook refuse new updates so Telegram keeps
+// them for the next instance. Updates already accepted stay in the channel.
+func StopTelegramWebhook() {
+	stopTelegramWebhookOnce.Do(func() {
+		close(telegramWebhookStopped)
+	})
+}
@@ -1,7 +1,9 @@
 package updates
 
 import (
+	"fatbot/metrics"
 	"fmt"
+	"reflect"
 
 	tgbotapi "github.com/go-telegram-bot-api/telegram-bot-api/v5"
 )
@@ -96,7 +98,7 @@ func HandleUpdates(fatBotUpdate FatBotUpdate) error {
 	// MyChatMember updates have their own From field, not SentFrom
 	if update.MyChatMember != nil {
 		updateType := MyChatMemberUpdate{FatBotUpdate: fatBotUpdate}
-		return updateType.handle()
+		return handleWithMetrics(updateType)
 	}
 	if update.SentFrom() == nil && update.Poll != nil {
 		return nil
@@ -110,10 +112,21 @@ func HandleUpdates(fatBotUpdate FatBotUpdate) error {
 		}
 		return err
 	} else {
-		err := updateType.handle()
+		err := handleWithMetrics(updateType)
 		if err != nil {
 			return err
 		}
 	}
 	return nil
 }
+
+// handleWithMetrics handles the update, counting it and any error under its type name.
+func handleWithMetrics(updateType UpdateType) error {
+	name := reflect.TypeOf(updateType).Name()
+	metrics.UpdatesHandled.WithLabelValues(name).Inc()
+	err := updateType.handle()
+	if err != nil {
+		metrics.HandlerErrors.WithLabelValues(name).Inc()
+	}
+	return err
+}
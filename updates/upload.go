@@ -2,22 +2,23 @@ package updates
 
 import (
 	"fatbot/ai"
+	"fatbot/i18n"
+	"fatbot/notify"
 	"fatbot/users"
+	"fatbot/vision"
 	"fmt"
 	"io"
 	"net/http"
 	"os"
-	"time"
 
 	"github.com/charmbracelet/log"
 	"github.com/getsentry/sentry-go"
 	tgbotapi "github.com/go-telegram-bot-api/telegram-bot-api/v5"
-	"github.com/spf13/viper"
 )
 
 func handleProbationUploadMessage(update tgbotapi.Update, user users.User) (tgbotapi.MessageConfig, error) {
 	msg := tgbotapi.NewMessage(update.FromChat().ID, "")
-	msg.Text = fmt.Sprintf("%s, %s", user.GetName(), ai.GetAiWelcomeResponse())
+	msg.Text = fmt.Sprintf("%s, %s", user.GetName(), ai.GetAiWelcomeResponse(users.GroupLang(update.FromChat().ID), update.FromChat().ID))
 	msg.ReplyToMessageID = update.Message.MessageID
 	return msg, nil
 }
@@ -30,7 +31,23 @@ func getFile(update MediaUpdate) ([]byte, error) {
 	} else if update.Update.Message.Video != nil {
 		fileConfig.FileID = update.Update.Message.Video.Thumbnail.FileID
 	}
-	getFiles, err := update.Bot.GetFile(fileConfig)
+	return downloadFile(update.Bot, fileConfig)
+}
+
+// fileUniqueID is the id of the photo getFile downloads that stays the same
+// when the photo is forwarded or sent again.
+func fileUniqueID(update MediaUpdate) string {
+	numPhotos := len(update.Update.Message.Photo)
+	if numPhotos > 0 {
+		return update.Update.Message.Photo[numPhotos-1].FileUniqueID
+	} else if update.Update.Message.Video != nil && update.Update.Message.Video.Thumbnail != nil {
+		return update.Update.Message.Video.Thumbnail.FileUniqueID
+	}
+	return ""
+}
+
+func downloadFile(bot *tgbotapi.BotAPI, fileConfig tgbotapi.FileConfig) ([]byte, error) {
+	getFiles, err := bot.GetFile(fileConfig)
 	if err != nil {
 		log.Error(err)
 	}
@@ -46,7 +63,7 @@ func getFile(update MediaUpdate) ([]byte, error) {
 	return io.ReadAll(resp.Body)
 }
 
-func handleWorkoutUpload(update MediaUpdate, labels []string, imageBytes []byte) (tgbotapi.MessageConfig, users.Workout, error) {
+func handleWorkoutUpload(update MediaUpdate, analysis vision.Analysis, imageBytes []byte) (tgbotapi.MessageConfig, users.Workout, error) {
 	var message string
 	botUpdate := update.Update
 	msg := tgbotapi.NewMessage(botUpdate.Message.Chat.ID, "")
@@ -66,13 +83,29 @@ func handleWorkoutUpload(update MediaUpdate, labels []string, imageBytes []byte)
 	if err != nil {
 		log.Warn(err)
 	}
-	workOutOnceIn := viper.GetInt("workout.period")
+	workOutOnceIn := users.GetGroupRules(chatId).WorkoutPeriodMinutes
 	if !lastWorkout.IsOlderThan(workOutOnceIn) && !user.OnProbation {
 		return msg, users.Workout{}, nil
-	} else if user.OnProbation {
-		if _, err := user.UpdateWorkout(botUpdate, lastWorkout); err != nil {
+	}
+
+	group, err := users.GetGroup(chatId)
+	if err != nil {
+		return msg, users.Workout{}, err
+	}
+	lang := group.Lang()
+	photo := checkDuplicatePhoto(user, group, imageBytes)
+	if photo.refused() {
+		msg.Text = photo.duplicateText(lang, user)
+		msg.ReplyToMessageID = botUpdate.Message.MessageID
+		return msg, users.Workout{}, nil
+	}
+
+	if user.OnProbation {
+		probationWorkout, err := user.UpdateWorkout(botUpdate, lastWorkout)
+		if err != nil {
 			return msg, users.Workout{}, err
 		}
+		photo.record(update.Bot, user, group, probationWorkout, imageBytes)
 		if err := user.UpdateOnProbation(false); err != nil {
 			return msg, users.Workout{}, fmt.Errorf("Issue updating probation %s: %s", user.GetName(), err)
 		}
@@ -88,57 +121,40 @@ func handleWorkoutUpload(update MediaUpdate, labels []string, imageBytes []byte)
 	if currentWorkout, err = user.UpdateWorkout(botUpdate, lastWorkout); err != nil {
 		return msg, users.Workout{}, err
 	}
+	photo.record(update.Bot, user, group, currentWorkout, imageBytes)
+	inReview := reviewWorkoutPhoto(update.Bot, user, group, currentWorkout, analysis, botUpdate.Message.Caption)
 
-	if lastWorkout.CreatedAt.IsZero() {
-		message = fmt.Sprintf("%s nice work!\nThis is your first workout",
-			user.GetName(),
-		)
-	} else {
-		hours := time.Now().Sub(lastWorkout.CreatedAt).Hours()
-		timeAgo := ""
-		if int(hours/24) == 0 {
-			timeAgo = fmt.Sprintf("%d hours ago", int(hours))
-		} else {
-			days := int(hours / 24)
-			timeAgo = fmt.Sprintf("%d days and %d hours ago", days, int(hours)-days*24)
-		}
+	streak, err := currentWorkout.UpdateStreak(&user, group)
+	if err != nil {
+		log.Errorf("Failed to update the streak of %s: %s", user.GetName(), err)
+	}
+	goal, err := user.GetWeeklyGoal(group)
+	if err != nil {
+		log.Errorf("Failed to get the weekly goal of %s: %s", user.GetName(), err)
+	}
+	if !lastWorkout.CreatedAt.IsZero() {
 		if err := user.LoadWorkoutsThisCycle(chatId); err != nil {
 			return msg, users.Workout{}, err
 		}
-		var streakMessage string
-		if currentWorkout.Streak > 0 {
-			streakSigns := ""
-			for i := 0; i < currentWorkout.Streak; i++ {
-				streakSigns += "👑"
-			}
-			streakMessage = fmt.Sprintf("%d in a row! %s %s", currentWorkout.Streak, streakSigns, users.GetRandomStreakMessage())
-		}
-
-		ranks := users.GetRanks()
-		userRank := ranks[user.Rank]
+		message = notify.StatsMessage(lang, user, lastWorkout, streak, goal, ai.GetAiResponse(lang, chatId, analysis.Labels))
+	} else {
+		message = notify.StatsMessage(lang, user, lastWorkout, streak, goal, "")
+	}
 
-		aiResponse := ai.GetAiResponse(labels)
-		if aiResponse == "" {
-			aiResponse = "Great work!"
+	if appleWatchData, ok := getAppleWatchData(analysis.Text); ok {
+		currentWorkout.WorkoutMetrics = appleWatchData
+		if err := currentWorkout.SaveMetrics(); err != nil {
+			log.Errorf("Failed to save Apple Watch metrics for %s: %s", user.GetName(), err)
+			sentry.CaptureException(err)
 		}
-
-		message = fmt.Sprintf("%s %s\nYour rank: %s\nLast workout: %s (%s)\nThis week: %d\n%s",
-			user.GetName(),
-			aiResponse,
-			fmt.Sprintf("%s %s (%d/%d)",
-				userRank.Name,
-				userRank.Emoji,
-				user.Rank,
-				len(ranks)),
-			lastWorkout.CreatedAt.Weekday(),
-			timeAgo,
-			len(user.Workouts),
-			streakMessage,
-		)
+		message += appleWatchSummary(lang, appleWatchData)
 	}
 
-	if appleWatchData := getAppleWatchData(imageBytes); appleWatchData != "" {
-		message += appleWatchData
+	if warning := photo.duplicateText(lang, user); warning != "" {
+		message += "\n\n" + warning
+	}
+	if inReview {
+		message += "\n\n" + i18n.T(lang, "review.pending")
 	}
 
 	msg.Text = message
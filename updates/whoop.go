@@ -3,6 +3,7 @@ package updates
 import (
 	"encoding/json"
 	"fatbot/db"
+	"fatbot/i18n"
 	"fatbot/notify"
 	"fatbot/state"
 	"fatbot/users"
@@ -29,10 +30,10 @@ func HandleWhoopCommand(fatBotUpdate FatBotUpdate) (tgbotapi.MessageConfig, erro
 	// Force scope update by changing state prefix or just rely on the new scope constant in client
 	authURL := whoop.GetAuthURL(state)
 	log.Infof("Whoop Auth URL: %s", authURL)
-	msg.Text = "Connect your Whoop account to automatically sync workouts."
+	msg.Text = i18n.T(user.Lang(), "provider.connect.whoop")
 	msg.ReplyMarkup = tgbotapi.NewInlineKeyboardMarkup(
 		tgbotapi.NewInlineKeyboardRow(
-			tgbotapi.NewInlineKeyboardButtonURL("Connect Whoop", authURL),
+			tgbotapi.NewInlineKeyboardButtonURL(i18n.T(user.Lang(), "provider.connect.whoop.button"), authURL),
 		),
 	)
 	return msg, nil
@@ -87,10 +88,7 @@ func HandleWhoopCallback(w http.ResponseWriter, r *http.Request) {
 
 	// Notify user via Telegram
 	if GlobalBot != nil {
-		msg := tgbotapi.NewMessage(user.TelegramUserID,
-			"Your Whoop account has been connected successfully!\n\n"+
-				"Your workouts will now be automatically synced. "+
-				"Note: Any previous Strava or Garmin integration has been disconnected.")
+		msg := tgbotapi.NewMessage(user.TelegramUserID, i18n.T(user.Lang(), "provider.connected.whoop"))
 		GlobalBot.Send(msg)
 	}
 
@@ -198,6 +196,7 @@ func processWhoopWorkoutWebhook(event whoop.WebhookEvent) {
 	if err == nil && existing.ID != 0 && existing.WhoopID == "" {
 		log.Infof("Whoop webhook: linking workout %s to existing workout %d for user %s", record.ID, existing.ID, user.GetName())
 		existing.WhoopID = record.ID
+		existing.AdoptMetrics(users.WhoopWorkoutMetrics(record))
 		db.DBCon.Save(&existing)
 		return
 	}
@@ -219,9 +218,9 @@ func processWhoopWorkoutWebhook(event whoop.WebhookEvent) {
 
 	// Bonus or small workout: ask the user
 	if isBonus || isSmall {
-		msg := tgbotapi.NewMessage(user.TelegramUserID, fmt.Sprintf("I detected a workout: %s. Should this count as a workout?", record.SportName))
-		yesBtn := tgbotapi.NewInlineKeyboardButtonData("Yes", fmt.Sprintf("whoop:yes:%s", record.ID))
-		noBtn := tgbotapi.NewInlineKeyboardButtonData("No", fmt.Sprintf("whoop:no:%s", record.ID))
+		msg := tgbotapi.NewMessage(user.TelegramUserID, i18n.T(user.Lang(), "provider.detected.whoop", "sport", record.SportName))
+		yesBtn := tgbotapi.NewInlineKeyboardButtonData(i18n.T(user.Lang(), "common.yes"), fmt.Sprintf("whoop:yes:%s", record.ID))
+		noBtn := tgbotapi.NewInlineKeyboardButtonData(i18n.T(user.Lang(), "common.no"), fmt.Sprintf("whoop:no:%s", record.ID))
 		msg.ReplyMarkup = tgbotapi.NewInlineKeyboardMarkup(tgbotapi.NewInlineKeyboardRow(yesBtn, noBtn))
 		GlobalBot.Send(msg)
 
@@ -232,9 +231,10 @@ func processWhoopWorkoutWebhook(event whoop.WebhookEvent) {
 	// Main workout: create and notify
 	for _, group := range user.Groups {
 		workout := users.Workout{
-			UserID:  user.ID,
-			GroupID: group.ID,
-			WhoopID: record.ID,
+			UserID:         user.ID,
+			GroupID:        group.ID,
+			WhoopID:        record.ID,
+			WorkoutMetrics: users.WhoopWorkoutMetrics(record),
 		}
 		db.DBCon.Create(&workout)
 		notify.NotifyWorkout(GlobalBot, user, workout, record.SportName, record.Score.Strain, record.Score.Kilojoule/4.184, record.Score.AverageHeartRate, duration.Minutes(), 0, "", "")
@@ -250,6 +250,9 @@ func handleWhoopWorkoutUpdate(user users.User, record *whoop.WorkoutData) {
 		log.Errorf("Failed to get workouts for Whoop ID %s: %s", record.ID, err)
 		return
 	}
+	if err := users.SaveProviderMetrics(users.MetricsWhoop, record.ID, users.WhoopWorkoutMetrics(record)); err != nil {
+		log.Errorf("Failed to save Whoop metrics for %s: %s", record.ID, err)
+	}
 
 	for _, workout := range workouts {
 		if workout.NotifyMessageID == 0 || workout.NotifyChatID == 0 {
@@ -0,0 +1,258 @@
+package updates
+
+import (
+	"fatbot/state"
+	"fatbot/users"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	tgbotapi "github.com/go-telegram-bot-api/telegram-bot-api/v5"
+)
+
+const (
+	workoutMoveStateKey = "workouts:move"
+	workoutMoveStateTTL = 600 // 10 minutes to type the new date
+	workoutMoveLayout   = "2006-01-02 15:04"
+)
+
+// handleWorkoutBrowserCallback handles the buttons of the Browse Workouts
+// admin menu:
+//
+//	workouts:page:<telegram user id>:<group chat id>:<page>
+//	workouts:show:<workout id>:<page>
+//	workouts:delete:<workout id>:<page>
+//	workouts:restore:<workout id>:<page>
+//	workouts:move:<workout id>:<page>, which asks for the new date
+//	workouts:group:<workout id>:<page>, which lists the groups to move it to
+//	workouts:regroup:<workout id>:<group chat id>
+//
+// Only admins of the workout's group can use them.
+func handleWorkoutBrowserCallback(fatBotUpdate FatBotUpdate) error {
+	bot := fatBotUpdate.Bot
+	callbackQuery := fatBotUpdate.Update.CallbackQuery
+	chatId := callbackQuery.Message.Chat.ID
+	messageId := callbackQuery.Message.MessageID
+	parts := strings.Split(callbackQuery.Data, ":")
+	if len(parts) < 4 {
+		return fmt.Errorf("bad workout browser callback %s", callbackQuery.Data)
+	}
+	admin, err := users.GetUserById(callbackQuery.From.ID)
+	if err != nil {
+		return err
+	}
+
+	if parts[1] == "page" {
+		if len(parts) != 5 {
+			return fmt.Errorf("bad workout browser callback %s", callbackQuery.Data)
+		}
+		telegramUserId, _ := strconv.ParseInt(parts[2], 10, 64)
+		groupChatId, _ := strconv.ParseInt(parts[3], 10, 64)
+		page, _ := strconv.Atoi(parts[4])
+		if !admin.CanManageGroup(groupChatId) {
+			bot.Request(tgbotapi.NewCallback(callbackQuery.ID, "Only group admins can browse workouts"))
+			return nil
+		}
+		bot.Request(tgbotapi.NewCallback(callbackQuery.ID, ""))
+		text, keyboard, err := state.WorkoutBrowserPage(telegramUserId, groupChatId, page)
+		if err != nil {
+			return err
+		}
+		_, err = bot.Request(tgbotapi.NewEditMessageTextAndMarkup(chatId, messageId, text, keyboard))
+		return err
+	}
+
+	workoutId, err := strconv.ParseUint(parts[2], 10, 64)
+	if err != nil {
+		return err
+	}
+	workout, err := users.GetAnyWorkout(uint(workoutId))
+	if err != nil {
+		return err
+	}
+	group, err := users.GetGroupByID(workout.GroupID)
+	if err != nil {
+		return err
+	}
+	if !admin.CanManageGroup(group.ChatID) {
+		bot.Request(tgbotapi.NewCallback(callbackQuery.ID, "Only group admins can change this workout"))
+		return nil
+	}
+	user, err := users.GetUser(workout.UserID)
+	if err != nil {
+		return err
+	}
+	entry := users.AuditEntry{
+		ActorTelegramID:  admin.TelegramUserID,
+		TargetTelegramID: user.TelegramUserID,
+		GroupChatID:      group.ChatID,
+		WorkoutID:        workout.ID,
+	}
+	page, _ := strconv.Atoi(parts[3])
+
+	switch parts[1] {
+	case "show":
+	case "delete":
+		if err := users.DeleteWorkout(workout.ID); err != nil {
+			return err
+		}
+		if workout.WhoopID != "" {
+			state.SetWithTTL("whoop:ignored:"+workout.WhoopID, "1", 604800) // 7 days
+		}
+		entry.Action = users.AuditDeleteWorkout
+		entry.Before = workout.CreatedAt.Format(time.RFC3339Nano)
+		users.RecordAudit(entry)
+	case "restore":
+		if err := users.RestoreWorkout(workout.ID); err != nil {
+			return err
+		}
+		if workout.WhoopID != "" {
+			state.ClearString("whoop:ignored:" + workout.WhoopID)
+		}
+		entry.Action = users.AuditRestoreWorkout
+		entry.After = workout.CreatedAt.Format(time.RFC3339Nano)
+		users.RecordAudit(entry)
+	case "move":
+		bot.Request(tgbotapi.NewCallback(callbackQuery.ID, ""))
+		if err := state.SetWithTTL(fmt.Sprintf("%s:%d", workoutMoveStateKey, chatId),
+			fmt.Sprintf("%d:%d", workout.ID, page), workoutMoveStateTTL); err != nil {
+			return err
+		}
+		location := group.GetRules().Location()
+		_, err := bot.Send(tgbotapi.NewMessage(chatId, fmt.Sprintf(
+			"Send the new date and time of workout #%d as YYYY-MM-DD HH:MM, in %s (now %s)",
+			workout.ID, location, workout.CreatedAt.In(location).Format(workoutMoveLayout))))
+		return err
+	case "group":
+		bot.Request(tgbotapi.NewCallback(callbackQuery.ID, ""))
+		if err := user.LoadGroups(); err != nil {
+			return err
+		}
+		var groups []*users.Group
+		for _, userGroup := range user.Groups {
+			if userGroup.ID != group.ID && admin.CanManageGroup(userGroup.ChatID) {
+				groups = append(groups, userGroup)
+			}
+		}
+		if len(groups) == 0 {
+			_, err := bot.Send(tgbotapi.NewMessage(chatId, fmt.Sprintf("%s has no other group you manage", user.GetName())))
+			return err
+		}
+		_, err := bot.Request(tgbotapi.NewEditMessageTextAndMarkup(chatId, messageId,
+			fmt.Sprintf("Move workout #%d from %s to:", workout.ID, group.Title),
+			state.CreateWorkoutGroupsKeyboard(workout, groups, page)))
+		return err
+	case "regroup":
+		targetChatId, err := strconv.ParseInt(parts[3], 10, 64)
+		if err != nil {
+			return err
+		}
+		target, err := users.GetGroup(targetChatId)
+		if err != nil {
+			return err
+		}
+		if !admin.CanManageGroup(target.ChatID) || !user.IsInGroup(target.ChatID) {
+			bot.Request(tgbotapi.NewCallback(callbackQuery.ID, "Can't move the workout to that group"))
+			return nil
+		}
+		if err := users.ReassignWorkout(workout.ID, target.ID); err != nil {
+			return err
+		}
+		entry.Action = users.AuditReassignWorkout
+		entry.Before = group.Title
+		entry.After = target.Title
+		users.RecordAudit(entry)
+		// The workout now shows up on the first page of the other group
+		page = 0
+	default:
+		return fmt.Errorf("bad workout browser callback %s", callbackQuery.Data)
+	}
+
+	bot.Request(tgbotapi.NewCallback(callbackQuery.ID, ""))
+	workout, err = users.GetAnyWorkout(workout.ID)
+	if err != nil {
+		return err
+	}
+	text, keyboard, err := state.WorkoutDetails(workout, page)
+	if err != nil {
+		return err
+	}
+	_, err = bot.Request(tgbotapi.NewEditMessageTextAndMarkup(chatId, messageId, text, keyboard))
+	return err
+}
+
+// isWorkoutMoveState checks if the admin was asked for a workout's new date.
+func isWorkoutMoveState(chatId int64) bool {
+	value, err := state.Get(fmt.Sprintf("%s:%d", workoutMoveStateKey, chatId))
+	return err == nil && value != ""
+}
+
+// handleWorkoutMoveMessage moves the workout to the date the admin typed, in
+// the group's timezone.
+func handleWorkoutMoveMessage(fatBotUpdate FatBotUpdate) error {
+	bot := fatBotUpdate.Bot
+	chatId := fatBotUpdate.Update.FromChat().ID
+	stateKey := fmt.Sprintf("%s:%d", workoutMoveStateKey, chatId)
+	value, err := state.Get(stateKey)
+	if err != nil {
+		return err
+	}
+	var workoutId uint
+	var page int
+	if _, err := fmt.Sscanf(value, "%d:%d", &workoutId, &page); err != nil {
+		state.ClearString(stateKey)
+		return err
+	}
+	workout, err := users.GetAnyWorkout(workoutId)
+	if err != nil {
+		state.ClearString(stateKey)
+		return err
+	}
+	group, err := users.GetGroupByID(workout.GroupID)
+	if err != nil {
+		state.ClearString(stateKey)
+		return err
+	}
+	admin, err := users.GetUserById(fatBotUpdate.Update.SentFrom().ID)
+	if err != nil || !admin.CanManageGroup(group.ChatID) {
+		state.ClearString(stateKey)
+		return nil
+	}
+	location := group.GetRules().Location()
+	createdAt, err := time.ParseInLocation(workoutMoveLayout, strings.TrimSpace(fatBotUpdate.Update.Message.Text), location)
+	if err != nil {
+		_, err := bot.Send(tgbotapi.NewMessage(chatId, "That's not a date like 2024-05-18 07:30, try again"))
+		return err
+	}
+	if createdAt.After(time.Now()) {
+		_, err := bot.Send(tgbotapi.NewMessage(chatId, "Workouts can't be moved to the future, try again"))
+		return err
+	}
+	state.ClearString(stateKey)
+	if err := users.MoveWorkout(workout.ID, createdAt); err != nil {
+		return err
+	}
+	user, err := users.GetUser(workout.UserID)
+	if err != nil {
+		return err
+	}
+	users.RecordAudit(users.AuditEntry{
+		Action:           users.AuditMoveWorkout,
+		ActorTelegramID:  admin.TelegramUserID,
+		TargetTelegramID: user.TelegramUserID,
+		GroupChatID:      group.ChatID,
+		WorkoutID:        workout.ID,
+		Before:           workout.CreatedAt.Format(time.RFC3339Nano),
+		After:            createdAt.Format(time.RFC3339Nano),
+	})
+	workout.CreatedAt = createdAt
+	text, keyboard, err := state.WorkoutDetails(workout, page)
+	if err != nil {
+		return err
+	}
+	msg := tgbotapi.NewMessage(chatId, text)
+	msg.ReplyMarkup = keyboard
+	_, err = bot.Send(msg)
+	return err
+}
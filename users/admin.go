@@ -15,20 +15,33 @@ func SendMessageToSuperAdmins(bot *tgbotapi.BotAPI, message tgbotapi.MessageConf
 }
 
 func SendMessageToGroupAdmins(bot *tgbotapi.BotAPI, chatId int64, message tgbotapi.MessageConfig) {
+	for _, admin := range groupAdminsOrSuperAdmins(chatId) {
+		admin.SendPrivateMessage(bot, message)
+	}
+}
+
+// SendPhotoToGroupAdmins is SendMessageToGroupAdmins for a photo.
+func SendPhotoToGroupAdmins(bot *tgbotapi.BotAPI, chatId int64, photo tgbotapi.PhotoConfig) {
+	for _, admin := range groupAdminsOrSuperAdmins(chatId) {
+		photo.ChatID = admin.TelegramUserID
+		if _, err := bot.Send(photo); err != nil {
+			log.Error("can't send photo to admin", "err", err, "admin", admin.GetName())
+		}
+	}
+}
+
+func groupAdminsOrSuperAdmins(chatId int64) []User {
 	group, err := GetGroupWithAdmins(chatId)
 	if err != nil {
 		log.Error("can't get group admins", "err", err, "group", group.Title)
-		return
+		return nil
 	}
 	if len(group.Admins) == 0 {
 		// Fallback to super admins when no local admins exist
 		log.Warn("no local admins for group, falling back to super admins", "group", group.Title)
-		SendMessageToSuperAdmins(bot, message)
-		return
-	}
-	for _, admin := range group.Admins {
-		admin.SendPrivateMessage(bot, message)
+		return GetSuperAdminUsers()
 	}
+	return group.Admins
 }
 
 func (user User) AddLocalAdmin(chatId int64) error {
@@ -71,3 +84,17 @@ func (user User) IsLocalAdmin() (bool, error) {
 	}
 	return len(user.GroupsAdmin) > 0, nil
 }
+
+// CanManageGroup reports whether the user is a super admin or an admin of the group.
+func (user User) CanManageGroup(chatId int64) bool {
+	if user.IsAdmin {
+		return true
+	}
+	user.loadManagedGroups()
+	for _, group := range user.GroupsAdmin {
+		if group.ChatID == chatId {
+			return true
+		}
+	}
+	return false
+}
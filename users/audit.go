@@ -0,0 +1,269 @@
+package users
+
+import (
+	"fatbot/db"
+	"fatbot/i18n"
+	"fmt"
+	"time"
+
+	"github.com/charmbracelet/log"
+	tgbotapi "github.com/go-telegram-bot-api/telegram-bot-api/v5"
+	"gorm.io/gorm"
+)
+
+type AuditAction string
+
+const (
+	AuditBan             AuditAction = "ban"
+	AuditRename          AuditAction = "rename"
+	AuditPushWorkout     AuditAction = "pushWorkout"
+	AuditDeleteWorkout   AuditAction = "deleteWorkout"
+	AuditRemoveUser      AuditAction = "removeUser"
+	AuditImmunity        AuditAction = "immunity"
+	AuditAddAdmin        AuditAction = "addAdmin"
+	AuditRemoveAdmin     AuditAction = "removeAdmin"
+	AuditCloseGroup      AuditAction = "closeGroup"
+	AuditApproveManual   AuditAction = "approveManualLog"
+	AuditRejectManual    AuditAction = "rejectManualLog"
+	AuditRestoreWorkout  AuditAction = "restoreWorkout"
+	AuditMoveWorkout     AuditAction = "moveWorkout"
+	AuditReassignWorkout AuditAction = "reassignWorkout"
+	AuditApproveWorkout  AuditAction = "approveWorkout"
+	AuditRejectWorkout   AuditAction = "rejectWorkout"
+	AuditUndo            AuditAction = "undo"
+)
+
+// SystemActor is the actor id of actions taken by the bot itself, e.g. bans
+// for missing the upload window.
+const SystemActor int64 = 0
+
+// DeletedActor replaces the actor id of entries by a user who deleted their
+// account.
+const DeletedActor int64 = -1
+
+// AuditEntry records an admin or system action: who did what to whom in
+// which group, with the value before and after the change.
+type AuditEntry struct {
+	gorm.Model
+	Action           AuditAction
+	ActorTelegramID  int64
+	TargetTelegramID int64 `gorm:"index"`
+	GroupChatID      int64 `gorm:"index"`
+	WorkoutID        uint
+	Before           string
+	After            string
+	UndoneAt         *time.Time
+	UndoneBy         int64
+}
+
+// RecordAudit stores an audit entry. Failing to audit never fails the action
+// itself, so errors are only logged.
+func RecordAudit(entry AuditEntry) {
+	if err := db.DBCon.Create(&entry).Error; err != nil {
+		log.Error("Failed to record audit entry", "action", entry.Action, "err", err)
+	}
+}
+
+// GetAuditEntries returns the latest entries for a group, optionally narrowed
+// down to a single target user.
+func GetAuditEntries(groupChatId, targetTelegramId int64, limit int) (entries []AuditEntry, err error) {
+	query := db.DBCon.Where("group_chat_id = ?", groupChatId)
+	if targetTelegramId != 0 {
+		query = query.Where("target_telegram_id = ?", targetTelegramId)
+	}
+	err = query.Order("created_at DESC").Limit(limit).Find(&entries).Error
+	return
+}
+
+func GetAuditEntry(id uint) (entry AuditEntry, err error) {
+	err = db.DBCon.First(&entry, id).Error
+	return
+}
+
+// Reversible reports whether the entry can still be undone.
+func (entry AuditEntry) Reversible() bool {
+	if entry.UndoneAt != nil {
+		return false
+	}
+	switch entry.Action {
+	case AuditBan, AuditRename, AuditPushWorkout, AuditDeleteWorkout, AuditImmunity, AuditApproveManual,
+		AuditRestoreWorkout, AuditMoveWorkout, AuditReassignWorkout, AuditApproveWorkout, AuditRejectWorkout:
+		return true
+	}
+	return false
+}
+
+func (entry AuditEntry) String() string {
+	actor := "system"
+	if entry.ActorTelegramID != SystemActor {
+		actor = auditUserName(entry.ActorTelegramID)
+	}
+	text := fmt.Sprintf("#%d %s %s: %s -> %s (%s)",
+		entry.ID,
+		entry.CreatedAt.Format("2006-01-02 15:04"),
+		entry.Action,
+		auditUserName(entry.TargetTelegramID),
+		entry.describeChange(),
+		actor,
+	)
+	if entry.UndoneAt != nil {
+		text += fmt.Sprintf(" [undone by %s]", auditUserName(entry.UndoneBy))
+	}
+	return text
+}
+
+func (entry AuditEntry) describeChange() string {
+	if entry.Before == "" && entry.After == "" {
+		return "done"
+	}
+	return fmt.Sprintf("%q => %q", entry.Before, entry.After)
+}
+
+func auditUserName(telegramUserId int64) string {
+	if telegramUserId == DeletedActor {
+		return "deleted user"
+	}
+	user, err := GetUserById(telegramUserId)
+	if err != nil || user.ID == 0 {
+		return fmt.Sprint(telegramUserId)
+	}
+	return user.GetName()
+}
+
+// Undo reverts the action and marks the entry as undone by the actor. The
+// entry is claimed first, so when two admins undo it at once only one
+// reverts it.
+func (entry *AuditEntry) Undo(bot *tgbotapi.BotAPI, actorTelegramId int64) error {
+	if !entry.Reversible() {
+		return fmt.Errorf("%s #%d can't be undone", entry.Action, entry.ID)
+	}
+	user, err := GetUserById(entry.TargetTelegramID)
+	if err != nil {
+		return err
+	}
+	now := time.Now()
+	claim := db.DBCon.Model(&AuditEntry{}).Where("id = ? AND undone_at IS NULL", entry.ID).
+		Updates(map[string]interface{}{"undone_at": now, "undone_by": actorTelegramId})
+	if claim.Error != nil {
+		return claim.Error
+	}
+	if claim.RowsAffected != 1 {
+		return fmt.Errorf("%s #%d was already undone", entry.Action, entry.ID)
+	}
+	entry.UndoneAt = &now
+	entry.UndoneBy = actorTelegramId
+	switch entry.Action {
+	case AuditRename:
+		err = user.Rename(entry.Before)
+	case AuditImmunity:
+		err = db.DBCon.Model(&user).Update("immuned", entry.Before == "true").Error
+	case AuditPushWorkout, AuditMoveWorkout:
+		var createdAt time.Time
+		if createdAt, err = time.Parse(time.RFC3339Nano, entry.Before); err == nil {
+			err = MoveWorkout(entry.WorkoutID, createdAt)
+		}
+	case AuditDeleteWorkout:
+		err = RestoreWorkout(entry.WorkoutID)
+	case AuditRestoreWorkout:
+		err = DeleteWorkout(entry.WorkoutID)
+	case AuditReassignWorkout:
+		// The entry belongs to the group the workout was moved from
+		var group Group
+		if group, err = GetGroup(entry.GroupChatID); err == nil {
+			err = ReassignWorkout(entry.WorkoutID, group.ID)
+		}
+	case AuditBan:
+		err = user.undoBan(bot, *entry)
+	case AuditApproveManual:
+		err = db.DBCon.Delete(&Workout{}, entry.WorkoutID).Error
+	case AuditApproveWorkout:
+		err = RejectWorkout(entry.WorkoutID)
+	case AuditRejectWorkout:
+		err = ApproveWorkout(entry.WorkoutID)
+	}
+	if err != nil {
+		// Release the claim so the undo can be tried again
+		if release := db.DBCon.Model(&AuditEntry{}).Where("id = ?", entry.ID).
+			Updates(map[string]interface{}{"undone_at": nil, "undone_by": 0}).Error; release != nil {
+			log.Error("Failed to release audit entry", "id", entry.ID, "err", release)
+		}
+		entry.UndoneAt = nil
+		entry.UndoneBy = 0
+		return err
+	}
+	RecordAudit(AuditEntry{
+		Action:           AuditUndo,
+		ActorTelegramID:  actorTelegramId,
+		TargetTelegramID: entry.TargetTelegramID,
+		GroupChatID:      entry.GroupChatID,
+		WorkoutID:        entry.WorkoutID,
+		Before:           string(entry.Action),
+		After:            fmt.Sprintf("#%d undone", entry.ID),
+	})
+	return nil
+}
+
+// undoBan lifts a ban from a single group and sends the user a fresh invite.
+// Unlike Rejoin it doesn't put the user on probation, the ban was a mistake.
+func (user *User) undoBan(bot *tgbotapi.BotAPI, entry AuditEntry) error {
+	chatId := entry.GroupChatID
+	unbanConfig := tgbotapi.UnbanChatMemberConfig{
+		ChatMemberConfig: user.CreateChatMemberConfig(bot.Self.UserName, chatId),
+		OnlyIfBanned:     true,
+	}
+	if _, err := bot.Request(unbanConfig); err != nil {
+		return err
+	}
+	if err := user.UpdateActive(true); err != nil {
+		return err
+	}
+	if err := user.forgiveBan(entry); err != nil {
+		return err
+	}
+	response, err := bot.Request(tgbotapi.CreateChatInviteLinkConfig{
+		ChatConfig:  tgbotapi.ChatConfig{ChatID: chatId, SuperGroupUsername: bot.Self.UserName},
+		Name:        user.GetName(),
+		ExpireDate:  int(time.Now().Add(24 * time.Hour).Unix()),
+		MemberLimit: 1,
+	})
+	if err != nil {
+		return err
+	}
+	link, err := extractInviteLinkFromResponse(response)
+	if err != nil {
+		return err
+	}
+	msg := tgbotapi.NewMessage(user.TelegramUserID,
+		i18n.T(user.Lang(), "ban.lifted", "link", link))
+	_, err = bot.Send(msg)
+	return err
+}
+
+// banEventWindow is how long before its audit entry a ban's event can be
+// recorded, with the ban messages sent in between.
+const banEventWindow = 10 * time.Minute
+
+// forgiveBan removes the ban event of the entry, so the rejoin wait doesn't
+// count it, and adds a dummy workout in the group so the next scan doesn't
+// ban the user again for the same missed window.
+func (user *User) forgiveBan(entry AuditEntry) error {
+	group, err := GetGroup(entry.GroupChatID)
+	if err != nil {
+		return err
+	}
+	return db.DBCon.Transaction(func(tx *gorm.DB) error {
+		var event Event
+		err := tx.Where("user_id = ? AND event = ? AND created_at BETWEEN ? AND ?", user.ID, BanEventType,
+			entry.CreatedAt.Add(-banEventWindow), entry.CreatedAt).
+			Order("created_at DESC").Limit(1).Find(&event).Error
+		if err != nil {
+			return err
+		}
+		if event.ID != 0 {
+			if err := tx.Delete(&event).Error; err != nil {
+				return err
+			}
+		}
+		return tx.Create(&Workout{UserID: user.ID, GroupID: group.ID, Flagged: true}).Error
+	})
+}
@@ -0,0 +1,203 @@
+package users
+
+import (
+	"fatbot/db"
+	"fmt"
+	"testing"
+	"time"
+
+	"gorm.io/driver/sqlite"
+	"gorm.io/gorm"
+	"gorm.io/gorm/logger"
+)
+
+// setupTestDB opens an in-memory SQLite database named after the test, sets
+// db.DBCon and migrates the models of the package.
+func setupTestDB(t *testing.T) {
+	t.Helper()
+	database, err := gorm.Open(sqlite.Open(fmt.Sprintf("file:%s?mode=memory&cache=shared", t.Name())),
+		&gorm.Config{Logger: logger.Default.LogMode(logger.Silent)})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := database.AutoMigrate(&User{}, &Group{}, &Workout{}, &Event{}, &WorkoutDisputePoll{},
+		&AuditEntry{}, &UserGroup{}, &GroupSettings{}); err != nil {
+		t.Fatal(err)
+	}
+	db.DBCon = database
+}
+
+func TestAuditEntryUndo(t *testing.T) {
+	setupTestDB(t)
+	user := User{TelegramUserID: 42, Name: "Dana", NickName: "Dee"}
+	db.DBCon.Create(&user)
+	createdAt := time.Date(2024, 5, 18, 10, 30, 0, 0, time.UTC)
+	workout := Workout{UserID: user.ID}
+	db.DBCon.Create(&workout)
+	db.DBCon.Model(&workout).Update("created_at", createdAt)
+
+	user.Rename("Dan")
+	db.DBCon.Model(&workout).Update("created_at", createdAt.Add(-48*time.Hour))
+	entries := []AuditEntry{
+		{Action: AuditRename, TargetTelegramID: 42, Before: "Dee", After: "Dan"},
+		{Action: AuditPushWorkout, TargetTelegramID: 42, WorkoutID: workout.ID,
+			Before: createdAt.Format(time.RFC3339Nano)},
+	}
+	for _, entry := range entries {
+		RecordAudit(entry)
+	}
+
+	var recorded []AuditEntry
+	db.DBCon.Find(&recorded)
+	for _, entry := range recorded {
+		if err := entry.Undo(nil, 7); err != nil {
+			t.Fatalf("undo %s: %s", entry.Action, err)
+		}
+		if entry.Reversible() {
+			t.Errorf("%s still reversible after undo", entry.Action)
+		}
+	}
+
+	var undos int64
+	db.DBCon.Model(&AuditEntry{}).Where("action = ? AND actor_telegram_id = ?", AuditUndo, 7).Count(&undos)
+	if undos != 2 {
+		t.Errorf("got %d undo entries, want one per undo", undos)
+	}
+
+	user, _ = GetUserById(42)
+	if user.NickName != "Dee" {
+		t.Errorf("nick name %q, want Dee", user.NickName)
+	}
+	db.DBCon.First(&workout, workout.ID)
+	if !workout.CreatedAt.Equal(createdAt) {
+		t.Errorf("workout at %s, want %s", workout.CreatedAt, createdAt)
+	}
+}
+
+func TestAuditEntryUndoDeletedWorkout(t *testing.T) {
+	setupTestDB(t)
+	user := User{TelegramUserID: 42}
+	db.DBCon.Create(&user)
+	workout := Workout{UserID: user.ID}
+	db.DBCon.Create(&workout)
+	db.DBCon.Delete(&workout)
+
+	entry := AuditEntry{Action: AuditDeleteWorkout, TargetTelegramID: 42, WorkoutID: workout.ID}
+	db.DBCon.Create(&entry)
+	if err := entry.Undo(nil, 7); err != nil {
+		t.Fatal(err)
+	}
+	var count int64
+	db.DBCon.Model(&Workout{}).Where("id = ?", workout.ID).Count(&count)
+	if count != 1 {
+		t.Error("deleted workout was not restored")
+	}
+	if err := entry.Undo(nil, 7); err == nil {
+		t.Error("expected a second undo to be refused")
+	}
+}
+
+func TestAuditEntryUndoClaimsOnce(t *testing.T) {
+	setupTestDB(t)
+	db.DBCon.Create(&User{TelegramUserID: 42, NickName: "Dan"})
+	entry := AuditEntry{Action: AuditRename, TargetTelegramID: 42, Before: "Dee", After: "Dan"}
+	db.DBCon.Create(&entry)
+	// Both admins loaded the entry before either undid it
+	first, second := entry, entry
+	if err := first.Undo(nil, 7); err != nil {
+		t.Fatal(err)
+	}
+	db.DBCon.Model(&User{}).Where("telegram_user_id = ?", 42).Update("nick_name", "Danny")
+	if err := second.Undo(nil, 8); err == nil {
+		t.Error("expected the second undo to be refused")
+	}
+	if user, _ := GetUserById(42); user.NickName != "Danny" {
+		t.Errorf("nick name %q, want the second undo to change nothing", user.NickName)
+	}
+	var undone AuditEntry
+	db.DBCon.First(&undone, entry.ID)
+	if undone.UndoneBy != 7 {
+		t.Errorf("undone by %d, want the first admin", undone.UndoneBy)
+	}
+}
+
+func TestAuditEntryUndoReassignedWorkout(t *testing.T) {
+	setupTestDB(t)
+	user := User{TelegramUserID: 42}
+	db.DBCon.Create(&user)
+	from, to := Group{ChatID: -100, Title: "Lifters"}, Group{ChatID: -200, Title: "Runners"}
+	db.DBCon.Create(&from)
+	db.DBCon.Create(&to)
+	workout := Workout{UserID: user.ID, GroupID: from.ID}
+	db.DBCon.Create(&workout)
+	if err := ReassignWorkout(workout.ID, to.ID); err != nil {
+		t.Fatal(err)
+	}
+
+	entry := AuditEntry{Action: AuditReassignWorkout, TargetTelegramID: 42, GroupChatID: from.ChatID,
+		WorkoutID: workout.ID, Before: from.Title, After: to.Title}
+	db.DBCon.Create(&entry)
+	if err := entry.Undo(nil, 7); err != nil {
+		t.Fatal(err)
+	}
+	workout, _ = GetAnyWorkout(workout.ID)
+	if workout.GroupID != from.ID {
+		t.Errorf("workout is in group %d, want %d", workout.GroupID, from.ID)
+	}
+}
+
+func TestForgiveBan(t *testing.T) {
+	setupTestDB(t)
+	user := User{TelegramUserID: 42}
+	db.DBCon.Create(&user)
+	group := Group{ChatID: -100, Title: "Lifters"}
+	db.DBCon.Create(&group)
+	db.DBCon.Create(&Workout{UserID: user.ID, GroupID: group.ID})
+	db.DBCon.Model(&Workout{}).Where("user_id = ?", user.ID).Update("created_at", time.Now().AddDate(0, 0, -6))
+	olderBan := Event{UserID: user.ID, Event: BanEventType}
+	db.DBCon.Create(&olderBan)
+	db.DBCon.Model(&olderBan).Update("created_at", time.Now().AddDate(0, -2, 0))
+	user.RegisterBanEvent()
+	entry := AuditEntry{Action: AuditBan, TargetTelegramID: 42, GroupChatID: group.ChatID}
+	db.DBCon.Create(&entry)
+
+	if err := user.forgiveBan(entry); err != nil {
+		t.Fatal(err)
+	}
+	if banDate, err := user.GetLastBanDate(); err != nil || !banDate.Equal(olderBan.CreatedAt) {
+		t.Errorf("last ban on %s, %v, want only the older ban left", banDate, err)
+	}
+	lastWorkout, err := user.GetLastXWorkout(1, group.ChatID)
+	if err != nil || lastWorkout.IsOlderThan(1) {
+		t.Errorf("got last workout %+v, %v, want a fresh window", lastWorkout, err)
+	}
+}
+
+func TestAuditEntryReversible(t *testing.T) {
+	tests := []struct {
+		action AuditAction
+		want   bool
+	}{
+		{AuditBan, true},
+		{AuditRename, true},
+		{AuditPushWorkout, true},
+		{AuditDeleteWorkout, true},
+		{AuditImmunity, true},
+		{AuditRemoveUser, false},
+		{AuditAddAdmin, false},
+		{AuditCloseGroup, false},
+		{AuditApproveManual, true},
+		{AuditRejectManual, false},
+		{AuditRestoreWorkout, true},
+		{AuditMoveWorkout, true},
+		{AuditReassignWorkout, true},
+		{AuditApproveWorkout, true},
+		{AuditRejectWorkout, true},
+		{AuditUndo, false},
+	}
+	for _, tt := range tests {
+		if got := (AuditEntry{Action: tt.action}).Reversible(); got != tt.want {
+			t.Errorf("%s: got %v, want %v", tt.action, got, tt.want)
+		}
+	}
+}
@@ -0,0 +1,94 @@
+package users
+
+import (
+	"fatbot/db"
+	"fmt"
+
+	"github.com/spf13/viper"
+	"gorm.io/gorm"
+)
+
+// What happens to the workouts of a user who deletes their account, set by
+// privacy.deleted_workouts.
+const (
+	DeletedWorkoutsAnonymize = "anonymize"
+	DeletedWorkoutsDelete    = "delete"
+)
+
+// DeletedWorkoutsPolicy returns the configured policy, anonymizing by default
+// so group history and averages stay intact.
+func DeletedWorkoutsPolicy() string {
+	if viper.GetString("privacy.deleted_workouts") == DeletedWorkoutsDelete {
+		return DeletedWorkoutsDelete
+	}
+	return DeletedWorkoutsAnonymize
+}
+
+// DeleteAccount removes the user for /delete_me: it deregisters the
+// integrations, drops the group memberships, events and audit entries about
+// the user, handles the workouts according to policy and deletes the user for
+// good, all in one transaction.
+func (user *User) DeleteAccount(policy string) error {
+	return db.DBCon.Transaction(func(tx *gorm.DB) error {
+		if err := user.deregisterWhoop(tx); err != nil {
+			return fmt.Errorf("deregister whoop: %w", err)
+		}
+		if err := user.deregisterGarmin(tx); err != nil {
+			return fmt.Errorf("deregister garmin: %w", err)
+		}
+		if err := user.deregisterStrava(tx); err != nil {
+			return fmt.Errorf("deregister strava: %w", err)
+		}
+		if err := tx.Model(user).Update("instagram_handle", "").Error; err != nil {
+			return err
+		}
+		if err := tx.Model(user).Association("Groups").Clear(); err != nil {
+			return err
+		}
+		if err := tx.Model(user).Association("GroupsAdmin").Clear(); err != nil {
+			return err
+		}
+		if err := tx.Unscoped().Where("user_id = ?", user.ID).Delete(&Event{}).Error; err != nil {
+			return err
+		}
+		if err := tx.Unscoped().Where("user_id = ?", user.ID).Delete(&WorkoutDisputePoll{}).Error; err != nil {
+			return err
+		}
+		// Entries about the user hold names and nicknames. The ones where the
+		// user was the admin stay for the group, without the user's ID.
+		if err := tx.Unscoped().Where("target_telegram_id = ?", user.TelegramUserID).Delete(&AuditEntry{}).Error; err != nil {
+			return err
+		}
+		if err := tx.Model(&AuditEntry{}).Where("actor_telegram_id = ?", user.TelegramUserID).
+			Update("actor_telegram_id", DeletedActor).Error; err != nil {
+			return err
+		}
+		if err := tx.Model(&AuditEntry{}).Where("undone_by = ?", user.TelegramUserID).
+			Update("undone_by", DeletedActor).Error; err != nil {
+			return err
+		}
+		workouts := tx.Unscoped().Model(&Workout{}).Where("user_id = ?", user.ID)
+		if policy == DeletedWorkoutsDelete {
+			if err := workouts.Delete(&Workout{}).Error; err != nil {
+				return err
+			}
+		} else {
+			// Keep the dates for group history but nothing that leads back to the user
+			if err := workouts.Updates(map[string]interface{}{
+				"user_id":           0,
+				"photo_message_id":  0,
+				"photo_file_id":     "",
+				"whoop_id":          "",
+				"garmin_id":         "",
+				"strava_id":         "",
+				"notify_message_id": 0,
+				"notify_chat_id":    0,
+				"device":            "",
+				"note":              "",
+			}).Error; err != nil {
+				return err
+			}
+		}
+		return tx.Unscoped().Delete(user).Error
+	})
+}
@@ -0,0 +1,56 @@
+package users
+
+import (
+	"fatbot/db"
+	"fatbot/imagehash"
+	"time"
+
+	"github.com/spf13/viper"
+)
+
+// What a group does with a workout photo that was posted before.
+const (
+	DuplicatesFlag   = "flag"   // Only mark the workout
+	DuplicatesWarn   = "warn"   // Tell the member it looks recycled
+	DuplicatesAdmins = "admins" // Show the group admins both photos
+	DuplicatesRefuse = "refuse" // Don't count the workout
+)
+
+// FindDuplicatePhoto looks for an earlier workout photo that is a near
+// duplicate of the hash, among the user's workouts in every group and
+// everyone's workouts in the group. The closest one wins.
+func FindDuplicatePhoto(user User, group Group, hash imagehash.Hash) (duplicate Workout, found bool, err error) {
+	since := time.Now().AddDate(0, 0, -viper.GetInt("workout.duplicates.days"))
+	maxDistance := viper.GetInt("workout.duplicates.distance")
+	var candidates []Workout
+	err = db.DBCon.
+		Where("photo_hash <> ? AND created_at > ?", "", since).
+		Where("user_id = ? OR group_id = ?", user.ID, group.ID).
+		Find(&candidates).Error
+	if err != nil {
+		return duplicate, false, err
+	}
+	closest := maxDistance + 1
+	for _, candidate := range candidates {
+		candidateHash, err := imagehash.Parse(candidate.PhotoHash)
+		if err != nil {
+			continue
+		}
+		if distance := imagehash.Distance(hash, candidateHash); distance < closest {
+			closest = distance
+			duplicate, found = candidate, true
+		}
+	}
+	return duplicate, found, nil
+}
+
+// SavePhotoHash stores the hash of the workout photo and the workout it
+// duplicates, if any.
+func (workout *Workout) SavePhotoHash(hash imagehash.Hash, duplicateOf uint) error {
+	workout.PhotoHash = hash.String()
+	workout.DuplicateOfID = duplicateOf
+	return db.DBCon.Model(workout).Updates(map[string]any{
+		"photo_hash":      workout.PhotoHash,
+		"duplicate_of_id": workout.DuplicateOfID,
+	}).Error
+}
@@ -0,0 +1,46 @@
+package users
+
+import (
+	"fatbot/db"
+	"fatbot/imagehash"
+	"testing"
+	"time"
+
+	"github.com/spf13/viper"
+)
+
+func TestFindDuplicatePhoto(t *testing.T) {
+	user, group := openAccountTestDB(t)
+	viper.Set("workout.duplicates.days", 365)
+	viper.Set("workout.duplicates.distance", 6)
+	defer viper.Set("workout.duplicates.days", nil)
+	defer viper.Set("workout.duplicates.distance", nil)
+
+	database := db.DBCon
+	var hash imagehash.Hash = 0xf0f0f0f0f0f0f0f0
+	far := Workout{UserID: user.ID, GroupID: group.ID}
+	database.Create(&far)
+	if err := far.SavePhotoHash(hash^0xffff, 0); err != nil {
+		t.Fatal(err)
+	}
+	near := Workout{UserID: user.ID, GroupID: group.ID}
+	database.Create(&near)
+	if err := near.SavePhotoHash(hash^0b101, 0); err != nil {
+		t.Fatal(err)
+	}
+	stale := Workout{UserID: user.ID, GroupID: group.ID}
+	database.Create(&stale)
+	stale.SavePhotoHash(hash, 0)
+	database.Model(&stale).Update("created_at", time.Now().AddDate(-2, 0, 0))
+
+	duplicate, found, err := FindDuplicatePhoto(user, group, hash)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !found || duplicate.ID != near.ID {
+		t.Errorf("got workout %d (found %v), want the close and recent %d", duplicate.ID, found, near.ID)
+	}
+	if _, found, _ := FindDuplicatePhoto(user, group, ^hash); found {
+		t.Error("a different photo was taken for a duplicate")
+	}
+}
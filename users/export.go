@@ -0,0 +1,273 @@
+package users
+
+import (
+	"archive/zip"
+	"encoding/csv"
+	"encoding/json"
+	"fatbot/db"
+	"fmt"
+	"io"
+	"strconv"
+	"time"
+)
+
+// UserExport is everything stored about a user, as sent by /export.
+// Provider tokens are left out, they are credentials and not history.
+type UserExport struct {
+	ExportedAt time.Time        `json:"exported_at"`
+	Profile    ExportProfile    `json:"profile"`
+	Groups     []ExportGroup    `json:"groups"`
+	Workouts   []ExportWorkout  `json:"workouts"`
+	Events     []ExportEvent    `json:"events"`
+	Ranks      []ExportRankStep `json:"rank_history"`
+}
+
+type ExportProfile struct {
+	TelegramUserID  int64     `json:"telegram_user_id"`
+	Username        string    `json:"username"`
+	Name            string    `json:"name"`
+	NickName        string    `json:"nickname"`
+	Active          bool      `json:"active"`
+	OnProbation     bool      `json:"on_probation"`
+	Immuned         bool      `json:"immuned"`
+	InstagramHandle string    `json:"instagram_handle"`
+	WhoopUserID     int64     `json:"whoop_user_id,omitempty"`
+	GarminUserID    string    `json:"garmin_user_id,omitempty"`
+	StravaAthleteID string    `json:"strava_athlete_id,omitempty"`
+	CreatedAt       time.Time `json:"created_at"`
+}
+
+type ExportGroup struct {
+	ChatID     int64     `json:"chat_id"`
+	Title      string    `json:"title"`
+	JoinedAt   time.Time `json:"joined_at"`
+	Admin      bool      `json:"admin"`
+	WeeklyGoal int       `json:"weekly_goal"`
+}
+
+type ExportWorkout struct {
+	ID              uint       `json:"id"`
+	GroupChatID     int64      `json:"group_chat_id"`
+	GroupTitle      string     `json:"group_title"`
+	CreatedAt       time.Time  `json:"created_at"`
+	UpdatedAt       time.Time  `json:"updated_at"`
+	Source          string     `json:"source"`
+	Flagged         bool       `json:"flagged"`
+	Streak          int        `json:"streak"`
+	WhoopID         string     `json:"whoop_id,omitempty"`
+	GarminID        string     `json:"garmin_id,omitempty"`
+	StravaID        string     `json:"strava_id,omitempty"`
+	HasPhoto        bool       `json:"has_photo"`
+	Sport           string     `json:"sport,omitempty"`
+	Category        string     `json:"category,omitempty"`
+	StartedAt       *time.Time `json:"started_at,omitempty"`
+	DurationMinutes float64    `json:"duration_minutes,omitempty"`
+	DistanceMeters  float64    `json:"distance_meters,omitempty"`
+	Calories        float64    `json:"calories,omitempty"`
+	AvgHeartRate    int        `json:"avg_heart_rate,omitempty"`
+	MaxHeartRate    int        `json:"max_heart_rate,omitempty"`
+	Strain          float64    `json:"strain,omitempty"`
+	Device          string     `json:"device,omitempty"`
+	Manual          bool       `json:"manual"`
+	Note            string     `json:"note,omitempty"`
+}
+
+type ExportEvent struct {
+	Event       string    `json:"event"`
+	GroupChatID int64     `json:"group_chat_id,omitempty"`
+	CreatedAt   time.Time `json:"created_at"`
+}
+
+// ExportRankStep is a rank the user held. Only the current rank and when it
+// was reached are stored, earlier ranks aren't kept.
+type ExportRankStep struct {
+	Rank  int       `json:"rank"`
+	Name  string    `json:"name"`
+	Since time.Time `json:"since"`
+}
+
+// Export collects the user's data for /export.
+func (user *User) Export() (export UserExport, err error) {
+	database := db.DBCon
+	if err = database.Preload("Groups").Preload("GroupsAdmin").
+		Where("telegram_user_id = ?", user.TelegramUserID).First(user).Error; err != nil {
+		return export, err
+	}
+	export.ExportedAt = time.Now()
+	export.Profile = ExportProfile{
+		TelegramUserID:  user.TelegramUserID,
+		Username:        user.Username,
+		Name:            user.Name,
+		NickName:        user.NickName,
+		Active:          user.Active,
+		OnProbation:     user.OnProbation,
+		Immuned:         user.Immuned,
+		InstagramHandle: user.InstagramHandle,
+		WhoopUserID:     user.WhoopUserID,
+		GarminUserID:    user.GarminUserID,
+		StravaAthleteID: user.StravaAthleteID,
+		CreatedAt:       user.CreatedAt,
+	}
+
+	groupsById := map[uint]*Group{}
+	admin := map[uint]bool{}
+	for _, group := range user.GroupsAdmin {
+		admin[group.ID] = true
+	}
+	for _, group := range user.Groups {
+		groupsById[group.ID] = group
+		joinedAt, _ := GetUserGroupJoinDate(user.ID, group.ID)
+		weeklyGoal, _ := user.GetWeeklyGoal(*group)
+		export.Groups = append(export.Groups, ExportGroup{
+			ChatID:     group.ChatID,
+			Title:      group.Title,
+			JoinedAt:   joinedAt,
+			Admin:      admin[group.ID],
+			WeeklyGoal: weeklyGoal,
+		})
+	}
+
+	var workouts []Workout
+	if err = database.Where("user_id = ?", user.ID).Order("created_at").Find(&workouts).Error; err != nil {
+		return export, err
+	}
+	for _, workout := range workouts {
+		group, ok := groupsById[workout.GroupID]
+		if !ok {
+			// Workouts can outlive the membership, e.g. after a ban
+			group = &Group{}
+			database.First(group, workout.GroupID)
+			groupsById[workout.GroupID] = group
+		}
+		export.Workouts = append(export.Workouts, ExportWorkout{
+			ID:              workout.ID,
+			GroupChatID:     group.ChatID,
+			GroupTitle:      group.Title,
+			CreatedAt:       workout.CreatedAt,
+			UpdatedAt:       workout.UpdatedAt,
+			Source:          workout.Source(),
+			Flagged:         workout.Flagged,
+			Streak:          workout.Streak,
+			WhoopID:         workout.WhoopID,
+			GarminID:        workout.GarminID,
+			StravaID:        workout.StravaID,
+			HasPhoto:        workout.PhotoFileID != "",
+			Sport:           workout.Sport,
+			Category:        workout.Category,
+			StartedAt:       workout.StartedAt,
+			DurationMinutes: workout.DurationMinutes,
+			DistanceMeters:  workout.DistanceMeters,
+			Calories:        workout.Calories,
+			AvgHeartRate:    workout.AvgHeartRate,
+			MaxHeartRate:    workout.MaxHeartRate,
+			Strain:          workout.Strain,
+			Device:          workout.Device,
+			Manual:          workout.Manual,
+			Note:            workout.Note,
+		})
+	}
+
+	for _, event := range user.GetEvents() {
+		export.Events = append(export.Events, ExportEvent{
+			Event:       string(event.Event),
+			GroupChatID: event.GroupID,
+			CreatedAt:   event.CreatedAt,
+		})
+	}
+
+	if user.RankUpdatedAt != nil {
+		export.Ranks = append(export.Ranks, ExportRankStep{
+			Rank:  user.Rank,
+			Name:  GetRanks()[user.Rank].Name,
+			Since: *user.RankUpdatedAt,
+		})
+	}
+	return export, nil
+}
+
+// WriteZip writes the export as export.json plus a CSV file per section.
+func (export UserExport) WriteZip(w io.Writer) error {
+	archive := zip.NewWriter(w)
+	file, err := archive.Create("export.json")
+	if err != nil {
+		return err
+	}
+	encoder := json.NewEncoder(file)
+	encoder.SetIndent("", "  ")
+	if err := encoder.Encode(export); err != nil {
+		return err
+	}
+
+	profile := export.Profile
+	tables := []struct {
+		name   string
+		header []string
+		rows   [][]string
+	}{
+		{"profile.csv", []string{"telegram_user_id", "username", "name", "nickname", "active", "on_probation", "immuned", "instagram_handle", "whoop_user_id", "garmin_user_id", "strava_athlete_id", "created_at"},
+			[][]string{{formatInt(profile.TelegramUserID), profile.Username, profile.Name, profile.NickName,
+				strconv.FormatBool(profile.Active), strconv.FormatBool(profile.OnProbation), strconv.FormatBool(profile.Immuned),
+				profile.InstagramHandle, formatInt(profile.WhoopUserID), profile.GarminUserID, profile.StravaAthleteID, formatTime(profile.CreatedAt)}}},
+		{"groups.csv", []string{"chat_id", "title", "joined_at", "admin", "weekly_goal"}, nil},
+		{"workouts.csv", []string{"id", "group_chat_id", "group_title", "created_at", "updated_at", "source", "flagged", "streak", "whoop_id", "garmin_id", "strava_id", "has_photo",
+			"sport", "category", "started_at", "duration_minutes", "distance_meters", "calories", "avg_heart_rate", "max_heart_rate", "strain", "device", "manual", "note"}, nil},
+		{"events.csv", []string{"event", "group_chat_id", "created_at"}, nil},
+		{"rank_history.csv", []string{"rank", "name", "since"}, nil},
+	}
+	for _, group := range export.Groups {
+		tables[1].rows = append(tables[1].rows, []string{formatInt(group.ChatID), group.Title, formatTime(group.JoinedAt), strconv.FormatBool(group.Admin), strconv.Itoa(group.WeeklyGoal)})
+	}
+	for _, workout := range export.Workouts {
+		tables[2].rows = append(tables[2].rows, []string{fmt.Sprint(workout.ID), formatInt(workout.GroupChatID), workout.GroupTitle,
+			formatTime(workout.CreatedAt), formatTime(workout.UpdatedAt), workout.Source, strconv.FormatBool(workout.Flagged),
+			strconv.Itoa(workout.Streak), workout.WhoopID, workout.GarminID, workout.StravaID, strconv.FormatBool(workout.HasPhoto),
+			workout.Sport, workout.Category, formatTimePtr(workout.StartedAt), formatFloat(workout.DurationMinutes), formatFloat(workout.DistanceMeters),
+			formatFloat(workout.Calories), strconv.Itoa(workout.AvgHeartRate), strconv.Itoa(workout.MaxHeartRate), formatFloat(workout.Strain),
+			workout.Device, strconv.FormatBool(workout.Manual), workout.Note})
+	}
+	for _, event := range export.Events {
+		tables[3].rows = append(tables[3].rows, []string{event.Event, formatInt(event.GroupChatID), formatTime(event.CreatedAt)})
+	}
+	for _, rank := range export.Ranks {
+		tables[4].rows = append(tables[4].rows, []string{strconv.Itoa(rank.Rank), rank.Name, formatTime(rank.Since)})
+	}
+
+	for _, table := range tables {
+		file, err := archive.Create(table.name)
+		if err != nil {
+			return err
+		}
+		writer := csv.NewWriter(file)
+		writer.Write(table.header)
+		writer.WriteAll(table.rows)
+		if err := writer.Error(); err != nil {
+			return err
+		}
+	}
+	return archive.Close()
+}
+
+func formatInt(value int64) string {
+	return strconv.FormatInt(value, 10)
+}
+
+func formatTime(value time.Time) string {
+	if value.IsZero() {
+		return ""
+	}
+	return value.Format(time.RFC3339)
+}
+
+func formatTimePtr(value *time.Time) string {
+	if value == nil {
+		return ""
+	}
+	return formatTime(*value)
+}
+
+func formatFloat(value float64) string {
+	if value == 0 {
+		return ""
+	}
+	return strconv.FormatFloat(value, 'f', 1, 64)
+}
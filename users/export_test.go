@@ -0,0 +1,124 @@
+package users
+
+import (
+	"archive/zip"
+	"bytes"
+	"encoding/csv"
+	"fatbot/db"
+	"testing"
+	"time"
+)
+
+// openAccountTestDB sets up a group with a member who has workouts, a
+// Strava connection and a ban.
+func openAccountTestDB(t *testing.T) (user User, group Group) {
+	setupTestDB(t)
+	database := db.DBCon
+	group = Group{ChatID: -100, Title: "Lifters"}
+	database.Create(&group)
+	rankedAt := time.Date(2024, 5, 18, 10, 0, 0, 0, time.UTC)
+	user = User{TelegramUserID: 42, Name: "Dana", Rank: 2, RankUpdatedAt: &rankedAt,
+		StravaAccessToken: "secret", StravaAthleteID: "77", InstagramHandle: "dana",
+		Groups: []*Group{&group}, GroupsAdmin: []*Group{&group}}
+	database.Create(&user)
+	database.Create(&Workout{UserID: user.ID, GroupID: group.ID, StravaID: "s1", PhotoFileID: "photo", Note: "knee hurt"})
+	database.Create(&Workout{UserID: user.ID, GroupID: group.ID})
+	database.Create(&Event{UserID: user.ID, Event: BanEventType})
+	return user, group
+}
+
+func TestExportWriteZip(t *testing.T) {
+	user, _ := openAccountTestDB(t)
+	export, err := user.Export()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(export.Groups) != 1 || !export.Groups[0].Admin || len(export.Workouts) != 2 ||
+		len(export.Events) != 1 || len(export.Ranks) != 1 {
+		t.Fatalf("incomplete export: %+v", export)
+	}
+	if export.Workouts[0].StravaID != "s1" || export.Workouts[0].GroupTitle != "Lifters" || export.Workouts[0].Note != "knee hurt" {
+		t.Errorf("got workout %+v", export.Workouts[0])
+	}
+
+	var buffer bytes.Buffer
+	if err := export.WriteZip(&buffer); err != nil {
+		t.Fatal(err)
+	}
+	archive, err := zip.NewReader(bytes.NewReader(buffer.Bytes()), int64(buffer.Len()))
+	if err != nil {
+		t.Fatal(err)
+	}
+	rows := map[string]int{}
+	for _, file := range archive.File {
+		reader, err := file.Open()
+		if err != nil {
+			t.Fatal(err)
+		}
+		if file.Name == "export.json" {
+			var contents bytes.Buffer
+			contents.ReadFrom(reader)
+			if bytes.Contains(contents.Bytes(), []byte("secret")) {
+				t.Error("export contains a provider token")
+			}
+			continue
+		}
+		records, err := csv.NewReader(reader).ReadAll()
+		if err != nil {
+			t.Fatalf("%s: %v", file.Name, err)
+		}
+		rows[file.Name] = len(records) - 1
+	}
+	want := map[string]int{"profile.csv": 1, "groups.csv": 1, "workouts.csv": 2, "events.csv": 1, "rank_history.csv": 1}
+	for name, count := range want {
+		if rows[name] != count {
+			t.Errorf("%s has %d rows, want %d", name, rows[name], count)
+		}
+	}
+}
+
+func TestDeleteAccount(t *testing.T) {
+	for _, policy := range []string{DeletedWorkoutsAnonymize, DeletedWorkoutsDelete} {
+		t.Run(policy, func(t *testing.T) {
+			user, group := openAccountTestDB(t)
+			RecordAudit(AuditEntry{Action: AuditRename, TargetTelegramID: user.TelegramUserID, Before: "Dee", After: "Dan"})
+			RecordAudit(AuditEntry{Action: AuditBan, ActorTelegramID: user.TelegramUserID, TargetTelegramID: 7})
+			if err := user.DeleteAccount(policy); err != nil {
+				t.Fatal(err)
+			}
+			var count int64
+			db.DBCon.Unscoped().Model(&User{}).Count(&count)
+			if count != 0 {
+				t.Error("user wasn't deleted")
+			}
+			db.DBCon.Unscoped().Model(&Event{}).Count(&count)
+			if count != 0 {
+				t.Error("events weren't deleted")
+			}
+			var entries []AuditEntry
+			db.DBCon.Unscoped().Find(&entries)
+			if len(entries) != 1 || entries[0].TargetTelegramID != 7 || entries[0].ActorTelegramID != DeletedActor {
+				t.Errorf("got audit entries %+v, want only the one by the user without their ID", entries)
+			}
+			if members := db.DBCon.Model(&group).Association("Users").Count(); members != 0 {
+				t.Errorf("group still has %d members", members)
+			}
+			var workouts []Workout
+			db.DBCon.Unscoped().Find(&workouts)
+			if policy == DeletedWorkoutsDelete {
+				if len(workouts) != 0 {
+					t.Errorf("%d workouts left", len(workouts))
+				}
+				return
+			}
+			if len(workouts) != 2 {
+				t.Fatalf("got %d workouts, want them kept", len(workouts))
+			}
+			for _, workout := range workouts {
+				if workout.UserID != 0 || workout.StravaID != "" || workout.PhotoFileID != "" || workout.Note != "" {
+					t.Errorf("workout not anonymized: %+v", workout)
+				}
+			}
+		})
+	}
+}
@@ -4,6 +4,8 @@ import (
 	"fatbot/db"
 	"fatbot/garmin"
 	"time"
+
+	"gorm.io/gorm"
 )
 
 func (user *User) UpdateGarminToken(token *garmin.TokenResponse) error {
@@ -50,10 +52,13 @@ func GarminWorkoutExists(garminID string) bool {
 }
 
 func (user *User) DeregisterGarmin() error {
-	db := db.DBCon
+	return user.deregisterGarmin(db.DBCon)
+}
+
+func (user *User) deregisterGarmin(tx *gorm.DB) error {
 	user.GarminAccessToken = ""
 	user.GarminRefreshToken = ""
 	user.GarminTokenExpiry = time.Time{}
 	user.GarminUserID = ""
-	return db.Save(&user).Error
+	return tx.Save(&user).Error
 }
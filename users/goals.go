@@ -0,0 +1,57 @@
+package users
+
+import (
+	"fatbot/db"
+	"fmt"
+	"time"
+)
+
+// MaxWeeklyGoal is the most workouts a week a member can commit to.
+const MaxWeeklyGoal = 14
+
+// SetWeeklyGoal sets how many workouts a week the user commits to in the
+// group. 0 clears the goal.
+func (user *User) SetWeeklyGoal(group Group, goal int) error {
+	if goal < 0 || goal > MaxWeeklyGoal {
+		return fmt.Errorf("weekly goal %d is not between 0 and %d", goal, MaxWeeklyGoal)
+	}
+	result := db.DBCon.Model(&UserGroup{}).
+		Where("user_id = ? AND group_id = ?", user.ID, group.ID).
+		Update("weekly_goal", goal)
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return fmt.Errorf("%s is not in %s", user.GetName(), group.Title)
+	}
+	return nil
+}
+
+// GetWeeklyGoal returns the user's weekly goal in the group, 0 for none.
+func (user *User) GetWeeklyGoal(group Group) (goal int, err error) {
+	err = db.DBCon.Model(&UserGroup{}).
+		Where("user_id = ? AND group_id = ?", user.ID, group.ID).
+		Limit(1).
+		Pluck("weekly_goal", &goal).Error
+	return
+}
+
+// GetWeeklyGoals returns the goals the group's members set, by user ID.
+func (group *Group) GetWeeklyGoals() (map[uint]int, error) {
+	var memberships []UserGroup
+	if err := db.DBCon.Where("group_id = ? AND weekly_goal > 0", group.ID).Find(&memberships).Error; err != nil {
+		return nil, err
+	}
+	goals := make(map[uint]int, len(memberships))
+	for _, membership := range memberships {
+		goals[membership.UserID] = membership.WeeklyGoal
+	}
+	return goals, nil
+}
+
+// GoalPace is how many workouts a member should have by now to reach the
+// weekly goal at an even pace through the cycle.
+func (rules GroupRules) GoalPace(goal int, now time.Time) int {
+	elapsed := now.Sub(rules.CycleStart(now))
+	return int(float64(goal) * elapsed.Hours() / (7 * 24))
+}
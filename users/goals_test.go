@@ -0,0 +1,62 @@
+package users
+
+import (
+	"testing"
+	"time"
+)
+
+func TestWeeklyGoals(t *testing.T) {
+	setDefaultRulesConfig()
+	user, group := openAccountTestDB(t)
+
+	if goal, err := user.GetWeeklyGoal(group); err != nil || goal != 0 {
+		t.Fatalf("got %d, %v before /goal, want none", goal, err)
+	}
+	if err := user.SetWeeklyGoal(group, MaxWeeklyGoal+1); err == nil {
+		t.Error("set a goal over the max")
+	}
+	if err := user.SetWeeklyGoal(Group{Title: "Strangers"}, 3); err == nil {
+		t.Error("set a goal in a group the user isn't in")
+	}
+	if err := user.SetWeeklyGoal(group, 4); err != nil {
+		t.Fatal(err)
+	}
+	if goal, _ := user.GetWeeklyGoal(group); goal != 4 {
+		t.Errorf("got goal %d, want 4", goal)
+	}
+	if goals, err := group.GetWeeklyGoals(); err != nil || len(goals) != 1 || goals[user.ID] != 4 {
+		t.Errorf("got group goals %v, %v", goals, err)
+	}
+
+	if err := user.SetWeeklyGoal(group, 0); err != nil {
+		t.Fatal(err)
+	}
+	if goals, _ := group.GetWeeklyGoals(); len(goals) != 0 {
+		t.Errorf("got group goals %v after clearing, want none", goals)
+	}
+}
+
+func TestGoalPace(t *testing.T) {
+	setDefaultRulesConfig()
+	rules := GroupSettings{}.Rules()
+	location := rules.Location()
+	// The cycle starts on Saturday at 20:00
+	standings := time.Date(2024, 5, 15, 20, 0, 0, 0, location)
+
+	tests := []struct {
+		goal int
+		now  time.Time
+		want int
+	}{
+		{goal: 4, now: standings, want: 2},
+		{goal: 7, now: standings, want: 4},
+		{goal: 3, now: standings, want: 1},
+		{goal: 4, now: time.Date(2024, 5, 11, 21, 0, 0, 0, location), want: 0},
+		{goal: 4, now: time.Date(2024, 5, 18, 19, 0, 0, 0, location), want: 3},
+	}
+	for _, tt := range tests {
+		if got := rules.GoalPace(tt.goal, tt.now); got != tt.want {
+			t.Errorf("GoalPace(%d, %s) = %d, want %d", tt.goal, tt.now, got, tt.want)
+		}
+	}
+}
@@ -0,0 +1,494 @@
+package users
+
+import (
+	"fatbot/db"
+	"fatbot/i18n"
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/spf13/viper"
+	"gorm.io/gorm"
+)
+
+// GroupSettings holds the per-group overrides of the accountability rules
+// and the weekly report schedule.
+// A nil field means the group follows the global default from config.yaml.
+type GroupSettings struct {
+	gorm.Model
+	GroupID              uint `gorm:"uniqueIndex"`
+	UploadWindowDays     *int
+	WarningLeadDays      *int
+	WarningHour          *int
+	NewUserGraceDays     *int
+	WorkoutPeriodMinutes *int
+	RejoinWaitHours      *int
+	Timezone             *string
+	ReportDay            *string
+	ReportHour           *int
+	Language             *string
+	ManualLogs           *string
+	ManualWeeklyCap      *int
+	DuplicatePhotos      *string
+	Plausibility         *int
+	StreakFreezeDays     *int
+}
+
+// GroupRules is the effective set of accountability rules for a group,
+// with config.yaml defaults filled in for anything the group doesn't override.
+type GroupRules struct {
+	UploadWindowDays     int
+	WarningLeadDays      int
+	WarningHour          int
+	NewUserGraceDays     int
+	WorkoutPeriodMinutes int
+	RejoinWaitHours      int
+	Timezone             string
+	ReportDay            time.Weekday
+	ReportHour           int
+	Language             i18n.Lang
+	ManualLogs           string
+	ManualWeeklyCap      int
+	DuplicatePhotos      string
+	Plausibility         int
+	StreakFreezeDays     int
+}
+
+type GroupSettingKey string
+
+const (
+	UploadWindowDaysSetting     GroupSettingKey = "uploadwindow"
+	WarningLeadDaysSetting      GroupSettingKey = "warninglead"
+	WarningHourSetting          GroupSettingKey = "warninghour"
+	NewUserGraceDaysSetting     GroupSettingKey = "gracedays"
+	WorkoutPeriodMinutesSetting GroupSettingKey = "workoutperiod"
+	RejoinWaitHoursSetting      GroupSettingKey = "rejoinwait"
+	TimezoneSetting             GroupSettingKey = "timezone"
+	ReportDaySetting            GroupSettingKey = "reportday"
+	ReportHourSetting           GroupSettingKey = "reporthour"
+	LanguageSetting             GroupSettingKey = "language"
+	ManualLogsSetting           GroupSettingKey = "manuallogs"
+	ManualWeeklyCapSetting      GroupSettingKey = "manualcap"
+	DuplicatePhotosSetting      GroupSettingKey = "duplicates"
+	PlausibilitySetting         GroupSettingKey = "plausibility"
+	StreakFreezeDaysSetting     GroupSettingKey = "streakfreeze"
+)
+
+type groupSettingSpec struct {
+	Label string
+	set   func(settings *GroupSettings, input string) error
+	reset func(settings *GroupSettings)
+}
+
+func intSetting(label string, min, max int, field func(settings *GroupSettings) **int) groupSettingSpec {
+	return groupSettingSpec{
+		Label: label,
+		set: func(settings *GroupSettings, input string) error {
+			value, err := strconv.Atoi(input)
+			if err != nil {
+				return fmt.Errorf("%s is not a number", input)
+			}
+			if value < min || value > max {
+				return fmt.Errorf("%s must be between %d and %d", label, min, max)
+			}
+			*field(settings) = &value
+			return nil
+		},
+		reset: func(settings *GroupSettings) { *field(settings) = nil },
+	}
+}
+
+var groupSettingSpecs = map[GroupSettingKey]groupSettingSpec{
+	UploadWindowDaysSetting: intSetting("Upload window (days)", 1, 30,
+		func(s *GroupSettings) **int { return &s.UploadWindowDays }),
+	WarningLeadDaysSetting: intSetting("Warning lead (days)", 0, 29,
+		func(s *GroupSettings) **int { return &s.WarningLeadDays }),
+	WarningHourSetting: intSetting("Warning hour", 0, 23,
+		func(s *GroupSettings) **int { return &s.WarningHour }),
+	NewUserGraceDaysSetting: intSetting("New member grace (days)", 0, 30,
+		func(s *GroupSettings) **int { return &s.NewUserGraceDays }),
+	WorkoutPeriodMinutesSetting: intSetting("Min minutes between workouts", 0, 1440,
+		func(s *GroupSettings) **int { return &s.WorkoutPeriodMinutes }),
+	RejoinWaitHoursSetting: intSetting("Rejoin wait (hours)", 0, 720,
+		func(s *GroupSettings) **int { return &s.RejoinWaitHours }),
+	TimezoneSetting: {
+		Label: "Timezone",
+		set: func(settings *GroupSettings, input string) error {
+			if _, err := time.LoadLocation(input); err != nil || input == "" {
+				return fmt.Errorf("%s is not a valid IANA timezone (e.g. Europe/Rome)", input)
+			}
+			settings.Timezone = &input
+			return nil
+		},
+		reset: func(settings *GroupSettings) { settings.Timezone = nil },
+	},
+	ReportDaySetting: {
+		Label: "Report day",
+		set: func(settings *GroupSettings, input string) error {
+			weekday, err := ParseWeekday(input)
+			if err != nil {
+				return err
+			}
+			day := weekday.String()
+			settings.ReportDay = &day
+			return nil
+		},
+		reset: func(settings *GroupSettings) { settings.ReportDay = nil },
+	},
+	ReportHourSetting: intSetting("Report hour", 0, 23,
+		func(s *GroupSettings) **int { return &s.ReportHour }),
+	LanguageSetting: {
+		Label: "Language",
+		set: func(settings *GroupSettings, input string) error {
+			lang, ok := i18n.Parse(input)
+			if !ok {
+				return fmt.Errorf("%s is not a supported language (%s)", input, supportedLanguages())
+			}
+			code := string(lang)
+			settings.Language = &code
+			return nil
+		},
+		reset: func(settings *GroupSettings) { settings.Language = nil },
+	},
+	ManualLogsSetting: {
+		Label: "Manual logs",
+		set: func(settings *GroupSettings, input string) error {
+			input = strings.ToLower(strings.TrimSpace(input))
+			switch input {
+			case ManualLogsCount, ManualLogsApproval, ManualLogsOff:
+				settings.ManualLogs = &input
+				return nil
+			}
+			return fmt.Errorf("%s is not one of %s, %s or %s", input, ManualLogsCount, ManualLogsApproval, ManualLogsOff)
+		},
+		reset: func(settings *GroupSettings) { settings.ManualLogs = nil },
+	},
+	ManualWeeklyCapSetting: intSetting("Manual logs per week (0 for no limit)", 0, 14,
+		func(s *GroupSettings) **int { return &s.ManualWeeklyCap }),
+	DuplicatePhotosSetting: {
+		Label: "Duplicate photos",
+		set: func(settings *GroupSettings, input string) error {
+			input = strings.ToLower(strings.TrimSpace(input))
+			switch input {
+			case DuplicatesFlag, DuplicatesWarn, DuplicatesAdmins, DuplicatesRefuse:
+				settings.DuplicatePhotos = &input
+				return nil
+			}
+			return fmt.Errorf("%s is not one of %s, %s, %s or %s", input,
+				DuplicatesFlag, DuplicatesWarn, DuplicatesAdmins, DuplicatesRefuse)
+		},
+		reset: func(settings *GroupSettings) { settings.DuplicatePhotos = nil },
+	},
+	PlausibilitySetting: intSetting("Min photo score to skip review (0 to turn off)", 0, 100,
+		func(s *GroupSettings) **int { return &s.Plausibility }),
+	StreakFreezeDaysSetting: intSetting("Days in a row to earn a streak freeze (0 to turn off)", 0, 60,
+		func(s *GroupSettings) **int { return &s.StreakFreezeDays }),
+}
+
+func supportedLanguages() string {
+	var codes []string
+	for _, lang := range i18n.Supported {
+		codes = append(codes, string(lang))
+	}
+	return strings.Join(codes, ", ")
+}
+
+// GroupSettingKeys lists the editable settings in display order.
+var GroupSettingKeys = []GroupSettingKey{
+	UploadWindowDaysSetting,
+	WarningLeadDaysSetting,
+	WarningHourSetting,
+	NewUserGraceDaysSetting,
+	WorkoutPeriodMinutesSetting,
+	RejoinWaitHoursSetting,
+	TimezoneSetting,
+	ReportDaySetting,
+	ReportHourSetting,
+	LanguageSetting,
+	ManualLogsSetting,
+	ManualWeeklyCapSetting,
+	DuplicatePhotosSetting,
+	PlausibilitySetting,
+	StreakFreezeDaysSetting,
+}
+
+func (key GroupSettingKey) Label() string {
+	return groupSettingSpecs[key].Label
+}
+
+// DefaultGroupRules returns the global accountability rules from config.yaml.
+func DefaultGroupRules() GroupRules {
+	return GroupRules{
+		UploadWindowDays:     viper.GetInt("workout.window.days"),
+		WarningLeadDays:      viper.GetInt("workout.warning.days_before"),
+		WarningHour:          viper.GetInt("workout.warning.hour"),
+		NewUserGraceDays:     viper.GetInt("users.new.days"),
+		WorkoutPeriodMinutes: viper.GetInt("workout.period"),
+		RejoinWaitHours:      viper.GetInt("ban.wait.hours"),
+		Timezone:             viper.GetString("timezone"),
+		ReportDay:            defaultReportDay(),
+		ReportHour:           viper.GetInt("report.hour"),
+		Language:             i18n.Default(),
+		ManualLogs:           defaultManualLogs(),
+		ManualWeeklyCap:      viper.GetInt("workout.manual.weekly_cap"),
+		DuplicatePhotos:      defaultDuplicatePhotos(),
+		Plausibility:         viper.GetInt("workout.plausibility.threshold"),
+		StreakFreezeDays:     viper.GetInt("workout.streak.freeze_every_days"),
+	}
+}
+
+func defaultDuplicatePhotos() string {
+	switch policy := viper.GetString("workout.duplicates.policy"); policy {
+	case DuplicatesWarn, DuplicatesAdmins, DuplicatesRefuse:
+		return policy
+	}
+	return DuplicatesFlag
+}
+
+func defaultManualLogs() string {
+	switch policy := viper.GetString("workout.manual.policy"); policy {
+	case ManualLogsApproval, ManualLogsOff:
+		return policy
+	}
+	return ManualLogsCount
+}
+
+func defaultReportDay() time.Weekday {
+	weekday, err := ParseWeekday(viper.GetString("report.day"))
+	if err != nil {
+		return time.Saturday
+	}
+	return weekday
+}
+
+// ParseWeekday parses a full or three letter english weekday name.
+func ParseWeekday(input string) (time.Weekday, error) {
+	input = strings.ToLower(strings.TrimSpace(input))
+	for weekday := time.Sunday; weekday <= time.Saturday; weekday++ {
+		name := strings.ToLower(weekday.String())
+		if input == name || (len(input) == 3 && strings.HasPrefix(name, input)) {
+			return weekday, nil
+		}
+	}
+	return time.Sunday, fmt.Errorf("%s is not a weekday", input)
+}
+
+// Rules merges the group overrides on top of the global defaults.
+func (settings GroupSettings) Rules() GroupRules {
+	rules := DefaultGroupRules()
+	overrides := []struct {
+		value  *int
+		target *int
+	}{
+		{settings.UploadWindowDays, &rules.UploadWindowDays},
+		{settings.WarningLeadDays, &rules.WarningLeadDays},
+		{settings.WarningHour, &rules.WarningHour},
+		{settings.NewUserGraceDays, &rules.NewUserGraceDays},
+		{settings.WorkoutPeriodMinutes, &rules.WorkoutPeriodMinutes},
+		{settings.RejoinWaitHours, &rules.RejoinWaitHours},
+		{settings.ReportHour, &rules.ReportHour},
+		{settings.ManualWeeklyCap, &rules.ManualWeeklyCap},
+		{settings.Plausibility, &rules.Plausibility},
+		{settings.StreakFreezeDays, &rules.StreakFreezeDays},
+	}
+	for _, override := range overrides {
+		if override.value != nil {
+			*override.target = *override.value
+		}
+	}
+	if settings.Timezone != nil {
+		rules.Timezone = *settings.Timezone
+	}
+	if settings.ReportDay != nil {
+		if weekday, err := ParseWeekday(*settings.ReportDay); err == nil {
+			rules.ReportDay = weekday
+		}
+	}
+	if settings.Language != nil {
+		rules.Language = i18n.Or(*settings.Language, rules.Language)
+	}
+	if settings.ManualLogs != nil {
+		rules.ManualLogs = *settings.ManualLogs
+	}
+	if settings.DuplicatePhotos != nil {
+		rules.DuplicatePhotos = *settings.DuplicatePhotos
+	}
+	return rules
+}
+
+// Location returns the group timezone, falling back to the global one
+// and finally to the server local time if neither can be loaded.
+func (rules GroupRules) Location() *time.Location {
+	if rules.Timezone != "" {
+		if location, err := time.LoadLocation(rules.Timezone); err == nil {
+			return location
+		}
+	}
+	if location, err := time.LoadLocation(viper.GetString("timezone")); err == nil {
+		return location
+	}
+	return time.Local
+}
+
+// StandingsDay is the mid-week day the power rankings go out on,
+// four days after the weekly report (Saturday -> Wednesday).
+func (rules GroupRules) StandingsDay() time.Weekday {
+	return (rules.ReportDay + 4) % 7
+}
+
+// CycleStart returns the start of the weekly cycle that contains now,
+// i.e. the most recent report day at the report hour in the group timezone.
+func (rules GroupRules) CycleStart(now time.Time) time.Time {
+	now = now.In(rules.Location())
+	daysSinceReport := (int(now.Weekday()) - int(rules.ReportDay) + 7) % 7
+	start := time.Date(now.Year(), now.Month(), now.Day()-daysSinceReport,
+		rules.ReportHour, 0, 0, 0, now.Location())
+	if start.After(now) {
+		start = start.AddDate(0, 0, -7)
+	}
+	return start
+}
+
+// DaysUntilReport returns the number of days left in the current cycle,
+// counting a partial day as one. Jobs run a little after the hour, so
+// truncating would come up a day short.
+func (rules GroupRules) DaysUntilReport(now time.Time) int {
+	cycleEnd := rules.CycleStart(now).AddDate(0, 0, 7)
+	return int(math.Ceil(cycleEnd.Sub(now).Hours() / 24))
+}
+
+// WarningDay is the number of days since the last workout on which
+// the last-day warning goes out.
+func (rules GroupRules) WarningDay() int {
+	return rules.UploadWindowDays - rules.WarningLeadDays
+}
+
+func (rules GroupRules) String() string {
+	return fmt.Sprintf(`Upload window: %d days
+Warning: %d days before, at %02d:00
+New member grace: %d days
+Min minutes between workouts: %d
+Rejoin wait: %d hours
+Timezone: %s
+Weekly report: %s at %02d:00
+Language: %s
+Manual logs: %s, %s
+Duplicate photos: %s
+Photo review: %s
+Streak freezes: %s`,
+		rules.UploadWindowDays,
+		rules.WarningLeadDays,
+		rules.WarningHour,
+		rules.NewUserGraceDays,
+		rules.WorkoutPeriodMinutes,
+		rules.RejoinWaitHours,
+		rules.Timezone,
+		rules.ReportDay,
+		rules.ReportHour,
+		rules.Language.Name(),
+		rules.ManualLogs,
+		rules.manualCapString(),
+		rules.DuplicatePhotos,
+		rules.plausibilityString(),
+		rules.streakFreezeString(),
+	)
+}
+
+func (rules GroupRules) manualCapString() string {
+	if rules.ManualWeeklyCap == 0 {
+		return "no weekly limit"
+	}
+	return fmt.Sprintf("at most %d a week", rules.ManualWeeklyCap)
+}
+
+func (rules GroupRules) plausibilityString() string {
+	if rules.Plausibility == 0 {
+		return "off"
+	}
+	return fmt.Sprintf("photos scoring below %d", rules.Plausibility)
+}
+
+func (rules GroupRules) streakFreezeString() string {
+	if rules.StreakFreezeDays == 0 {
+		return "off"
+	}
+	return fmt.Sprintf("one every %d days in a row", rules.StreakFreezeDays)
+}
+
+func (group *Group) GetSettings() (settings GroupSettings, err error) {
+	db := db.DBCon
+	err = db.Where("group_id = ?", group.ID).Find(&settings).Error
+	return
+}
+
+// GetRules returns the effective rules for the group, falling back to the
+// global defaults when the group has no settings row.
+func (group *Group) GetRules() GroupRules {
+	if group.ID == 0 {
+		return DefaultGroupRules()
+	}
+	settings, err := group.GetSettings()
+	if err != nil {
+		return DefaultGroupRules()
+	}
+	return settings.Rules()
+}
+
+// GetGroupRules returns the effective rules for the group with the given chat id.
+func GetGroupRules(chatId int64) GroupRules {
+	group, err := GetGroup(chatId)
+	if err != nil {
+		return DefaultGroupRules()
+	}
+	return group.GetRules()
+}
+
+// UpdateGroupSetting overrides a single rule for a group from raw admin
+// input. The input "default" resets the setting back to the global default.
+func UpdateGroupSetting(chatId int64, key GroupSettingKey, input string) error {
+	spec, ok := groupSettingSpecs[key]
+	if !ok {
+		return fmt.Errorf("unknown group setting %s", key)
+	}
+	group, err := GetGroup(chatId)
+	if err != nil {
+		return err
+	}
+	if group.ID == 0 {
+		return fmt.Errorf("could not find group %d", chatId)
+	}
+	settings, err := group.GetSettings()
+	if err != nil {
+		return err
+	}
+	settings.GroupID = group.ID
+	input = strings.TrimSpace(input)
+	if strings.EqualFold(input, "default") {
+		spec.reset(&settings)
+	} else if err := spec.set(&settings, input); err != nil {
+		return err
+	}
+	rules := settings.Rules()
+	if rules.WarningLeadDays >= rules.UploadWindowDays {
+		return fmt.Errorf("warning lead (%d days) must be shorter than the upload window (%d days)",
+			rules.WarningLeadDays, rules.UploadWindowDays)
+	}
+	return db.DBCon.Save(&settings).Error
+}
+
+// RejoinWaitHours returns the longest rejoin wait among the user's groups,
+// since rejoining unbans the user from all of them at once.
+func (user User) RejoinWaitHours() int {
+	if err := user.LoadGroups(); err != nil || len(user.Groups) == 0 {
+		return DefaultGroupRules().RejoinWaitHours
+	}
+	waitHours := 0
+	for _, group := range user.Groups {
+		if hours := group.GetRules().RejoinWaitHours; hours > waitHours {
+			waitHours = hours
+		}
+	}
+	return waitHours
+}
@@ -0,0 +1,157 @@
+package users
+
+import (
+	"fatbot/i18n"
+	"testing"
+	"time"
+
+	"github.com/spf13/viper"
+)
+
+func setDefaultRulesConfig() {
+	viper.Set("workout.window.days", 5)
+	viper.Set("workout.warning.days_before", 1)
+	viper.Set("workout.warning.hour", 19)
+	viper.Set("users.new.days", 5)
+	viper.Set("workout.period", 60)
+	viper.Set("ban.wait.hours", 24)
+	viper.Set("timezone", "Europe/Rome")
+	viper.Set("report.day", "Saturday")
+	viper.Set("report.hour", 20)
+	viper.Set("language", "en")
+	viper.Set("workout.manual.policy", "count")
+	viper.Set("workout.manual.weekly_cap", 2)
+	viper.Set("workout.duplicates.policy", "admins")
+	viper.Set("workout.plausibility.threshold", 30)
+	viper.Set("workout.streak.freeze_every_days", 7)
+}
+
+func TestGroupSettingsRules(t *testing.T) {
+	setDefaultRulesConfig()
+	defaults := GroupRules{
+		UploadWindowDays:     5,
+		WarningLeadDays:      1,
+		WarningHour:          19,
+		NewUserGraceDays:     5,
+		WorkoutPeriodMinutes: 60,
+		RejoinWaitHours:      24,
+		Timezone:             "Europe/Rome",
+		ReportDay:            time.Saturday,
+		ReportHour:           20,
+		Language:             i18n.English,
+		ManualLogs:           ManualLogsCount,
+		ManualWeeklyCap:      2,
+		DuplicatePhotos:      DuplicatesAdmins,
+		Plausibility:         30,
+		StreakFreezeDays:     7,
+	}
+
+	three := 3
+	zero := 0
+	timezone := "America/New_York"
+	reportDay := "Sunday"
+	language := "he"
+	manualLogs := ManualLogsApproval
+	duplicates := DuplicatesRefuse
+	overridden := defaults
+	overridden.UploadWindowDays = 3
+	overridden.RejoinWaitHours = 0
+	overridden.Timezone = timezone
+	overridden.ReportDay = time.Sunday
+	overridden.Language = i18n.Hebrew
+	overridden.ManualLogs = ManualLogsApproval
+	overridden.ManualWeeklyCap = 0
+	overridden.DuplicatePhotos = DuplicatesRefuse
+	overridden.Plausibility = 0
+	overridden.StreakFreezeDays = 0
+
+	tests := []struct {
+		name     string
+		settings GroupSettings
+		want     GroupRules
+	}{
+		{
+			name:     "defaults",
+			settings: GroupSettings{},
+			want:     defaults,
+		},
+		{
+			name: "overrides",
+			settings: GroupSettings{
+				UploadWindowDays: &three,
+				RejoinWaitHours:  &zero,
+				Timezone:         &timezone,
+				ReportDay:        &reportDay,
+				Language:         &language,
+				ManualLogs:       &manualLogs,
+				ManualWeeklyCap:  &zero,
+				DuplicatePhotos:  &duplicates,
+				Plausibility:     &zero,
+				StreakFreezeDays: &zero,
+			},
+			want: overridden,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.settings.Rules(); got != tt.want {
+				t.Errorf("got %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestGroupRulesCycleStart(t *testing.T) {
+	setDefaultRulesConfig()
+	rules := GroupSettings{}.Rules()
+	location := rules.Location()
+
+	tests := []struct {
+		name string
+		now  time.Time
+		want time.Time
+	}{
+		{
+			name: "mid week",
+			now:  time.Date(2024, 5, 15, 12, 0, 0, 0, location), // Wednesday
+			want: time.Date(2024, 5, 11, 20, 0, 0, 0, location),
+		},
+		{
+			name: "report day before report hour",
+			now:  time.Date(2024, 5, 18, 19, 59, 0, 0, location),
+			want: time.Date(2024, 5, 11, 20, 0, 0, 0, location),
+		},
+		{
+			name: "report day after report hour",
+			now:  time.Date(2024, 5, 18, 20, 1, 0, 0, location),
+			want: time.Date(2024, 5, 18, 20, 0, 0, 0, location),
+		},
+		{
+			name: "other timezone",
+			now:  time.Date(2024, 5, 18, 15, 0, 0, 0, time.UTC), // 17:00 in Rome
+			want: time.Date(2024, 5, 11, 20, 0, 0, 0, location),
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := rules.CycleStart(tt.now); !got.Equal(tt.want) {
+				t.Errorf("got %s, want %s", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestGroupRulesDaysUntilReport(t *testing.T) {
+	setDefaultRulesConfig()
+	rules := GroupSettings{}.Rules()
+	location := rules.Location()
+
+	// The hourly job runs a few seconds after the standings go out on Wednesday
+	standings := time.Date(2024, 5, 15, 20, 0, 5, 0, location)
+	if got := rules.DaysUntilReport(standings); got != 3 {
+		t.Errorf("got %d days on the standings day, want 3", got)
+	}
+	if got := rules.DaysUntilReport(time.Date(2024, 5, 18, 19, 0, 0, 0, location)); got != 1 {
+		t.Errorf("got %d days an hour before the report, want 1", got)
+	}
+}
@@ -22,6 +22,7 @@ type Group struct {
 	Users               []User `gorm:"many2many:user_groups;"`
 	Admins              []User `gorm:"many2many:groups_admins;"`
 	Workouts            []Workout
+	Settings            GroupSettings
 }
 
 func CreateGroup(chatId int64, title string) error {
@@ -66,12 +67,14 @@ func GetGroups() (groups []Group) {
 
 func GetGroupsWithInsta() []Group {
 	var groups []Group
+	instaUsers := db.DBCon.Model(&User{}).
+		Select("users.id").
+		Where("users.instagram_handle <> ?", "").
+		Where("users.id IN (?)", WorkoutsWithPhoto().Select("workouts.user_id"))
 	db.DBCon.Model(&Group{}).
-		Joins("JOIN user_groups ON user_groups.group_id = groups.id").
-		Joins("JOIN users ON users.id = user_groups.user_id").
-		Joins("JOIN workouts ON workouts.user_id = users.id").
-		Where("users.instagram_handle != ? AND workouts.photo_file_id != ?", "", "").
-		Group("groups.id").
+		Where("groups.id IN (?)", db.DBCon.Model(&UserGroup{}).
+			Select("user_groups.group_id").
+			Where("user_groups.user_id IN (?)", instaUsers)).
 		Find(&groups)
 	return groups
 }
@@ -246,7 +249,12 @@ func GenerateSlug(title string) string {
 // with existing slugs by appending a numeric suffix if needed.
 // The DB uniqueIndex on Slug is the final safety net against race conditions.
 func GenerateUniqueSlug(title string) string {
-	db := db.DBCon
+	return GenerateUniqueSlugIn(db.DBCon, title)
+}
+
+// GenerateUniqueSlugIn is GenerateUniqueSlug against the given connection,
+// so it can run inside a transaction.
+func GenerateUniqueSlugIn(db *gorm.DB, title string) string {
 	base := GenerateSlug(title)
 	slug := base
 	var count int64
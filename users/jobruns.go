@@ -0,0 +1,138 @@
+package users
+
+import (
+	"fatbot/db"
+	"fmt"
+	"slices"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/charmbracelet/log"
+	"gorm.io/gorm"
+)
+
+// Names of the scheduled jobs, shared by the scheduler and the admin menu.
+const (
+	JobScanUsers           = "scan_users"
+	JobWhoopSync           = "whoop_sync"
+	JobWeeklyReport        = "weekly_report"
+	JobStandings           = "standings"
+	JobMonthlyReport       = "monthly_report"
+	JobNudgeBannedUsers    = "nudge_banned_users"
+	JobUpdateRanks         = "update_ranks"
+	JobInstagramAutomation = "instagram_automation"
+	JobBackupDatabase      = "backup_database"
+	JobBackfillMetrics     = "backfill_workout_metrics"
+)
+
+var (
+	scheduledJobsMu sync.Mutex
+	scheduledJobs   []string
+)
+
+// RegisterScheduledJob lists a job the scheduler runs on this instance, so
+// the admin menu only offers jobs that can actually run.
+func RegisterScheduledJob(name string) {
+	scheduledJobsMu.Lock()
+	defer scheduledJobsMu.Unlock()
+	if !slices.Contains(scheduledJobs, name) {
+		scheduledJobs = append(scheduledJobs, name)
+	}
+}
+
+// ScheduledJobs returns the registered jobs in the order they were scheduled.
+func ScheduledJobs() []string {
+	scheduledJobsMu.Lock()
+	defer scheduledJobsMu.Unlock()
+	return slices.Clone(scheduledJobs)
+}
+
+type JobOutcome string
+
+const (
+	JobRunning JobOutcome = "running"
+	JobSuccess JobOutcome = "success"
+	JobFailed  JobOutcome = "failed"
+)
+
+// JobRun is one run of a scheduled job, whether by the scheduler or
+// triggered by an admin. Actions lists what the run did, or in a dry run
+// what it would have done, one per line.
+type JobRun struct {
+	gorm.Model
+	Job         string `gorm:"index"`
+	TriggeredBy int64
+	DryRun      bool
+	Instance    string
+	StartedAt   time.Time
+	FinishedAt  *time.Time
+	Outcome     JobOutcome
+	Affected    int
+	Actions     string
+	Error       string
+}
+
+// StartJobRun records that a job started. Failing to record never stops the
+// job, the returned run is just not persisted.
+func StartJobRun(job string, triggeredBy int64, dryRun bool, instance string) *JobRun {
+	run := &JobRun{
+		Job:         job,
+		TriggeredBy: triggeredBy,
+		DryRun:      dryRun,
+		Instance:    instance,
+		StartedAt:   time.Now(),
+		Outcome:     JobRunning,
+	}
+	if err := db.DBCon.Create(run).Error; err != nil {
+		log.Error("Failed to record job run", "job", job, "err", err)
+	}
+	return run
+}
+
+// Finish stores the outcome of the run.
+func (run *JobRun) Finish(actions []string, err error) {
+	now := time.Now()
+	run.FinishedAt = &now
+	run.Affected = len(actions)
+	run.Actions = strings.Join(actions, "\n")
+	run.Outcome = JobSuccess
+	if err != nil {
+		run.Outcome = JobFailed
+		run.Error = err.Error()
+	}
+	if run.ID == 0 {
+		return
+	}
+	if err := db.DBCon.Save(run).Error; err != nil {
+		log.Error("Failed to record job run outcome", "job", run.Job, "err", err)
+	}
+}
+
+// GetJobRuns returns the latest runs of a job, or of every job when job is empty.
+func GetJobRuns(job string, limit int) (runs []JobRun, err error) {
+	query := db.DBCon.Order("started_at DESC").Limit(limit)
+	if job != "" {
+		query = query.Where("job = ?", job)
+	}
+	err = query.Find(&runs).Error
+	return
+}
+
+func (run JobRun) String() string {
+	text := fmt.Sprintf("%s %s", run.StartedAt.Format("2006-01-02 15:04"), run.Job)
+	if run.DryRun {
+		text += " (dry run)"
+	}
+	if run.TriggeredBy != SystemActor {
+		text += " by " + auditUserName(run.TriggeredBy)
+	}
+	text += fmt.Sprintf(": %s, %d affected", run.Outcome, run.Affected)
+	if run.FinishedAt != nil {
+		text += fmt.Sprintf(" in %s", run.FinishedAt.Sub(run.StartedAt).Round(time.Second))
+	}
+	if run.Error != "" {
+		text += " - " + run.Error
+	}
+	return text
+}
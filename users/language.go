@@ -0,0 +1,38 @@
+package users
+
+import (
+	"fatbot/db"
+	"fatbot/i18n"
+)
+
+// Lang is the language for messages sent to the user directly.
+func (user User) Lang() i18n.Lang {
+	return i18n.Or(user.Language, i18n.Default())
+}
+
+// Lang is the language for messages sent to the group chat.
+func (group *Group) Lang() i18n.Lang {
+	return group.GetRules().Language
+}
+
+// GroupLang returns the language of the group with the given chat id.
+func GroupLang(chatId int64) i18n.Lang {
+	return GetGroupRules(chatId).Language
+}
+
+// SetLanguage stores the language the user picked.
+func (user *User) SetLanguage(lang i18n.Lang) error {
+	user.Language = string(lang)
+	return db.DBCon.Model(user).Update("language", user.Language).Error
+}
+
+// DetectLanguage sets the language from Telegram's language_code for users
+// who haven't picked one, so they don't have to.
+func (user *User) DetectLanguage(code string) {
+	if user.Language != "" || user.ID == 0 {
+		return
+	}
+	if lang, ok := i18n.Parse(code); ok {
+		user.SetLanguage(lang)
+	}
+}
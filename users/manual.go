@@ -0,0 +1,108 @@
+package users
+
+import (
+	"fatbot/db"
+	"time"
+)
+
+// What a group does with workouts logged with /log.
+const (
+	ManualLogsCount    = "count"
+	ManualLogsApproval = "approval"
+	ManualLogsOff      = "off"
+)
+
+// ManualCategories are the activities offered by /log, in display order.
+var ManualCategories = []string{
+	CategoryRun,
+	CategoryRide,
+	CategoryWalk,
+	CategorySwim,
+	CategoryStrength,
+	CategoryCardio,
+	CategoryMobility,
+	CategorySport,
+	CategoryOther,
+}
+
+// ManualMinutes are the durations offered by /log.
+var ManualMinutes = []int{20, 30, 45, 60, 90, 120}
+
+// ManualLogRefusal is why a group doesn't take a manual log. Refusals are
+// for the user, not errors.
+type ManualLogRefusal string
+
+const (
+	ManualLogAccepted ManualLogRefusal = ""
+	ManualLogDisabled ManualLogRefusal = "off"
+	ManualLogCapped   ManualLogRefusal = "capped"
+	ManualLogTooSoon  ManualLogRefusal = "too_soon"
+)
+
+func ManualWorkoutMetrics(category string, minutes int) WorkoutMetrics {
+	end := time.Now()
+	start := end.Add(-time.Duration(minutes) * time.Minute)
+	return WorkoutMetrics{
+		Sport:           category,
+		Category:        category,
+		StartedAt:       &start,
+		EndedAt:         &end,
+		DurationMinutes: float64(minutes),
+		MetricsSource:   MetricsManual,
+		Manual:          true,
+	}
+}
+
+// CountManualWorkoutsThisCycle counts the user's manual logs in the group's
+// current weekly cycle.
+func (user *User) CountManualWorkoutsThisCycle(group Group) (count int64, err error) {
+	cycleStart := group.GetRules().CycleStart(time.Now())
+	err = db.DBCon.Model(&Workout{}).
+		Where("user_id = ? AND group_id = ? AND metrics_source = ? AND created_at > ?",
+			user.ID, group.ID, MetricsManual, cycleStart).
+		Count(&count).Error
+	return
+}
+
+// CheckManualLog tells whether the group takes a manual log from the user
+// right now, leaving approval aside.
+func (user *User) CheckManualLog(group Group) (ManualLogRefusal, error) {
+	rules := group.GetRules()
+	if rules.ManualLogs == ManualLogsOff {
+		return ManualLogDisabled, nil
+	}
+	if rules.ManualWeeklyCap > 0 {
+		count, err := user.CountManualWorkoutsThisCycle(group)
+		if err != nil {
+			return ManualLogAccepted, err
+		}
+		if count >= int64(rules.ManualWeeklyCap) {
+			return ManualLogCapped, nil
+		}
+	}
+	lastWorkout, err := user.GetLastXWorkout(1, group.ChatID)
+	if err == nil && !lastWorkout.IsOlderThan(rules.WorkoutPeriodMinutes) {
+		return ManualLogTooSoon, nil
+	}
+	return ManualLogAccepted, nil
+}
+
+// LogManualWorkout creates a workout without a photo or a provider,
+// continuing the streak like a photo upload does.
+func (user *User) LogManualWorkout(group Group, metrics WorkoutMetrics, note string) (Workout, error) {
+	workout := Workout{
+		UserID:         user.ID,
+		GroupID:        group.ID,
+		Note:           note,
+		WorkoutMetrics: metrics,
+	}
+	// Approved logs keep the time they were sent, not the time of approval
+	if metrics.EndedAt != nil {
+		workout.CreatedAt = *metrics.EndedAt
+	}
+	if err := db.DBCon.Create(&workout).Error; err != nil {
+		return workout, err
+	}
+	_, err := workout.UpdateStreak(user, group)
+	return workout, err
+}
@@ -0,0 +1,44 @@
+package users
+
+import (
+	"fatbot/db"
+	"testing"
+	"time"
+)
+
+func TestCheckManualLog(t *testing.T) {
+	setDefaultRulesConfig()
+	user, group := openAccountTestDB(t)
+	database := db.DBCon
+
+	if refusal, err := user.CheckManualLog(group); err != nil || refusal != ManualLogTooSoon {
+		t.Fatalf("got %q, %v right after a workout, want %q", refusal, err, ManualLogTooSoon)
+	}
+	database.Model(&Workout{}).Where("user_id = ?", user.ID).Update("created_at", time.Now().AddDate(0, 0, -1))
+	if refusal, err := user.CheckManualLog(group); err != nil || refusal != ManualLogAccepted {
+		t.Fatalf("got %q, %v, want the log accepted", refusal, err)
+	}
+
+	workout, err := user.LogManualWorkout(group, ManualWorkoutMetrics(CategoryRun, 30), "park loop")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if workout.Source() != "manual" || workout.Note != "park loop" || workout.DurationMinutes != 30 ||
+		!workout.CreatedAt.Equal(*workout.EndedAt) || workout.Streak != 2 {
+		t.Errorf("got manual workout %+v", workout)
+	}
+	if count, _ := user.CountManualWorkoutsThisCycle(group); count != 1 {
+		t.Errorf("counted %d manual workouts, want 1", count)
+	}
+
+	weeklyCap, off := 1, ManualLogsOff
+	settings := GroupSettings{GroupID: group.ID, ManualWeeklyCap: &weeklyCap}
+	database.Create(&settings)
+	if refusal, _ := user.CheckManualLog(group); refusal != ManualLogCapped {
+		t.Errorf("got %q with the cap reached, want %q", refusal, ManualLogCapped)
+	}
+	database.Model(&settings).Update("manual_logs", off)
+	if refusal, _ := user.CheckManualLog(group); refusal != ManualLogDisabled {
+		t.Errorf("got %q with manual logs off, want %q", refusal, ManualLogDisabled)
+	}
+}
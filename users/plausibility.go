@@ -0,0 +1,161 @@
+package users
+
+import (
+	"fatbot/db"
+	"regexp"
+	"slices"
+	"strings"
+
+	"gorm.io/gorm"
+)
+
+// Where a workout stands in the admins' review queue.
+const (
+	ReviewPending  = "pending"
+	ReviewApproved = "approved"
+	ReviewRejected = "rejected"
+)
+
+// Image labels, lowercased, that tell what a photo shows.
+var (
+	workoutLabels = labelSet(
+		"fitness", "working out", "exercise", "gym", "sport", "sports", "running", "jogging",
+		"cycling", "bicycle", "mountain bike", "swimming", "swimming pool", "yoga", "pilates",
+		"weights", "weight lifting", "dumbbell", "barbell", "treadmill", "climbing", "rock climbing",
+		"hiking", "boxing", "martial arts", "tennis", "football", "soccer", "basketball",
+		"volleyball", "skiing", "rowing", "stretching", "marathon", "gymnastics",
+	)
+	activeLabels = labelSet(
+		"sportswear", "shorts", "sneaker", "shoe", "footwear", "running shoe", "sweat",
+		"trail", "path", "road", "track", "outdoors", "nature", "mountain", "park", "water",
+		"helmet", "goggles", "smart watch", "wristwatch",
+	)
+	unrelatedLabels = labelSet(
+		"cat", "dog", "pet", "food", "meal", "dish", "dessert", "drink", "beverage", "alcohol",
+		"cocktail", "plate", "furniture", "couch", "bed", "bedroom", "car", "meme", "cartoon",
+	)
+)
+
+// Words in the photo's text that fitness apps and watches show.
+var workoutTextRegex = regexp.MustCompile(
+	`(?i)\b(kcal|cal|bpm|km|mi|pace|workout|heart rate|avg|duration|elevation|reps|sets|strain|steps|\d{1,2}:\d{2}:\d{2})\b`)
+
+// Words in a caption that say it's a workout, in the bot's languages.
+var workoutCaptionWords = []string{
+	"workout", "gym", "run", "ran", "ride", "swim", "yoga", "pilates", "lift", "training",
+	"train", "cardio", "hike", "walk", "class", "spin", "crossfit", "climb", "km", "legs",
+	"allenamento", "palestra", "corsa", "nuoto", "bici", "camminata",
+	"אימון", "ריצה", "כושר", "שחייה", "הליכה", "אופניים",
+}
+
+func labelSet(labels ...string) map[string]bool {
+	set := make(map[string]bool, len(labels))
+	for _, label := range labels {
+		set[label] = true
+	}
+	return set
+}
+
+// ScorePlausibility rates from 0 to 100 how much an upload looks like a
+// workout, from the image labels, the lines of text found in the photo and
+// the caption. Workout labels weigh the most, screenshots of fitness apps
+// and a caption about the workout make up for a plain photo, and pets or
+// food count against it.
+func ScorePlausibility(labels, text []string, caption string) int {
+	var workout, active, unrelated int
+	for _, label := range labels {
+		label = strings.ToLower(label)
+		switch {
+		case workoutLabels[label]:
+			workout++
+		case activeLabels[label]:
+			active++
+		case unrelatedLabels[label]:
+			unrelated++
+		}
+	}
+	score := 0
+	if workout > 0 {
+		score += 40 + 10*min(workout, 3)
+	}
+	score += 10 * min(active, 2)
+	score -= 25 * min(unrelated, 2)
+
+	textHits := 0
+	for _, line := range text {
+		if workoutTextRegex.MatchString(line) {
+			textHits++
+		}
+	}
+	score += 20 * min(textHits, 3)
+
+	caption = strings.ToLower(caption)
+	for _, word := range strings.FieldsFunc(caption, func(r rune) bool {
+		return !(r >= 'a' && r <= 'z' || r >= 0x0590 && r <= 0x05FF)
+	}) {
+		if slices.Contains(workoutCaptionWords, word) {
+			score += 20
+			break
+		}
+	}
+	return max(0, min(score, 100))
+}
+
+// SavePlausibility stores the workout's score and puts it in the review
+// queue if it needs one.
+func (workout *Workout) SavePlausibility(score int, needsReview bool) error {
+	workout.Plausibility = score
+	if needsReview {
+		workout.Review = ReviewPending
+	}
+	return db.DBCon.Model(workout).Updates(map[string]any{
+		"plausibility": workout.Plausibility,
+		"review":       workout.Review,
+	}).Error
+}
+
+// GetPendingReviews returns the group's workouts waiting for an admin, oldest
+// first.
+func GetPendingReviews(groupID uint) (workouts []Workout, err error) {
+	err = db.DBCon.
+		Where("group_id = ? AND review = ?", groupID, ReviewPending).
+		Order("created_at").
+		Find(&workouts).Error
+	return
+}
+
+// ReviewWorkout answers a pending review, approving the workout or
+// rejecting and deleting it. It reports false without changing anything when
+// the workout is no longer pending, e.g. when another admin answered first.
+func ReviewWorkout(id uint, review string) (reviewed bool, err error) {
+	err = db.DBCon.Transaction(func(tx *gorm.DB) error {
+		result := tx.Model(&Workout{}).Where("id = ? AND review = ?", id, ReviewPending).Update("review", review)
+		if result.Error != nil {
+			return result.Error
+		}
+		reviewed = result.RowsAffected == 1
+		if reviewed && review == ReviewRejected {
+			return tx.Delete(&Workout{}, id).Error
+		}
+		return nil
+	})
+	return
+}
+
+// ApproveWorkout takes the workout out of the review queue, restoring it if
+// it was rejected.
+func ApproveWorkout(id uint) error {
+	return db.DBCon.Unscoped().Model(&Workout{}).Where("id = ?", id).
+		Updates(map[string]any{"review": ReviewApproved, "deleted_at": nil}).Error
+}
+
+// RejectWorkout takes the workout out of the review queue and deletes it.
+func RejectWorkout(id uint) error {
+	return db.DBCon.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Unscoped().Model(&Workout{}).Where("id = ?", id).
+			Update("review", ReviewRejected).Error; err != nil {
+			return err
+		}
+		return tx.Delete(&Workout{}, id).Error
+	})
+}
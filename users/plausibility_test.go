@@ -0,0 +1,79 @@
+package users
+
+import (
+	"fatbot/db"
+	"testing"
+)
+
+func TestScorePlausibility(t *testing.T) {
+	tests := []struct {
+		name    string
+		labels  []string
+		text    []string
+		caption string
+		atLeast int
+		below   int
+	}{
+		{"gym", []string{"Person", "Gym", "Working Out", "Sportswear"}, nil, "", 70, 101},
+		{"cat", []string{"Cat", "Pet", "Animal"}, nil, "", 0, 1},
+		{"watch screenshot", []string{"Text", "Electronics"}, []string{"Outdoor Run", "5.02 KM", "152 BPM", "0:31:12"}, "", 60, 101},
+		{"landscape with a caption", []string{"Outdoors", "Nature", "Sky"}, nil, "Long walk today", 40, 41},
+		{"hebrew caption", []string{"Room"}, nil, "אימון בוקר", 20, 21},
+		{"nothing to go on", []string{"Room", "Indoors"}, nil, "", 0, 1},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			score := ScorePlausibility(tt.labels, tt.text, tt.caption)
+			if score < tt.atLeast || score >= tt.below {
+				t.Errorf("got %d, want [%d, %d)", score, tt.atLeast, tt.below)
+			}
+		})
+	}
+}
+
+func TestReviewQueue(t *testing.T) {
+	user, group := openAccountTestDB(t)
+	database := db.DBCon
+	workout := Workout{UserID: user.ID, GroupID: group.ID}
+	database.Create(&workout)
+	if err := workout.SavePlausibility(10, true); err != nil {
+		t.Fatal(err)
+	}
+	if pending, _ := GetPendingReviews(group.ID); len(pending) != 1 || pending[0].ID != workout.ID {
+		t.Fatalf("got %+v pending, want the workout", pending)
+	}
+
+	if err := RejectWorkout(workout.ID); err != nil {
+		t.Fatal(err)
+	}
+	rejected, _ := GetAnyWorkout(workout.ID)
+	if !rejected.Deleted() || rejected.Review != ReviewRejected {
+		t.Errorf("rejected workout is %+v", rejected)
+	}
+	if pending, _ := GetPendingReviews(group.ID); len(pending) != 0 {
+		t.Errorf("got %d pending after rejecting, want none", len(pending))
+	}
+
+	if err := ApproveWorkout(workout.ID); err != nil {
+		t.Fatal(err)
+	}
+	if approved, _ := GetAnyWorkout(workout.ID); approved.Deleted() || approved.Review != ReviewApproved {
+		t.Errorf("approved workout is %+v", approved)
+	}
+}
+
+func TestReviewWorkoutOnce(t *testing.T) {
+	user, group := openAccountTestDB(t)
+	workout := Workout{UserID: user.ID, GroupID: group.ID, Review: ReviewPending}
+	db.DBCon.Create(&workout)
+
+	if reviewed, err := ReviewWorkout(workout.ID, ReviewRejected); err != nil || !reviewed {
+		t.Fatalf("got %v, %v, want the pending workout rejected", reviewed, err)
+	}
+	if reviewed, err := ReviewWorkout(workout.ID, ReviewApproved); err != nil || reviewed {
+		t.Errorf("got %v, %v, want a second answer refused", reviewed, err)
+	}
+	if rejected, _ := GetAnyWorkout(workout.ID); !rejected.Deleted() || rejected.Review != ReviewRejected {
+		t.Errorf("workout is %+v, want it still rejected", rejected)
+	}
+}
@@ -2,6 +2,7 @@ package users
 
 import (
 	"fatbot/db"
+	"fmt"
 	"time"
 
 	"github.com/charmbracelet/log"
@@ -107,6 +108,28 @@ func (user *User) updateRank() error {
 	return user.promoteRank()
 }
 
+// PendingRankUpdates describes the rank changes UpdateAllUserRanks would
+// make, without saving them.
+func PendingRankUpdates() (changes []string) {
+	ranks := GetRanks()
+	for _, user := range GetUsers(0) {
+		if user.RankUpdatedAt == nil {
+			changes = append(changes, fmt.Sprintf("set %s to %s", user.GetName(), ranks[1].Name))
+			continue
+		}
+		nextRank, ok := ranks[user.Rank+1]
+		if !ok {
+			continue
+		}
+		daysNeeded := nextRank.MinDays - ranks[user.Rank].MinDays
+		if int(time.Since(*user.RankUpdatedAt).Hours()/24) >= daysNeeded {
+			changes = append(changes, fmt.Sprintf("promote %s from %s to %s",
+				user.GetName(), ranks[user.Rank].Name, nextRank.Name))
+		}
+	}
+	return changes
+}
+
 // Run rank update for all users
 func UpdateAllUserRanks() {
 	for _, user := range GetUsers(0) {
@@ -5,6 +5,8 @@ import (
 	"fatbot/strava"
 	"fmt"
 	"time"
+
+	"gorm.io/gorm"
 )
 
 // UpdateStravaToken saves the Strava tokens to the user and clears other integrations
@@ -73,12 +75,15 @@ func (user *User) ClearOtherIntegrations() {
 
 // DeregisterStrava clears all Strava tokens and athlete ID
 func (user *User) DeregisterStrava() error {
-	db := db.DBCon
+	return user.deregisterStrava(db.DBCon)
+}
+
+func (user *User) deregisterStrava(tx *gorm.DB) error {
 	user.StravaAccessToken = ""
 	user.StravaRefreshToken = ""
 	user.StravaTokenExpiry = time.Time{}
 	user.StravaAthleteID = ""
-	return db.Save(&user).Error
+	return tx.Save(&user).Error
 }
 
 // GetStravaUsers returns all users with a Strava access token
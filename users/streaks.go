@@ -0,0 +1,90 @@
+package users
+
+import (
+	"fatbot/db"
+	"time"
+
+	"github.com/spf13/viper"
+)
+
+// Streak is a member's run of consecutive workout days in a group. Days are
+// the calendar days of the group timezone, however many workouts they have.
+// Every StreakFreezeDays days in a row earn a freeze, and a freeze covers
+// one missed day without breaking the streak.
+type Streak struct {
+	Current int
+	Longest int
+	Freezes int
+}
+
+// streakDay numbers the calendar day of t in location, so that consecutive
+// days are one apart across month and year ends and DST changes.
+func streakDay(t time.Time, location *time.Location) int {
+	year, month, day := t.In(location).Date()
+	return int(time.Date(year, month, day, 0, 0, 0, 0, time.UTC).Unix() / (24 * 60 * 60))
+}
+
+// computeStreak walks the distinct workout days, oldest first, up to today.
+// Freezes are part of the streak: a gap longer than the freezes left breaks
+// it and loses them. maxFreezes of 0 is no limit.
+func computeStreak(days []int, today, freezeEvery, maxFreezes int) (streak Streak) {
+	for i, day := range days {
+		if i == 0 {
+			streak.Current = 1
+		} else if missed := day - days[i-1] - 1; missed <= streak.Freezes {
+			streak.Freezes -= missed
+			streak.Current++
+		} else {
+			streak = Streak{Current: 1, Longest: streak.Longest}
+		}
+		if freezeEvery > 0 && streak.Current%freezeEvery == 0 &&
+			(maxFreezes <= 0 || streak.Freezes < maxFreezes) {
+			streak.Freezes++
+		}
+		streak.Longest = max(streak.Longest, streak.Current)
+	}
+	if len(days) == 0 {
+		return streak
+	}
+	// Today doesn't count as missed yet
+	if missed := today - days[len(days)-1] - 1; missed > streak.Freezes {
+		streak.Current, streak.Freezes = 0, 0
+	} else if missed > 0 {
+		streak.Freezes -= missed
+	}
+	return streak
+}
+
+// GetStreak returns the user's streak in the group as of at, from the
+// workouts up to then.
+func (user *User) GetStreak(group Group, at time.Time) (Streak, error) {
+	var times []time.Time
+	err := db.DBCon.Model(&Workout{}).
+		Where("user_id = ? AND group_id = ? AND flagged = ? AND created_at <= ?", user.ID, group.ID, false, at).
+		Order("created_at").
+		Pluck("created_at", &times).Error
+	if err != nil {
+		return Streak{}, err
+	}
+	rules := group.GetRules()
+	location := rules.Location()
+	var days []int
+	for _, t := range times {
+		if day := streakDay(t, location); len(days) == 0 || days[len(days)-1] != day {
+			days = append(days, day)
+		}
+	}
+	return computeStreak(days, streakDay(at, location),
+		rules.StreakFreezeDays, viper.GetInt("workout.streak.max_freezes")), nil
+}
+
+// UpdateStreak saves the streak the workout continues on it, and returns
+// it for the announcement.
+func (workout *Workout) UpdateStreak(user *User, group Group) (Streak, error) {
+	streak, err := user.GetStreak(group, workout.CreatedAt)
+	if err != nil {
+		return streak, err
+	}
+	workout.Streak = streak.Current
+	return streak, db.DBCon.Model(workout).Update("streak", streak.Current).Error
+}
@@ -0,0 +1,113 @@
+package users
+
+import (
+	"fatbot/db"
+	"testing"
+	"time"
+
+	"github.com/spf13/viper"
+	"gorm.io/gorm"
+)
+
+func TestComputeStreak(t *testing.T) {
+	tests := []struct {
+		name  string
+		days  []int
+		today int
+		want  Streak
+	}{
+		{
+			name: "no workouts",
+			want: Streak{},
+		},
+		{
+			name:  "a week earns a freeze",
+			days:  []int{1, 2, 3, 4, 5, 6, 7},
+			today: 7,
+			want:  Streak{Current: 7, Longest: 7, Freezes: 1},
+		},
+		{
+			name:  "today isn't missed yet",
+			days:  []int{1, 2, 3},
+			today: 4,
+			want:  Streak{Current: 3, Longest: 3},
+		},
+		{
+			name:  "a missed day without freezes breaks it",
+			days:  []int{1, 2, 3, 5},
+			today: 5,
+			want:  Streak{Current: 1, Longest: 3},
+		},
+		{
+			name:  "a freeze covers a missed day",
+			days:  []int{1, 2, 3, 4, 5, 6, 7, 9, 10},
+			today: 10,
+			want:  Streak{Current: 9, Longest: 9},
+		},
+		{
+			name:  "a freeze covers yesterday until today is over",
+			days:  []int{1, 2, 3, 4, 5, 6, 7},
+			today: 9,
+			want:  Streak{Current: 7, Longest: 7},
+		},
+		{
+			name:  "a gap longer than the freezes loses them",
+			days:  []int{1, 2, 3, 4, 5, 6, 7, 10},
+			today: 10,
+			want:  Streak{Current: 1, Longest: 7},
+		},
+		{
+			name:  "freezes are capped",
+			days:  []int{1, 2, 3, 4, 5, 6, 7, 8, 9, 10, 11, 12, 13, 14, 15, 16, 17, 18, 19, 20, 21},
+			today: 21,
+			want:  Streak{Current: 21, Longest: 21, Freezes: 2},
+		},
+		{
+			name:  "over",
+			days:  []int{1, 2, 3},
+			today: 6,
+			want:  Streak{Longest: 3},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := computeStreak(tt.days, tt.today, 7, 2); got != tt.want {
+				t.Errorf("got %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestGetStreak(t *testing.T) {
+	setDefaultRulesConfig()
+	viper.Set("workout.streak.max_freezes", 2)
+	user, group := openAccountTestDB(t)
+	database := db.DBCon
+	database.Where("user_id = ?", user.ID).Delete(&Workout{})
+
+	// Late evenings in UTC are the next day in Rome, and 2024 is a leap year
+	for _, createdAt := range []time.Time{
+		time.Date(2024, 2, 27, 23, 30, 0, 0, time.UTC), // Feb 28 in Rome
+		time.Date(2024, 2, 28, 23, 30, 0, 0, time.UTC), // Feb 29
+		time.Date(2024, 2, 29, 8, 0, 0, 0, time.UTC),   // Feb 29 again
+		time.Date(2024, 3, 1, 9, 0, 0, 0, time.UTC),
+	} {
+		database.Create(&Workout{Model: gorm.Model{CreatedAt: createdAt}, UserID: user.ID, GroupID: group.ID})
+	}
+	database.Create(&Workout{Model: gorm.Model{CreatedAt: time.Date(2024, 3, 2, 9, 0, 0, 0, time.UTC)},
+		UserID: user.ID, GroupID: group.ID, Flagged: true})
+
+	at := time.Date(2024, 3, 2, 12, 0, 0, 0, time.UTC)
+	if streak, err := user.GetStreak(group, at); err != nil || streak != (Streak{Current: 3, Longest: 3}) {
+		t.Errorf("got %+v, %v, want 3 days in a row in Rome", streak, err)
+	}
+	if streak, _ := user.GetStreak(group, at.AddDate(0, 0, 1)); streak != (Streak{Longest: 3}) {
+		t.Errorf("got %+v after a missed day, want the streak over", streak)
+	}
+
+	timezone := "UTC"
+	database.Create(&GroupSettings{GroupID: group.ID, Timezone: &timezone})
+	if streak, _ := user.GetStreak(group, at); streak != (Streak{Current: 4, Longest: 4}) {
+		t.Errorf("got %+v in UTC, want the late Feb 27 workout to count", streak)
+	}
+}
@@ -7,10 +7,12 @@ import (
 
 // UserGroup is the explicit join table for the many2many relationship between User and Group.
 // GORM already created this table implicitly. Adding CreatedAt lets us track when a user joined a group.
+// WeeklyGoal is the member's /goal in the group, 0 for none.
 type UserGroup struct {
-	UserID    uint `gorm:"primaryKey"`
-	GroupID   uint `gorm:"primaryKey"`
-	CreatedAt time.Time
+	UserID     uint `gorm:"primaryKey"`
+	GroupID    uint `gorm:"primaryKey"`
+	CreatedAt  time.Time
+	WeeklyGoal int `gorm:"default:0"`
 }
 
 // GetUserGroupJoinDate returns when a user joined a specific group.
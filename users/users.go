@@ -3,13 +3,13 @@ package users
 import (
 	"encoding/json"
 	"fatbot/db"
-	"fatbot/migrations"
+	"fatbot/i18n"
+	"fatbot/metrics"
 	"fmt"
 	"time"
 
 	"github.com/charmbracelet/log"
 	"github.com/getsentry/sentry-go"
-	"github.com/spf13/viper"
 
 	tgbotapi "github.com/go-telegram-bot-api/telegram-bot-api/v5"
 	"gorm.io/gorm"
@@ -49,6 +49,9 @@ type User struct {
 
 	InstagramHandle string
 
+	// Language is an i18n code, taken from Telegram until the user picks one
+	Language string
+
 	Workouts    []Workout
 	Events      []Event
 	Groups      []*Group `gorm:"many2many:user_groups;"`
@@ -73,23 +76,6 @@ func ptrTimeNow() *time.Time {
 	return &now
 }
 
-func InitDB() error {
-	db := db.DBCon
-	db.AutoMigrate(&User{}, &Group{}, &Workout{}, &Event{}, &Blacklist{}, &WorkoutDisputePoll{}, &UserGroup{})
-
-	// Backfill slugs for existing groups that don't have one
-	var groups []Group
-	db.Where("slug IS NULL OR slug = ''").Find(&groups)
-	for _, group := range groups {
-		slug := GenerateUniqueSlug(group.Title)
-		db.Model(&group).Update("slug", slug)
-	}
-
-	migrations.AddProviderWorkoutUniqueIndexes()
-
-	return nil
-}
-
 func (user *User) GetLastRejoinEvent() (*Event, error) {
 	var lastRejoin Event
 	err := db.DBCon.
@@ -151,9 +137,8 @@ func GetUsersWithInsta(chatId int64) []User {
 	db.DBCon.Model(&User{}).
 		Joins("JOIN user_groups ON user_groups.user_id = users.id").
 		Joins("JOIN groups ON groups.id = user_groups.group_id").
-		Joins("JOIN workouts ON workouts.user_id = users.id").
-		Where("groups.chat_id = ? AND users.instagram_handle != ? AND workouts.photo_file_id != ?", chatId, "", "").
-		Group("users.id").
+		Where("groups.chat_id = ? AND users.instagram_handle <> ?", chatId, "").
+		Where("users.id IN (?)", WorkoutsWithPhoto().Select("workouts.user_id")).
 		Find(&usersList)
 	return usersList
 }
@@ -289,6 +274,8 @@ func (user *User) Ban(bot *tgbotapi.BotAPI, chatId int64) (errors []error) {
 	_, err := bot.Request(banChatMemberConfig)
 	if err != nil {
 		errors = append(errors, err)
+	} else {
+		metrics.Bans.Inc()
 	}
 	if err := user.UpdateActive(false); err != nil {
 		log.Debug("Func Ban", "updateActive", false)
@@ -299,26 +286,11 @@ func (user *User) Ban(bot *tgbotapi.BotAPI, chatId int64) (errors []error) {
 		log.Errorf("Error while registering ban event: %s", err)
 	}
 	messagesToSend := []tgbotapi.MessageConfig{}
-	waitHours := viper.GetInt("ban.wait.hours")
-	groupMessage := tgbotapi.NewMessage(chatId, fmt.Sprintf(
-		"%s was not working out. 🦥⛔",
-		user.GetName(),
-	))
-	userMessage := tgbotapi.NewMessage(user.TelegramUserID, fmt.Sprintf(
-		`%s you were banned from the group
-after not working out.
-You can rejoin after %d hours:
-
-1. Tap this: /join
-2. Wait for approval
-3. Get a link to join the group
-
-*NOTICE!!* After joining the group, you
-will have 60 minutes to send a workout
-in the group chat.`,
-		user.GetName(),
-		waitHours,
-	))
+	waitHours := GetGroupRules(chatId).RejoinWaitHours
+	groupMessage := tgbotapi.NewMessage(chatId, i18n.T(GroupLang(chatId), "ban.group",
+		"name", user.GetName()))
+	userMessage := tgbotapi.NewMessage(user.TelegramUserID, i18n.N(user.Lang(), "ban.dm", waitHours,
+		"name", user.GetName()))
 	messagesToSend = append(messagesToSend, groupMessage)
 	messagesToSend = append(messagesToSend, userMessage)
 	for _, msg := range messagesToSend {
@@ -424,7 +396,11 @@ func (user *User) InviteNewUser(bot *tgbotapi.BotAPI, chatId int64) error {
 	if err != nil {
 		return err
 	}
-	msg.Text = "You're invited to join! You have 5 days to post your first workout photo in the group. After that, post at least once every 5 days to stay in. Here's your link: " + link
+	rules := GetGroupRules(chatId)
+	msg.Text = i18n.T(user.Lang(), "invite.new",
+		"grace", i18n.N(user.Lang(), "days", rules.NewUserGraceDays),
+		"window", i18n.N(user.Lang(), "days", rules.UploadWindowDays),
+		"link", link)
 	if _, err := bot.Send(msg); err != nil {
 		return err
 	}
@@ -514,6 +490,7 @@ func (user User) Rejoin(update tgbotapi.Update, bot *tgbotapi.BotAPI) error {
 	if err := user.RegisterRejoinEvent(); err != nil {
 		log.Errorf("Error while registering rejoin event: %s", err)
 	}
+	metrics.Rejoins.Inc()
 
 	return nil
 }
@@ -534,15 +511,16 @@ func (user User) IsNew(chatId int64) (bool, error) {
 
 	// Determine the join date: prefer per-group join date, fall back to user creation date
 	joinDate := user.CreatedAt
+	newUserGraceDays := DefaultGroupRules().NewUserGraceDays
 	group, err := GetGroup(chatId)
 	if err == nil {
 		if ugJoinDate, err := GetUserGroupJoinDate(user.ID, group.ID); err == nil && !ugJoinDate.IsZero() {
 			joinDate = ugJoinDate
 		}
+		newUserGraceDays = group.GetRules().NewUserGraceDays
 	}
 
-	newUserGraceDays := viper.GetFloat64("users.new.days")
-	return time.Now().Sub(joinDate).Hours() <= 24*newUserGraceDays, nil
+	return time.Now().Sub(joinDate).Hours() <= 24*float64(newUserGraceDays), nil
 }
 
 func (user User) SetImmunity(action bool) {
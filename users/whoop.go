@@ -5,6 +5,8 @@ import (
 	"fatbot/whoop"
 	"strconv"
 	"time"
+
+	"gorm.io/gorm"
 )
 
 func (user *User) UpdateWhoopToken(token *whoop.TokenResponse) error {
@@ -67,3 +69,14 @@ func GetWorkoutsByWhoopID(whoopID string) ([]Workout, error) {
 	result := db.DBCon.Where("whoop_id = ?", whoopID).Find(&workouts)
 	return workouts, result.Error
 }
+
+// DeregisterWhoop clears all Whoop tokens and IDs
+func (user *User) DeregisterWhoop() error {
+	return user.deregisterWhoop(db.DBCon)
+}
+
+func (user *User) deregisterWhoop(tx *gorm.DB) error {
+	user.clearWhoop()
+	user.WhoopUserID = 0
+	return tx.Save(&user).Error
+}
@@ -0,0 +1,49 @@
+package users
+
+import (
+	"fatbot/db"
+	"time"
+)
+
+// Deleted reports whether the workout was soft-deleted.
+func (workout Workout) Deleted() bool {
+	return workout.DeletedAt.Valid
+}
+
+// GetWorkoutsPage returns the user's workouts in the group, newest first and
+// including deleted ones, skipping the first offset. It asks for one more
+// than the limit so callers can tell whether there is a next page.
+func GetWorkoutsPage(userID, groupID uint, offset, limit int) (workouts []Workout, err error) {
+	err = db.DBCon.Unscoped().
+		Where("user_id = ? AND group_id = ?", userID, groupID).
+		Order("created_at DESC").
+		Offset(offset).
+		Limit(limit + 1).
+		Find(&workouts).Error
+	return
+}
+
+// GetAnyWorkout finds a workout by ID, deleted or not.
+func GetAnyWorkout(id uint) (workout Workout, err error) {
+	err = db.DBCon.Unscoped().First(&workout, id).Error
+	return
+}
+
+func DeleteWorkout(id uint) error {
+	return db.DBCon.Delete(&Workout{}, id).Error
+}
+
+func RestoreWorkout(id uint) error {
+	return db.DBCon.Unscoped().Model(&Workout{}).Where("id = ?", id).
+		Update("deleted_at", nil).Error
+}
+
+func MoveWorkout(id uint, createdAt time.Time) error {
+	return db.DBCon.Unscoped().Model(&Workout{}).Where("id = ?", id).
+		Update("created_at", createdAt).Error
+}
+
+func ReassignWorkout(id, groupID uint) error {
+	return db.DBCon.Unscoped().Model(&Workout{}).Where("id = ?", id).
+		Update("group_id", groupID).Error
+}
@@ -0,0 +1,44 @@
+package users
+
+import (
+	"fatbot/db"
+	"testing"
+	"time"
+)
+
+func TestWorkoutsPageIncludesDeleted(t *testing.T) {
+	// Comes with two workouts of Dana in Lifters
+	user, group := openAccountTestDB(t)
+	database := db.DBCon
+	old := Workout{UserID: user.ID, GroupID: group.ID}
+	database.Create(&old)
+	movedTo := time.Now().AddDate(0, 0, -3)
+	if err := MoveWorkout(old.ID, movedTo); err != nil {
+		t.Fatal(err)
+	}
+	if err := DeleteWorkout(old.ID); err != nil {
+		t.Fatal(err)
+	}
+
+	workouts, err := GetWorkoutsPage(user.ID, group.ID, 0, 2)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(workouts) != 3 {
+		t.Fatalf("got %d workouts, want 2 and one to tell there's a next page", len(workouts))
+	}
+	last := workouts[2]
+	if last.ID != old.ID || !last.Deleted() || !last.CreatedAt.Equal(movedTo) {
+		t.Errorf("oldest workout is %+v, want the deleted and moved one", last)
+	}
+	if workouts, _ := GetWorkoutsPage(user.ID, group.ID, 2, 2); len(workouts) != 1 {
+		t.Errorf("got %d workouts on the second page, want 1", len(workouts))
+	}
+
+	if err := RestoreWorkout(old.ID); err != nil {
+		t.Fatal(err)
+	}
+	if restored, err := GetAnyWorkout(old.ID); err != nil || restored.Deleted() {
+		t.Errorf("workout wasn't restored: %+v, %v", restored, err)
+	}
+}
@@ -1,6 +1,7 @@
 package users
 
 import (
+	"fatbot/i18n"
 	"math/rand"
 )
 
@@ -41,28 +42,10 @@ import (
 //		return workoutMessages[rand.Intn(len(workoutMessages))]
 //	}
 
-func GetRandomStreakMessage() string {
-	streakMessages := []string{
-		"Keep up the streak, superhero!",
-		"You're killing the streak, champ!",
-		"Streaking! Keep pushing yourself!",
-		"Streaking is your new hobby!",
-		"Keep the streak alive, warrior!",
-		"Keep up the streak, rockstar!",
-		"Streaker alert! You're amazing!",
-		"Keep the streak alive, legend!",
-		"Keep up the streak, athlete!",
-		"Streaking! Keep it up, superstar!",
-		"Your streak is inspiring, warrior!",
-		"Keep up the streak, fitness guru!",
-		"Streaking! You got this, champ!",
-		"Keep the streak going, legend!",
-		"Streaking! Never give up, warrior!",
-		"Keep up the streak, fitness freak!",
-		"Streaking is your new lifestyle!",
-		"Keep the streak alive, ironman!",
-		"Streaking! Stay committed, superstar!",
-		"Keep up the streak, fitness queen/king!",
+func GetRandomStreakMessage(lang i18n.Lang) string {
+	streakMessages := i18n.Variants(lang, "streak.cheer")
+	if len(streakMessages) == 0 {
+		return ""
 	}
 	return streakMessages[rand.Intn(len(streakMessages))]
 }
@@ -0,0 +1,292 @@
+package users
+
+import (
+	"fatbot/db"
+	"fatbot/garmin"
+	"fatbot/strava"
+	"fatbot/whoop"
+	"fmt"
+	"math"
+	"strings"
+	"time"
+	"unicode"
+
+	"gorm.io/gorm"
+)
+
+// Where a workout's metrics came from. Photos have metrics only when the
+// screenshot could be read.
+const (
+	MetricsWhoop      = "whoop"
+	MetricsGarmin     = "garmin"
+	MetricsStrava     = "strava"
+	MetricsAppleWatch = "apple_watch"
+	MetricsManual     = "manual"
+)
+
+// Sport categories, so workouts from different sources can be compared.
+const (
+	CategoryRun      = "run"
+	CategoryRide     = "ride"
+	CategorySwim     = "swim"
+	CategoryWalk     = "walk"
+	CategoryStrength = "strength"
+	CategoryCardio   = "cardio"
+	CategoryMobility = "mobility"
+	CategorySport    = "sport"
+	CategoryOther    = "other"
+)
+
+// WorkoutMetrics are the details of a workout, normalized across sources.
+// Whatever a source doesn't report is left zero.
+type WorkoutMetrics struct {
+	Sport           string
+	Category        string
+	StartedAt       *time.Time
+	EndedAt         *time.Time
+	DurationMinutes float64
+	DistanceMeters  float64
+	Calories        float64
+	AvgHeartRate    int
+	MaxHeartRate    int
+	Strain          float64 // On Whoop's 0-21 scale, estimated for other sources
+	MetricsSource   string
+	Device          string
+	Manual          bool
+}
+
+// HasMetrics reports whether any source filled in the metrics.
+func (metrics WorkoutMetrics) HasMetrics() bool {
+	return metrics.MetricsSource != ""
+}
+
+// categoryKeywords are checked in order, so "trail running" is a run and
+// not a walk.
+var categoryKeywords = []struct {
+	category string
+	keywords []string
+}{
+	{CategoryRun, []string{"run", "jog"}},
+	{CategoryRide, []string{"ride", "cycl", "bik", "spin"}},
+	{CategorySwim, []string{"swim"}},
+	{CategoryWalk, []string{"walk", "hik"}},
+	{CategoryStrength, []string{"strength", "weight", "lift", "crossfit", "functional", "calisthenics"}},
+	{CategoryMobility, []string{"yoga", "pilates", "stretch", "mobility"}},
+	{CategoryCardio, []string{"hiit", "cardio", "elliptical", "rowing", "stair", "box", "jump rope", "dance"}},
+	{CategorySport, []string{"tennis", "padel", "squash", "soccer", "football", "basketball", "volleyball", "climb", "golf", "martial"}},
+}
+
+// SportCategory maps a sport name from any source, like "Running",
+// "TRAIL_RUNNING" or "VirtualRide", to one of the categories.
+func SportCategory(sport string) string {
+	var words strings.Builder
+	var previous rune
+	for _, r := range sport {
+		if unicode.IsUpper(r) && unicode.IsLower(previous) {
+			words.WriteRune(' ')
+		}
+		previous = r
+		if r == '_' || r == '-' {
+			r = ' '
+		}
+		words.WriteRune(unicode.ToLower(r))
+	}
+	name := words.String()
+	for _, entry := range categoryKeywords {
+		for _, keyword := range entry.keywords {
+			if strings.Contains(name, keyword) {
+				return entry.category
+			}
+		}
+	}
+	return CategoryOther
+}
+
+// HeartRateStrain estimates a 0-21 strain from the average heart rate, for
+// sources that don't score effort.
+func HeartRateStrain(avgHR int) float64 {
+	if avgHR < 60 {
+		return 0
+	}
+	// Simplified linear-ish mapping for a 1.0-21.0 scale
+	// (AvgHR - 60) / (MaxHR - 60) * 21
+	// Assuming MaxHR around 190
+	maxHR := 190.0
+	minHR := 60.0
+	strain := (float64(avgHR) - minHR) / (maxHR - minHR) * 21.0
+	return math.Max(0, math.Min(strain, 21.0))
+}
+
+func WhoopWorkoutMetrics(record *whoop.WorkoutData) WorkoutMetrics {
+	start, end := record.Start, record.End
+	return WorkoutMetrics{
+		Sport:           record.SportName,
+		Category:        SportCategory(record.SportName),
+		StartedAt:       &start,
+		EndedAt:         &end,
+		DurationMinutes: end.Sub(start).Minutes(),
+		Calories:        record.Score.Kilojoule / 4.184,
+		AvgHeartRate:    record.Score.AverageHeartRate,
+		MaxHeartRate:    record.Score.MaxHeartRate,
+		Strain:          record.Score.Strain,
+		MetricsSource:   MetricsWhoop,
+		Device:          "Whoop",
+	}
+}
+
+func GarminWorkoutMetrics(activity garmin.ActivityData) WorkoutMetrics {
+	duration := time.Duration(activity.DurationInSeconds) * time.Second
+	start := time.Unix(activity.StartTimeInSeconds, 0)
+	end := start.Add(duration)
+	sport := activity.ActivityType
+	if sport == "" {
+		sport = activity.ActivityName
+	}
+	return WorkoutMetrics{
+		Sport:           sport,
+		Category:        SportCategory(sport),
+		StartedAt:       &start,
+		EndedAt:         &end,
+		DurationMinutes: duration.Minutes(),
+		DistanceMeters:  activity.DistanceInMeters,
+		Calories:        activity.Calories,
+		AvgHeartRate:    activity.AverageHeartRate,
+		MaxHeartRate:    activity.MaxHeartRate,
+		Strain:          HeartRateStrain(activity.AverageHeartRate),
+		MetricsSource:   MetricsGarmin,
+		Device:          activity.DeviceName,
+	}
+}
+
+func StravaWorkoutMetrics(activity *strava.ActivityData) WorkoutMetrics {
+	duration := time.Duration(activity.MovingTime) * time.Second
+	sport := activity.SportType
+	if sport == "" {
+		sport = activity.Type
+	}
+	metrics := WorkoutMetrics{
+		Sport:           sport,
+		Category:        SportCategory(sport),
+		DurationMinutes: duration.Minutes(),
+		DistanceMeters:  activity.Distance,
+		Calories:        activity.Calories,
+		AvgHeartRate:    int(math.Round(activity.AverageHeartrate)),
+		MaxHeartRate:    int(math.Round(activity.MaxHeartrate)),
+		MetricsSource:   MetricsStrava,
+		Device:          activity.DeviceName,
+		Manual:          activity.Manual,
+	}
+	if start, err := time.Parse(time.RFC3339, activity.StartDate); err == nil {
+		end := start.Add(time.Duration(activity.ElapsedTime) * time.Second)
+		metrics.StartedAt, metrics.EndedAt = &start, &end
+	}
+	if activity.SufferScore != nil {
+		metrics.Strain = strava.SufferScoreToStrain(*activity.SufferScore)
+	} else {
+		metrics.Strain = HeartRateStrain(metrics.AvgHeartRate)
+	}
+	return metrics
+}
+
+// SaveProviderMetrics stores the metrics on every workout, one per group,
+// that was created from the provider workout.
+func SaveProviderMetrics(source, providerID string, metrics WorkoutMetrics) error {
+	var column string
+	switch source {
+	case MetricsWhoop:
+		column = "whoop_id"
+	case MetricsGarmin:
+		column = "garmin_id"
+		providerID = garmin.NormalizeSummaryID(providerID)
+	case MetricsStrava:
+		column = "strava_id"
+	default:
+		return fmt.Errorf("no provider %q", source)
+	}
+	var workouts []Workout
+	if err := db.DBCon.Where(column+" = ?", providerID).Find(&workouts).Error; err != nil {
+		return err
+	}
+	for _, workout := range workouts {
+		workout.WorkoutMetrics = metrics
+		if err := db.DBCon.Save(&workout).Error; err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// MaxMetricsBackfillFailures is how many times fetching the metrics of a
+// workout may fail before the backfill gives up on it, so workouts the
+// provider can't return don't take up the batch every night.
+const MaxMetricsBackfillFailures = 3
+
+// WorkoutsMissingMetrics returns provider workouts created since the given
+// time that have no metrics yet, at most one per provider workout.
+func WorkoutsMissingMetrics(since time.Time, limit int) ([]Workout, error) {
+	var workouts []Workout
+	err := db.DBCon.
+		Where("created_at > ? AND (metrics_source IS NULL OR metrics_source = ?)", since, "").
+		Where("whoop_id <> ? OR garmin_id <> ? OR strava_id <> ?", "", "", "").
+		Where("metrics_backfill_failures < ?", MaxMetricsBackfillFailures).
+		Order("created_at DESC").
+		Find(&workouts).Error
+	if err != nil {
+		return nil, err
+	}
+	seen := map[string]bool{}
+	var unique []Workout
+	for _, workout := range workouts {
+		key := workout.WhoopID + "|" + workout.GarminID + "|" + workout.StravaID
+		if seen[key] {
+			continue
+		}
+		seen[key] = true
+		unique = append(unique, workout)
+		if len(unique) == limit {
+			break
+		}
+	}
+	return unique, nil
+}
+
+// RecordMetricsBackfillFailure counts a failed fetch on every workout created
+// from the same provider workout.
+func RecordMetricsBackfillFailure(workout Workout) error {
+	return db.DBCon.Model(&Workout{}).
+		Where("whoop_id = ? AND garmin_id = ? AND strava_id = ?", workout.WhoopID, workout.GarminID, workout.StravaID).
+		Update("metrics_backfill_failures", gorm.Expr("metrics_backfill_failures + 1")).Error
+}
+
+// WorkoutTotals sums up the metrics of some workouts. Workouts without
+// metrics still count, they just add nothing else.
+type WorkoutTotals struct {
+	Workouts       int
+	Minutes        float64
+	Calories       float64
+	DistanceMeters float64
+}
+
+func TotalWorkouts(workouts []Workout) WorkoutTotals {
+	totals := WorkoutTotals{Workouts: len(workouts)}
+	for _, workout := range workouts {
+		totals.Minutes += workout.DurationMinutes
+		totals.Calories += workout.Calories
+		totals.DistanceMeters += workout.DistanceMeters
+	}
+	return totals
+}
+
+// AdoptMetrics takes the metrics of a provider workout that was matched to
+// this one. They replace what was read off a screenshot, but not the metrics
+// of another provider.
+func (workout *Workout) AdoptMetrics(metrics WorkoutMetrics) {
+	switch workout.MetricsSource {
+	case "", MetricsAppleWatch:
+		workout.WorkoutMetrics = metrics
+	}
+}
+
+func (workout *Workout) SaveMetrics() error {
+	return db.DBCon.Save(workout).Error
+}
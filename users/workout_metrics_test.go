@@ -0,0 +1,130 @@
+package users
+
+import (
+	"fatbot/db"
+	"fatbot/strava"
+	"fatbot/whoop"
+	"math"
+	"testing"
+	"time"
+)
+
+func TestSportCategory(t *testing.T) {
+	tests := map[string]string{
+		"Running":            CategoryRun,
+		"TRAIL_RUNNING":      CategoryRun,
+		"VirtualRide":        CategoryRide,
+		"Mountain Biking":    CategoryRide,
+		"Pool Swimming":      CategorySwim,
+		"Hiking":             CategoryWalk,
+		"WeightTraining":     CategoryStrength,
+		"Functional Fitness": CategoryStrength,
+		"STRENGTH_TRAINING":  CategoryStrength,
+		"Yoga":               CategoryMobility,
+		"HIIT":               CategoryCardio,
+		"Padel":              CategorySport,
+		"Activity":           CategoryOther,
+		"":                   CategoryOther,
+	}
+	for sport, want := range tests {
+		if got := SportCategory(sport); got != want {
+			t.Errorf("SportCategory(%q) = %q, want %q", sport, got, want)
+		}
+	}
+}
+
+func TestProviderWorkoutMetrics(t *testing.T) {
+	start := time.Date(2024, 5, 18, 7, 0, 0, 0, time.UTC)
+	whoopMetrics := WhoopWorkoutMetrics(&whoop.WorkoutData{
+		SportName: "Running",
+		Start:     start,
+		End:       start.Add(45 * time.Minute),
+		Score:     whoop.WorkoutScore{Strain: 12.5, Kilojoule: 2092, AverageHeartRate: 150, MaxHeartRate: 181},
+	})
+	if whoopMetrics.DurationMinutes != 45 || whoopMetrics.Calories != 500 || whoopMetrics.MaxHeartRate != 181 ||
+		whoopMetrics.Category != CategoryRun || whoopMetrics.MetricsSource != MetricsWhoop || !whoopMetrics.StartedAt.Equal(start) {
+		t.Errorf("got Whoop metrics %+v", whoopMetrics)
+	}
+
+	sufferScore := 100.0
+	stravaMetrics := StravaWorkoutMetrics(&strava.ActivityData{
+		SportType:        "Ride",
+		MovingTime:       3600,
+		ElapsedTime:      4200,
+		Distance:         30000,
+		AverageHeartrate: 139.6,
+		StartDate:        "2024-05-18T07:00:00Z",
+		SufferScore:      &sufferScore,
+		Manual:           true,
+	})
+	if stravaMetrics.DurationMinutes != 60 || stravaMetrics.AvgHeartRate != 140 || math.Abs(stravaMetrics.Strain-14) > 0.001 ||
+		!stravaMetrics.Manual || stravaMetrics.EndedAt.Sub(*stravaMetrics.StartedAt) != 70*time.Minute {
+		t.Errorf("got Strava metrics %+v", stravaMetrics)
+	}
+}
+
+func TestSaveProviderMetrics(t *testing.T) {
+	// Comes with a Strava workout without metrics and one from a photo
+	user, group := openAccountTestDB(t)
+	other := Group{ChatID: -200, Title: "Runners"}
+	database := db.DBCon
+	database.Create(&other)
+	for _, groupID := range []uint{group.ID, other.ID} {
+		database.Create(&Workout{UserID: user.ID, GroupID: groupID, WhoopID: "w1"})
+	}
+	database.Create(&Workout{UserID: user.ID, GroupID: group.ID, StravaID: "s2",
+		WorkoutMetrics: WorkoutMetrics{MetricsSource: MetricsStrava, DurationMinutes: 30}})
+	database.Create(&Workout{UserID: user.ID, GroupID: group.ID})
+
+	missing, err := WorkoutsMissingMetrics(time.Now().Add(-time.Hour), 10)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(missing) != 2 || missing[0].WhoopID != "w1" || missing[1].StravaID != "s1" {
+		t.Fatalf("got %+v missing metrics, want the Whoop workout once and s1", missing)
+	}
+
+	metrics := WorkoutMetrics{Sport: "Running", DurationMinutes: 45, MetricsSource: MetricsWhoop}
+	if err := SaveProviderMetrics(MetricsWhoop, "w1", metrics); err != nil {
+		t.Fatal(err)
+	}
+	var workouts []Workout
+	database.Where("whoop_id = ?", "w1").Find(&workouts)
+	for _, workout := range workouts {
+		if workout.Sport != "Running" || workout.DurationMinutes != 45 {
+			t.Errorf("workout %d has metrics %+v", workout.ID, workout.WorkoutMetrics)
+		}
+	}
+	missing, _ = WorkoutsMissingMetrics(time.Now().Add(-time.Hour), 10)
+	if len(missing) != 1 {
+		t.Fatalf("%d workouts missing metrics, want only s1", len(missing))
+	}
+	for range MaxMetricsBackfillFailures {
+		if err := RecordMetricsBackfillFailure(missing[0]); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if missing, _ := WorkoutsMissingMetrics(time.Now().Add(-time.Hour), 10); len(missing) != 0 {
+		t.Errorf("got %+v missing metrics, want s1 given up after failing", missing)
+	}
+
+	var all []Workout
+	database.Find(&all)
+	if totals := TotalWorkouts(all); totals.Workouts != 6 || totals.Minutes != 120 {
+		t.Errorf("got totals %+v", totals)
+	}
+}
+
+func TestAdoptMetrics(t *testing.T) {
+	whoopMetrics := WorkoutMetrics{MetricsSource: MetricsWhoop, Strain: 12}
+	photo := Workout{WorkoutMetrics: WorkoutMetrics{MetricsSource: MetricsAppleWatch, Strain: 8}}
+	photo.AdoptMetrics(whoopMetrics)
+	if photo.Strain != 12 {
+		t.Errorf("screenshot metrics weren't replaced: %+v", photo.WorkoutMetrics)
+	}
+	garmin := Workout{WorkoutMetrics: WorkoutMetrics{MetricsSource: MetricsGarmin, Strain: 9}}
+	garmin.AdoptMetrics(whoopMetrics)
+	if garmin.Strain != 9 {
+		t.Errorf("another provider's metrics were replaced: %+v", garmin.WorkoutMetrics)
+	}
+}
@@ -2,22 +2,26 @@ package users
 
 import "time"
 
-func IsLastWorkoutOverdue(lastWorkout time.Time) (bool, int) {
+// IsLastWorkoutOverdue reports whether more than windowDays calendar days
+// have passed since the last workout, counting days in the given location.
+func IsLastWorkoutOverdue(lastWorkout time.Time, windowDays int, location *time.Location) (bool, int) {
+	lastWorkout = lastWorkout.In(location)
+	now := time.Now().In(location)
 	lastWorkoutDay := time.Date(
 		lastWorkout.Year(),
 		lastWorkout.Month(),
 		lastWorkout.Day(),
 		0, 0, 0, 0,
-		lastWorkout.Location(),
+		location,
 	)
 	currentDay := time.Date(
-		time.Now().Year(),
-		time.Now().Month(),
-		time.Now().Day(),
+		now.Year(),
+		now.Month(),
+		now.Day(),
 		0, 0, 0, 0,
-		time.Now().Location(),
+		location,
 	)
 
 	daysDiff := int(currentDay.Sub(lastWorkoutDay).Hours() / 24)
-	return daysDiff > 5, daysDiff
+	return daysDiff > windowDays, daysDiff
 }
@@ -2,13 +2,13 @@ package users
 
 import (
 	"fatbot/db"
+	"fatbot/metrics"
 	"fmt"
 	"time"
 
 	"github.com/charmbracelet/log"
 	"github.com/getsentry/sentry-go"
 	tgbotapi "github.com/go-telegram-bot-api/telegram-bot-api/v5"
-	"github.com/spf13/viper"
 
 	"gorm.io/gorm"
 )
@@ -34,41 +34,50 @@ type Workout struct {
 	StravaID        string
 	NotifyMessageID int   // Telegram message ID of the bot's group notification (for editing)
 	NotifyChatID    int64 // Chat ID where the notification was sent
+	Note            string
+	PhotoHash       string `gorm:"index"` // Perceptual hash of the photo, see imagehash
+	DuplicateOfID   uint   // The earlier workout whose photo this one recycles
+	Plausibility    int    // How much the photo looks like a workout, 0-100
+	Review          string `gorm:"index"` // Pending, approved or rejected by an admin, empty if never queued
+	// Failed attempts to fetch the metrics again, see WorkoutsMissingMetrics
+	MetricsBackfillFailures int `gorm:"default:0"`
+	WorkoutMetrics
 }
 
-func getLastCycleExactTime() time.Time {
-	reportHour := viper.GetInt("report.hour")
-	reportWeekDay := viper.GetString("report.day")
-	timezone := viper.GetString("timezone")
-	location, _ := time.LoadLocation(timezone)
-	var lastCycleStartDate time.Time
-	if time.Now().Weekday().String() == reportWeekDay && time.Now().In(location).Hour() >= reportHour {
-		lastCycleStartDate = time.Now()
-	} else {
-		daysSinceCycleStart := int(time.Now().Weekday()) + 1
-		lastCycleStartDate = time.Now().AddDate(0, 0, -int(daysSinceCycleStart))
+// Source names where the workout came from.
+func (workout Workout) Source() string {
+	switch {
+	case workout.WhoopID != "":
+		return "whoop"
+	case workout.GarminID != "":
+		return "garmin"
+	case workout.StravaID != "":
+		return "strava"
+	case workout.Flagged:
+		return "immunity"
+	case workout.MetricsSource == MetricsManual:
+		return "manual"
 	}
-	return time.Date(
-		lastCycleStartDate.Year(),
-		lastCycleStartDate.Month(),
-		lastCycleStartDate.Day(),
-		reportHour, 0, 0, 0,
-		location)
+	return "photo"
+}
+
+func (workout *Workout) AfterCreate(tx *gorm.DB) error {
+	metrics.WorkoutsCreated.WithLabelValues(workout.Source()).Inc()
+	return nil
 }
 
 func (user *User) LoadWorkoutsThisMonthlyCycle(chatId int64) error {
-	timezone := viper.GetString("timezone")
-	location, _ := time.LoadLocation(timezone)
-	thisMonthsFirstDay := time.Date(
-		time.Now().Year(),
-		time.Now().Month(),
-		1,
-		0, 0, 0, 0,
-		location)
 	group, err := GetGroup(chatId)
 	if err != nil {
 		return err
 	}
+	now := time.Now().In(group.GetRules().Location())
+	thisMonthsFirstDay := time.Date(
+		now.Year(),
+		now.Month(),
+		1,
+		0, 0, 0, 0,
+		now.Location())
 	db := db.DBCon
 	if err := db.Model(&User{}).
 		Preload(
@@ -85,11 +94,11 @@ func (user *User) LoadWorkoutsThisMonthlyCycle(chatId int64) error {
 }
 
 func (user *User) LoadWorkoutsThisCycle(chatId int64) error {
-	lastCycleExactTime := getLastCycleExactTime()
 	group, err := GetGroup(chatId)
 	if err != nil {
 		return err
 	}
+	lastCycleExactTime := group.GetRules().CycleStart(time.Now())
 	db := db.DBCon
 	if err := db.Model(&User{}).
 		Preload(
@@ -104,15 +113,14 @@ func (user *User) LoadWorkoutsThisCycle(chatId int64) error {
 }
 
 func (user *User) LoadWorkoutsReportCycle(chatId int64) error {
-	// The report runs at the start of a new cycle, so we want the PREVIOUS cycle.
-	// getLastCycleExactTime() returns the start of the CURRENT cycle (e.g., Sat 20:00 Today).
-	cycleFinish := getLastCycleExactTime()
-	cycleStart := cycleFinish.AddDate(0, 0, -7)
-
 	group, err := GetGroup(chatId)
 	if err != nil {
 		return err
 	}
+	// The report runs at the start of a new cycle, so we want the PREVIOUS cycle.
+	// CycleStart returns the start of the CURRENT cycle (e.g., Sat 20:00 Today).
+	cycleFinish := group.GetRules().CycleStart(time.Now())
+	cycleStart := cycleFinish.AddDate(0, 0, -7)
 	db := db.DBCon
 	if err := db.Model(&User{}).
 		Preload(
@@ -130,16 +138,15 @@ func (user *User) LoadWorkoutsReportCycle(chatId int64) error {
 
 func (user *User) GetPreviousWeekWorkouts(chatId int64) []Workout {
 	db := db.DBCon
-	timezone := viper.GetString("timezone")
-	location, _ := time.LoadLocation(timezone)
-	previousWeeksStart := time.Now().In(location).Add(time.Duration(-14) * time.Hour * 24)
-	previousWeeksEnd := time.Now().In(location).Add(time.Duration(-7) * time.Hour * 24)
 	group, err := GetGroup(chatId)
 	if err != nil {
 		log.Error(err)
 		sentry.CaptureException(err)
 		return []Workout{}
 	}
+	location := group.GetRules().Location()
+	previousWeeksStart := time.Now().In(location).Add(time.Duration(-14) * time.Hour * 24)
+	previousWeeksEnd := time.Now().In(location).Add(time.Duration(-7) * time.Hour * 24)
 	if err := db.Model(&User{}).
 		Preload("Workouts", "created_at > ? AND created_at < ? AND group_id = ? AND flagged = ?",
 			previousWeeksStart, previousWeeksEnd, group.ID, false).
@@ -155,7 +162,7 @@ func (user *User) FlagLastWorkout(chatId int64) error {
 	if err != nil {
 		return err
 	}
-	err = db.Model(&workout).Update("flagged", 1).Error
+	err = db.Model(&workout).Update("flagged", true).Error
 	if err != nil {
 		return err
 	}
@@ -187,15 +194,6 @@ func (user *User) PushWorkout(days, chatId int64) error {
 	return nil
 }
 
-func IsTodayOrWasYesterday(someDate time.Time) bool {
-	today := time.Now().YearDay()
-	workout := someDate.YearDay()
-	if today == 1 {
-		return workout == 1 || workout == 365
-	}
-	return today-1 == workout || today == workout
-}
-
 func IsSameDay(date1, date2 time.Time) bool {
 	y1, m1, d1 := date1.Date()
 	y2, m2, d2 := date2.Date()
@@ -231,21 +229,11 @@ func (user *User) UpdateWorkout(update tgbotapi.Update, lastWorkout Workout) (Wo
 		return lastWorkout, err
 	}
 
-	var streak int
-	if IsTodayOrWasYesterday(lastWorkout.CreatedAt) {
-		if lastWorkout.Streak > 0 {
-			streak = lastWorkout.Streak + 1
-		} else {
-			streak = 2
-		}
-	}
-
 	workout := &Workout{
 		UserID:         user.ID,
 		PhotoMessageID: messageId,
 		PhotoFileID:    fileId,
 		GroupID:        group.ID,
-		Streak:         streak,
 	}
 	db.Model(&user).Association("Workouts").Append(workout)
 	return *workout, nil
@@ -270,6 +258,14 @@ func (user *User) GetLastWorkout() (Workout, error) {
 	return Workout{}, &NoWorkoutsError{Name: user.GetName()}
 }
 
+// WorkoutsWithPhoto scopes workouts to the ones that have a photo attached,
+// for use as a subquery. Missing photos are stored as NULL, an empty string
+// or, in old rows, the string "NULL".
+func WorkoutsWithPhoto() *gorm.DB {
+	return db.DBCon.Model(&Workout{}).
+		Where("workouts.photo_file_id IS NOT NULL AND workouts.photo_file_id <> ? AND workouts.photo_file_id <> ?", "", "NULL")
+}
+
 func (user *User) GetLastXWorkout(lastx int, chatId int64) (Workout, error) {
 	db := db.DBCon
 	group, err := GetGroup(chatId)
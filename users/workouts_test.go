@@ -1,6 +1,7 @@
 package users
 
 import (
+	"fatbot/db"
 	"fmt"
 	"testing"
 	"time"
@@ -61,3 +62,17 @@ func TestIsOlderThan(t *testing.T) {
 		})
 	}
 }
+
+func TestWorkoutsWithPhoto(t *testing.T) {
+	user, group := openAccountTestDB(t)
+	for _, fileID := range []string{"", "NULL"} {
+		db.DBCon.Create(&Workout{UserID: user.ID, GroupID: group.ID, PhotoFileID: fileID})
+	}
+	var fileIDs []string
+	if err := WorkoutsWithPhoto().Pluck("photo_file_id", &fileIDs).Error; err != nil {
+		t.Fatal(err)
+	}
+	if len(fileIDs) != 1 || fileIDs[0] != "photo" {
+		t.Errorf("got %q, want only the real photo", fileIDs)
+	}
+}
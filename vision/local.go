@@ -0,0 +1,108 @@
+package vision
+
+import (
+	"bytes"
+	"context"
+	"fatbot/metrics"
+	"fmt"
+	"os/exec"
+	"regexp"
+	"strings"
+	"time"
+
+	"github.com/spf13/viper"
+)
+
+// Local analyzes photos offline. The text comes from the tesseract command
+// line OCR, vision.local.tesseract in config.yaml, and the labels from the
+// words in that text, so photos without text get no labels. It can't tell
+// unsafe photos. A tesseract run that takes longer than
+// vision.timeout_seconds is killed.
+type Local struct {
+	tesseract string
+	timeout   time.Duration
+}
+
+func NewLocal() *Local {
+	tesseract := viper.GetString("vision.local.tesseract")
+	if tesseract == "" {
+		tesseract = "tesseract"
+	}
+	timeout := time.Duration(viper.GetInt("vision.timeout_seconds")) * time.Second
+	if timeout <= 0 {
+		timeout = 30 * time.Second
+	}
+	return &Local{tesseract: tesseract, timeout: timeout}
+}
+
+func (l *Local) Name() string {
+	return "local"
+}
+
+func (l *Local) Analyze(imageBytes []byte) (analysis Analysis, err error) {
+	analysis.Text, err = l.readText(imageBytes)
+	analysis.Labels = labelsFromText(analysis.Text)
+	return analysis, err
+}
+
+// readText runs tesseract on the photo, reading it from stdin and writing
+// the text to stdout.
+func (l *Local) readText(imageBytes []byte) ([]string, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), l.timeout)
+	defer cancel()
+	cmd := exec.CommandContext(ctx, l.tesseract, "stdin", "stdout")
+	cmd.Stdin = bytes.NewReader(imageBytes)
+	var stderr bytes.Buffer
+	cmd.Stderr = &stderr
+	start := time.Now()
+	output, err := cmd.Output()
+	metrics.ObserveAI("tesseract", "detect_text", start, err)
+	if ctx.Err() == context.DeadlineExceeded {
+		return nil, fmt.Errorf("tesseract timed out after %s", l.timeout)
+	}
+	if err != nil {
+		if stderr.Len() > 0 {
+			return nil, fmt.Errorf("%w: %s", err, strings.TrimSpace(stderr.String()))
+		}
+		return nil, err
+	}
+	var lines []string
+	for _, line := range strings.Split(string(output), "\n") {
+		if line = strings.TrimSpace(line); line != "" {
+			lines = append(lines, line)
+		}
+	}
+	return lines, nil
+}
+
+// textLabels are the labels that words on a photo stand for, mostly from
+// fitness app and watch screenshots.
+var textLabels = []struct {
+	pattern *regexp.Regexp
+	label   string
+}{
+	{regexp.MustCompile(`(?i)\b(run|running|jog|pace|marathon)\b`), "Running"},
+	{regexp.MustCompile(`(?i)\b(ride|cycling|bike|bicycle)\b`), "Cycling"},
+	{regexp.MustCompile(`(?i)\b(swim|swimming|pool|laps)\b`), "Swimming"},
+	{regexp.MustCompile(`(?i)\b(walk|walking|hike|hiking|steps)\b`), "Hiking"},
+	{regexp.MustCompile(`(?i)\b(yoga|pilates|stretch\w*)\b`), "Yoga"},
+	{regexp.MustCompile(`(?i)\b(strength|weights|reps|sets|lift\w*)\b`), "Weights"},
+	{regexp.MustCompile(`(?i)\b(workout|training|kcal|bpm|heart rate|strain)\b`), "Fitness"},
+}
+
+// labelsFromText is the offline classifier, it finds labels in the words of
+// the photo's text. Any text at all makes it a "Text" photo, like a
+// screenshot.
+func labelsFromText(lines []string) []string {
+	if len(lines) == 0 {
+		return nil
+	}
+	text := strings.Join(lines, "\n")
+	labels := []string{"Text"}
+	for _, textLabel := range textLabels {
+		if textLabel.pattern.MatchString(text) {
+			labels = append(labels, textLabel.label)
+		}
+	}
+	return labels
+}
@@ -0,0 +1,120 @@
+package vision
+
+import (
+	"bytes"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fatbot/ai"
+	"fatbot/metrics"
+	"fmt"
+	"io"
+	"net/http"
+	"time"
+
+	"github.com/spf13/viper"
+)
+
+const openAIVisionPrompt = `Describe this photo for a workout accountability group. Answer with a JSON object with:
+"labels": up to 20 short English labels of what's in the photo, most prominent first, like "Gym", "Running", "Person", "Cat";
+"text": the lines of text on the photo, top to bottom, empty if there's none;
+"unsafe": the kinds of adult, violent or otherwise unsafe content in the photo, empty if it's safe.`
+
+// OpenAI analyzes photos with an OpenAI vision model. The model is
+// vision.openai.model in config.yaml.
+type OpenAI struct {
+	url    string
+	client *http.Client
+}
+
+func NewOpenAI() *OpenAI {
+	return &OpenAI{
+		url:    "https://api.openai.com/v1/chat/completions",
+		client: &http.Client{Timeout: 60 * time.Second},
+	}
+}
+
+func (o *OpenAI) Name() string {
+	return "openai"
+}
+
+type openAIContent struct {
+	Type     string            `json:"type"`
+	Text     string            `json:"text,omitempty"`
+	ImageURL map[string]string `json:"image_url,omitempty"`
+}
+
+type openAIRequest struct {
+	Model          string            `json:"model"`
+	Messages       []openAIMessage   `json:"messages"`
+	ResponseFormat map[string]string `json:"response_format"`
+}
+
+type openAIMessage struct {
+	Role    string          `json:"role"`
+	Content []openAIContent `json:"content"`
+}
+
+type openAIResponse struct {
+	Choices []struct {
+		Message struct {
+			Content string `json:"content"`
+		} `json:"message"`
+	} `json:"choices"`
+	Error *struct {
+		Message string `json:"message"`
+	} `json:"error"`
+}
+
+func (o *OpenAI) Analyze(imageBytes []byte) (analysis Analysis, err error) {
+	model := viper.GetString("vision.openai.model")
+	if model == "" {
+		model = "gpt-4o-mini"
+	}
+	body, err := json.Marshal(openAIRequest{
+		Model: model,
+		Messages: []openAIMessage{{
+			Role: "user",
+			Content: []openAIContent{
+				{Type: "text", Text: openAIVisionPrompt},
+				{Type: "image_url", ImageURL: map[string]string{
+					"url": "data:" + http.DetectContentType(imageBytes) + ";base64," + base64.StdEncoding.EncodeToString(imageBytes),
+				}},
+			},
+		}},
+		ResponseFormat: map[string]string{"type": "json_object"},
+	})
+	if err != nil {
+		return analysis, err
+	}
+
+	start := time.Now()
+	defer func() { metrics.ObserveAI("openai", "vision", start, err) }()
+	request, err := http.NewRequest(http.MethodPost, o.url, bytes.NewReader(body))
+	if err != nil {
+		return analysis, err
+	}
+	request.Header.Set("Content-Type", "application/json")
+	request.Header.Set("Authorization", "Bearer "+ai.OpenAIToken())
+	resp, err := o.client.Do(request)
+	if err != nil {
+		return analysis, err
+	}
+	defer resp.Body.Close()
+	respBody, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return analysis, err
+	}
+	var response openAIResponse
+	if err := json.Unmarshal(respBody, &response); err != nil {
+		return analysis, fmt.Errorf("openai vision returned %s: %w", resp.Status, err)
+	}
+	if response.Error != nil {
+		return analysis, fmt.Errorf("openai vision returned %s: %s", resp.Status, response.Error.Message)
+	}
+	if len(response.Choices) == 0 {
+		return analysis, errors.New("openai vision returned no choices")
+	}
+	err = json.Unmarshal([]byte(response.Choices[0].Message.Content), &analysis)
+	return analysis, err
+}
@@ -0,0 +1,141 @@
+package vision
+
+import (
+	"errors"
+	"fatbot/metrics"
+	"strings"
+	"time"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/awserr"
+	"github.com/aws/aws-sdk-go/aws/session"
+	"github.com/aws/aws-sdk-go/service/rekognition"
+	"github.com/charmbracelet/log"
+)
+
+// Rekognition analyzes photos with AWS Rekognition, using the credentials
+// and region from the environment.
+type Rekognition struct {
+	svc *rekognition.Rekognition
+}
+
+func NewRekognition() *Rekognition {
+	return &Rekognition{svc: rekognition.New(session.New())}
+}
+
+func (r *Rekognition) Name() string {
+	return "rekognition"
+}
+
+func (r *Rekognition) Analyze(imageBytes []byte) (analysis Analysis, err error) {
+	image := &rekognition.Image{Bytes: imageBytes}
+	var labelsErr, textErr, unsafeErr error
+	analysis.Labels, labelsErr = r.detectLabels(image)
+	analysis.Text, textErr = r.detectText(image)
+	analysis.Unsafe, unsafeErr = r.detectUnsafe(image)
+	return analysis, errors.Join(labelsErr, textErr, unsafeErr)
+}
+
+func (r *Rekognition) detectLabels(image *rekognition.Image) ([]string, error) {
+	input := &rekognition.DetectLabelsInput{
+		Image:         image,
+		MaxLabels:     aws.Int64(50),
+		MinConfidence: aws.Float64(80.000000),
+	}
+
+	start := time.Now()
+	result, err := r.svc.DetectLabels(input)
+	metrics.ObserveAI("rekognition", "detect_labels", start, err)
+	if err != nil {
+		logRekognitionError(err)
+		return nil, err
+	}
+
+	answer := []string{}
+	unwantedLabels := map[string]byte{
+		"adult":  0,
+		"male":   0,
+		"female": 0,
+	}
+	for _, label := range result.Labels {
+		if _, ok := unwantedLabels[strings.ToLower(*label.Name)]; ok {
+			continue
+		}
+		answer = append(answer, *label.Name)
+	}
+	return answer, nil
+}
+
+func (r *Rekognition) detectText(image *rekognition.Image) ([]string, error) {
+	input := &rekognition.DetectTextInput{
+		Image: image,
+	}
+
+	start := time.Now()
+	result, err := r.svc.DetectText(input)
+	metrics.ObserveAI("rekognition", "detect_text", start, err)
+	if err != nil {
+		return nil, err
+	}
+
+	var detectedText []string
+	for _, text := range result.TextDetections {
+		if *text.Type == "LINE" {
+			detectedText = append(detectedText, *text.DetectedText)
+		}
+	}
+	return detectedText, nil
+}
+
+func (r *Rekognition) detectUnsafe(image *rekognition.Image) ([]string, error) {
+	input := &rekognition.DetectModerationLabelsInput{
+		Image:         image,
+		MinConfidence: aws.Float64(80.000000),
+	}
+
+	start := time.Now()
+	result, err := r.svc.DetectModerationLabels(input)
+	metrics.ObserveAI("rekognition", "detect_moderation_labels", start, err)
+	if err != nil {
+		return nil, err
+	}
+
+	var unsafe []string
+	for _, label := range result.ModerationLabels {
+		// Top level categories only, their children repeat them in detail
+		if label.ParentName == nil || *label.ParentName == "" {
+			unsafe = append(unsafe, *label.Name)
+		}
+	}
+	return unsafe, nil
+}
+
+// logRekognitionError tells the AWS error codes apart, most of them point at
+// the setup rather than the photo.
+func logRekognitionError(err error) {
+	aerr, ok := err.(awserr.Error)
+	if !ok {
+		log.Error(err.Error())
+		return
+	}
+	switch aerr.Code() {
+	case rekognition.ErrCodeInvalidS3ObjectException:
+		log.Error(rekognition.ErrCodeInvalidS3ObjectException, aerr.Error())
+	case rekognition.ErrCodeInvalidParameterException:
+		log.Error(rekognition.ErrCodeInvalidParameterException, aerr.Error())
+	case rekognition.ErrCodeImageTooLargeException:
+		log.Error(rekognition.ErrCodeImageTooLargeException, aerr.Error())
+	case rekognition.ErrCodeAccessDeniedException:
+		log.Error(rekognition.ErrCodeAccessDeniedException, aerr.Error())
+	case rekognition.ErrCodeInternalServerError:
+		log.Error(rekognition.ErrCodeInternalServerError, aerr.Error())
+	case rekognition.ErrCodeThrottlingException:
+		log.Error(rekognition.ErrCodeThrottlingException, aerr.Error())
+	case rekognition.ErrCodeProvisionedThroughputExceededException:
+		log.Error(rekognition.ErrCodeProvisionedThroughputExceededException, aerr.Error())
+	case rekognition.ErrCodeInvalidImageFormatException:
+		log.Error(rekognition.ErrCodeInvalidImageFormatException, aerr.Error())
+	default:
+		log.Error(aerr.Error())
+	}
+}
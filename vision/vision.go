@@ -0,0 +1,105 @@
+// Package vision analyzes workout photos: what's in them, the text on them
+// and whether they're safe to show.
+package vision
+
+import (
+	"encoding/json"
+	"fatbot/state"
+	"fmt"
+
+	"github.com/charmbracelet/log"
+	"github.com/getsentry/sentry-go"
+	"github.com/spf13/viper"
+)
+
+// Analysis is what an analyzer found in a photo.
+type Analysis struct {
+	Labels []string `json:"labels"`
+	// Text has the lines of text on the photo, top to bottom.
+	Text []string `json:"text"`
+	// Unsafe has the moderation labels of adult, violent or otherwise unsafe
+	// content, empty when the photo is safe.
+	Unsafe []string `json:"unsafe"`
+}
+
+// Analyzer is an image analysis backend. When a part of the analysis fails
+// it returns the rest along with the error.
+type Analyzer interface {
+	Name() string
+	Analyze(imageBytes []byte) (Analysis, error)
+}
+
+var analyzer Analyzer
+
+// Init sets up the analyzer from vision.backend in config.yaml, either
+// rekognition (default), openai or local.
+func Init() error {
+	switch backend := viper.GetString("vision.backend"); backend {
+	case "", "rekognition":
+		analyzer = NewRekognition()
+	case "openai":
+		analyzer = NewOpenAI()
+	case "local":
+		analyzer = NewLocal()
+	default:
+		return fmt.Errorf("unknown vision backend %s, expected rekognition, openai or local", backend)
+	}
+	return nil
+}
+
+// SetAnalyzer replaces the backend, e.g. with a Static one in tests.
+func SetAnalyzer(newAnalyzer Analyzer) {
+	analyzer = newAnalyzer
+}
+
+func cacheKey(fileUniqueID string) string {
+	return "vision:" + fileUniqueID
+}
+
+// Analyze runs the analyzer on the photo. Telegram keeps the unique file id
+// of a photo when it's forwarded, so the analysis is cached by it and the
+// same photo isn't analyzed twice. Failures are logged and leave out what
+// couldn't be found, an upload never fails for them, and they aren't cached.
+func Analyze(fileUniqueID string, imageBytes []byte) (analysis Analysis) {
+	if analyzer == nil {
+		log.Error("vision analyzer is not initialized")
+		return
+	}
+	if fileUniqueID != "" {
+		if cached, err := state.Get(cacheKey(fileUniqueID)); err == nil && cached != "" {
+			if err := json.Unmarshal([]byte(cached), &analysis); err == nil {
+				return analysis
+			}
+		}
+	}
+	analysis, err := analyzer.Analyze(imageBytes)
+	if err != nil {
+		log.Errorf("%s failed to analyze the photo: %s", analyzer.Name(), err)
+		sentry.CaptureException(err)
+		return analysis
+	}
+	ttl := viper.GetInt("vision.cache_hours") * 3600
+	if fileUniqueID == "" || ttl <= 0 {
+		return analysis
+	}
+	if value, err := json.Marshal(analysis); err == nil {
+		if err := state.SetWithTTL(cacheKey(fileUniqueID), string(value), ttl); err != nil {
+			log.Warnf("Failed to cache the analysis of %s: %s", fileUniqueID, err)
+		}
+	}
+	return analysis
+}
+
+// Static is an analyzer that finds the same thing in every photo, for tests
+// and local development.
+type Static struct {
+	Analysis Analysis
+}
+
+func (static Static) Name() string {
+	return "static"
+}
+
+func (static Static) Analyze(imageBytes []byte) (Analysis, error) {
+	return static.Analysis, nil
+}
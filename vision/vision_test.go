@@ -0,0 +1,93 @@
+package vision
+
+import (
+	"errors"
+	"fatbot/state"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/spf13/viper"
+)
+
+type countingAnalyzer struct {
+	Static
+	calls int
+	err   error
+}
+
+func (c *countingAnalyzer) Analyze(imageBytes []byte) (Analysis, error) {
+	c.calls++
+	return c.Analysis, c.err
+}
+
+func TestAnalyzeCachesByFileUniqueID(t *testing.T) {
+	state.SetStore(state.NewMemoryStore())
+	viper.Set("vision.cache_hours", 1)
+	defer viper.Set("vision.cache_hours", nil)
+	counting := &countingAnalyzer{Static: Static{Analysis: Analysis{
+		Labels: []string{"Gym", "Person"},
+		Text:   []string{"152 BPM"},
+	}}}
+	SetAnalyzer(counting)
+
+	first := Analyze("AQADf", []byte("photo"))
+	again := Analyze("AQADf", []byte("forwarded photo"))
+	if counting.calls != 1 {
+		t.Errorf("analyzed %d times, want the forwarded photo from the cache", counting.calls)
+	}
+	if !reflect.DeepEqual(first, counting.Analysis) || !reflect.DeepEqual(again, first) {
+		t.Errorf("got %+v and %+v, want %+v", first, again, counting.Analysis)
+	}
+
+	Analyze("", []byte("photo without id"))
+	Analyze("", []byte("photo without id"))
+	if counting.calls != 3 {
+		t.Errorf("analyzed %d times, photos without an id can't be cached", counting.calls)
+	}
+
+	counting.err = errors.New("text detection failed")
+	Analyze("AQADg", []byte("other photo"))
+	Analyze("AQADg", []byte("other photo"))
+	if counting.calls != 5 {
+		t.Errorf("analyzed %d times, failed analyses shouldn't be cached", counting.calls)
+	}
+}
+
+func TestLabelsFromText(t *testing.T) {
+	got := labelsFromText([]string{"Outdoor Run", "5.02 KM", "Avg Heart Rate 152 BPM"})
+	want := []string{"Text", "Running", "Fitness"}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("got %v, want %v", got, want)
+	}
+	if got := labelsFromText(nil); got != nil {
+		t.Errorf("got %v for a photo without text, want no labels", got)
+	}
+}
+
+func TestInitRejectsUnknownBackend(t *testing.T) {
+	viper.Set("vision.backend", "crystal-ball")
+	defer viper.Set("vision.backend", nil)
+	if err := Init(); err == nil {
+		t.Error("an unknown backend was accepted")
+	}
+}
+
+func TestLocalReadTextTimesOut(t *testing.T) {
+	tesseract := filepath.Join(t.TempDir(), "tesseract")
+	if err := os.WriteFile(tesseract, []byte("#!/bin/sh\nexec sleep 10\n"), 0o755); err != nil {
+		t.Fatal(err)
+	}
+	local := &Local{tesseract: tesseract, timeout: 100 * time.Millisecond}
+	start := time.Now()
+	_, err := local.readText([]byte("photo"))
+	if err == nil || !strings.Contains(err.Error(), "timed out") {
+		t.Errorf("got %v, want a timeout", err)
+	}
+	if elapsed := time.Since(start); elapsed > 5*time.Second {
+		t.Errorf("took %s, want tesseract killed", elapsed)
+	}
+}
@@ -5,6 +5,7 @@ import (
 	"crypto/sha256"
 	"encoding/base64"
 	"encoding/json"
+	"fatbot/metrics"
 	"fmt"
 	"io"
 	"net/http"
@@ -27,13 +28,13 @@ const (
 // httpClient is a shared client reused across all Whoop API calls.
 // A single client with a bounded transport is far cheaper than creating
 // a new &http.Client{} per call, which leaks idle TCP connections.
-var httpClient = &http.Client{
+var httpClient = metrics.InstrumentClient("whoop", &http.Client{
 	Timeout: 30 * time.Second,
 	Transport: &http.Transport{
 		MaxIdleConns:    5,
 		IdleConnTimeout: 60 * time.Second,
 	},
-}
+})
 
 type TokenResponse struct {
 	AccessToken  string `json:"access_token"`
@@ -127,7 +128,7 @@ func ExchangeToken(code string) (*TokenResponse, error) {
 	data.Set("client_secret", clientSecret)
 	data.Set("redirect_uri", redirectURI)
 
-	resp, err := http.PostForm(TokenURL, data)
+	resp, err := httpClient.PostForm(TokenURL, data)
 	if err != nil {
 		return nil, err
 	}
@@ -155,7 +156,7 @@ func RefreshToken(refreshToken string) (*TokenResponse, error) {
 	data.Set("client_secret", clientSecret)
 	data.Set("scope", Scope)
 
-	resp, err := http.PostForm(TokenURL, data)
+	resp, err := httpClient.PostForm(TokenURL, data)
 	if err != nil {
 		return nil, err
 	}